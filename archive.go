@@ -0,0 +1,69 @@
+package alpinebits
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/HGV/alpinebits/archive"
+)
+
+// DefaultArchiveQueueSize is the number of exchanges waiting to be archived
+// before further ones are dropped.
+const DefaultArchiveQueueSize = archive.DefaultQueueSize
+
+// WithArchive stores the raw request and response of every request routed to
+// an action in a. Exchanges are queued once they have been served and stored
+// by a background goroutine, so a slow archive does not delay the response.
+// Exchanges are dropped while the queue is full and failures are only logged.
+// Call Router.CloseArchive on shutdown to store the queued exchanges.
+func WithArchive(a archive.Archive) RouterFunc {
+	return func(r *Router) {
+		r.archive = a
+	}
+}
+
+// WithArchiveMaxRequestSize archives only the first n bytes of larger requests,
+// so they are not held in memory until they are stored. The entries are
+// marked as truncated. Zero, the default, archives requests whole.
+func WithArchiveMaxRequestSize(n int64) RouterFunc {
+	return func(r *Router) {
+		r.archiveMaxRequestSize = n
+	}
+}
+
+func WithArchiveQueueSize(n int) RouterFunc {
+	return func(r *Router) {
+		r.archiveQueueSize = n
+	}
+}
+
+// CloseArchive stops queueing exchanges and waits until the queued ones are
+// stored or ctx is done.
+func (router *Router) CloseArchive(ctx context.Context) error {
+	if router.archiver == nil {
+		return nil
+	}
+	return router.archiver.Close(ctx)
+}
+
+func (router *Router) archiveExchange(ctx context.Context, ex exchange, start, end time.Time, response string) {
+	err := router.archiver.Store(ctx, archive.Entry{
+		Side:        archive.SideServer,
+		ClientID:    ex.ClientID,
+		Version:     ex.Version,
+		Action:      ex.Action,
+		HotelCode:   ex.hotelCode,
+		RequestedAt: start,
+		RespondedAt: end,
+		StatusCode:  ex.StatusCode,
+		Request:     ex.request,
+		Response:    response,
+
+		RequestSize:      int64(ex.RequestSize),
+		RequestTruncated: len(ex.request) < ex.RequestSize,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "archiving request failed", "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+package archive
+
+import (
+	"context"
+	"time"
+)
+
+// Archive stores the raw messages exchanged with AlpineBits partners.
+type Archive interface {
+	Store(ctx context.Context, e Entry) error
+}
+
+// Querier is implemented by archives that can look up stored entries.
+type Querier interface {
+	Query(ctx context.Context, q Query) ([]Entry, error)
+}
+
+type Side string
+
+const (
+	SideServer Side = "server"
+	SideClient Side = "client"
+)
+
+// Entry is a single request and its response. Response is empty if the
+// request failed before a response was received. RequestTruncated reports
+// whether Request holds only the start of the request, which was RequestSize
+// bytes long.
+type Entry struct {
+	Side        Side      `json:"side"`
+	ClientID    string    `json:"client_id"`
+	Version     string    `json:"version"`
+	Action      string    `json:"action"`
+	HotelCode   string    `json:"hotel_code,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+	RespondedAt time.Time `json:"responded_at"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Request     string    `json:"request"`
+	Response    string    `json:"response,omitempty"`
+
+	RequestSize      int64 `json:"request_size,omitempty"`
+	RequestTruncated bool  `json:"request_truncated,omitempty"`
+}
+
+// Query selects entries by hotel code and by the time window [From, To) of
+// their request. Zero values match any hotel code or time.
+type Query struct {
+	HotelCode string
+	From      time.Time
+	To        time.Time
+}
+
+func (q Query) Match(e Entry) bool {
+	if q.HotelCode != "" && e.HotelCode != q.HotelCode {
+		return false
+	}
+	if !q.From.IsZero() && e.RequestedAt.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !e.RequestedAt.Before(q.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,271 @@
+package archive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxFileSize = 64 << 20
+	dayLayout          = "2006-01-02"
+	fileExt            = ".jsonl"
+	noHotelCode        = "_"
+)
+
+// FileSystem archives entries as JSON lines below a directory, in one
+// directory per day and one file per hotel code. Files are rotated when they
+// exceed the maximum file size and days older than the maximum age are
+// removed.
+type FileSystem struct {
+	dir         string
+	maxFileSize int64
+	maxAge      time.Duration
+
+	mu         sync.Mutex
+	segments   map[string]int
+	lastPruned string
+}
+
+var _ Archive = (*FileSystem)(nil)
+var _ Querier = (*FileSystem)(nil)
+
+type FileSystemFunc func(*FileSystem)
+
+func WithMaxFileSize(n int64) FileSystemFunc {
+	return func(f *FileSystem) {
+		f.maxFileSize = n
+	}
+}
+
+// WithMaxAge removes archived days older than d. Zero keeps all days.
+func WithMaxAge(d time.Duration) FileSystemFunc {
+	return func(f *FileSystem) {
+		f.maxAge = d
+	}
+}
+
+func NewFileSystem(dir string, opts ...FileSystemFunc) (*FileSystem, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	f := &FileSystem{
+		dir:         dir,
+		maxFileSize: DefaultMaxFileSize,
+		segments:    make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f, nil
+}
+
+func (f *FileSystem) Store(ctx context.Context, e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	day := e.RequestedAt.UTC().Format(dayLayout)
+	name := hotelFileName(e.HotelCode)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.prune(day); err != nil {
+		return err
+	}
+
+	dayDir := filepath.Join(f.dir, day)
+	if err := os.MkdirAll(dayDir, 0o750); err != nil {
+		return err
+	}
+
+	key := filepath.Join(day, name)
+	segment, ok := f.segments[key]
+	if !ok {
+		segments, err := segmentsOf(dayDir, name)
+		if err != nil {
+			return err
+		}
+		if len(segments) > 0 {
+			segment = slices.Max(segments)
+		}
+	}
+
+	path := segmentPath(dayDir, name, segment)
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 && info.Size()+int64(len(b)) > f.maxFileSize {
+		segment++
+		path = segmentPath(dayDir, name, segment)
+	}
+	f.segments[key] = segment
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(b); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (f *FileSystem) Query(ctx context.Context, q Query) ([]Entry, error) {
+	days, err := f.days()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, day := range days {
+		if !q.includesDay(day) {
+			continue
+		}
+
+		dayDir := filepath.Join(f.dir, day)
+		files, err := os.ReadDir(dayDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if q.HotelCode != "" && !strings.HasPrefix(file.Name(), hotelFileName(q.HotelCode)+".") {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			found, err := readEntries(filepath.Join(dayDir, file.Name()), q)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, found...)
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return a.RequestedAt.Compare(b.RequestedAt)
+	})
+	return entries, nil
+}
+
+func (q Query) includesDay(day string) bool {
+	if !q.From.IsZero() && day < q.From.UTC().Format(dayLayout) {
+		return false
+	}
+	if !q.To.IsZero() && day > q.To.UTC().Format(dayLayout) {
+		return false
+	}
+	return true
+}
+
+func (f *FileSystem) prune(today string) error {
+	if f.maxAge <= 0 || f.lastPruned == today {
+		return nil
+	}
+
+	days, err := f.days()
+	if err != nil {
+		return err
+	}
+	oldest := time.Now().Add(-f.maxAge).UTC().Format(dayLayout)
+	for _, day := range days {
+		if day >= oldest {
+			break
+		}
+		if err := os.RemoveAll(filepath.Join(f.dir, day)); err != nil {
+			return err
+		}
+		for key := range f.segments {
+			if strings.HasPrefix(key, day+string(filepath.Separator)) {
+				delete(f.segments, key)
+			}
+		}
+	}
+	f.lastPruned = today
+	return nil
+}
+
+func (f *FileSystem) days() ([]string, error) {
+	dirs, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []string
+	for _, dir := range dirs {
+		if _, err := time.Parse(dayLayout, dir.Name()); err == nil && dir.IsDir() {
+			days = append(days, dir.Name())
+		}
+	}
+	slices.Sort(days)
+	return days, nil
+}
+
+func readEntries(path string, q Query) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<30)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if q.Match(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+func segmentsOf(dayDir, name string) ([]int, error) {
+	files, err := os.ReadDir(dayDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []int
+	for _, file := range files {
+		rest, ok := strings.CutPrefix(file.Name(), name+".")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSuffix(rest, fileExt)); err == nil {
+			segments = append(segments, n)
+		}
+	}
+	return segments, nil
+}
+
+func segmentPath(dayDir, name string, segment int) string {
+	return filepath.Join(dayDir, fmt.Sprintf("%s.%d%s", name, segment, fileExt))
+}
+
+func hotelFileName(hotelCode string) string {
+	if hotelCode == "" {
+		return noHotelCode
+	}
+	return url.PathEscape(hotelCode)
+}
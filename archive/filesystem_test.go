@@ -0,0 +1,95 @@
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSystem(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := NewFileSystem(dir, WithMaxFileSize(512))
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	start := time.Date(2024, 10, 1, 23, 0, 0, 0, time.UTC)
+	for i := range 6 {
+		hotelCode := "123"
+		if i%2 == 1 {
+			hotelCode = "456"
+		}
+		requestedAt := start.Add(time.Duration(i) * 30 * time.Minute)
+		assert.NoError(t, archive.Store(ctx, Entry{
+			Side:        SideServer,
+			ClientID:    "client",
+			Version:     "2020-10",
+			Action:      "OTA_HotelInvCountNotif:FreeRooms",
+			HotelCode:   hotelCode,
+			RequestedAt: requestedAt,
+			RespondedAt: requestedAt.Add(time.Second),
+			StatusCode:  200,
+			Request:     strings.Repeat("x", 200),
+			Response:    "<OTA_HotelInvCountNotifRS/>",
+		}))
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*.jsonl"))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "2024-10-01", "123.0.jsonl"),
+		filepath.Join(dir, "2024-10-01", "456.0.jsonl"),
+		filepath.Join(dir, "2024-10-02", "123.0.jsonl"),
+		filepath.Join(dir, "2024-10-02", "123.1.jsonl"),
+		filepath.Join(dir, "2024-10-02", "456.0.jsonl"),
+		filepath.Join(dir, "2024-10-02", "456.1.jsonl"),
+	}, files)
+
+	entries, err := archive.Query(ctx, Query{HotelCode: "123"})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, start, entries[0].RequestedAt)
+	assert.Equal(t, "<OTA_HotelInvCountNotifRS/>", entries[0].Response)
+
+	entries, err = archive.Query(ctx, Query{
+		From: start.Add(30 * time.Minute),
+		To:   start.Add(2 * time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	for _, e := range entries {
+		assert.False(t, e.RequestedAt.Before(start.Add(30*time.Minute)))
+	}
+
+	entries, err = archive.Query(ctx, Query{HotelCode: "789"})
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFileSystemMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := NewFileSystem(dir, WithMaxAge(24*time.Hour))
+	assert.NoError(t, err)
+
+	old := filepath.Join(dir, "2000-01-01")
+	assert.NoError(t, os.MkdirAll(old, 0o750))
+
+	assert.NoError(t, archive.Store(context.Background(), Entry{RequestedAt: time.Now()}))
+
+	_, err = os.Stat(old)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	entries, err := archive.Query(context.Background(), Query{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestHotelFileName(t *testing.T) {
+	assert.Equal(t, "_", hotelFileName(""))
+	assert.Equal(t, "123", hotelFileName("123"))
+	assert.Equal(t, "..%2F123", hotelFileName("../123"))
+}
@@ -0,0 +1,98 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+// DefaultQueueSize is the number of entries waiting to be stored before
+// further ones are dropped.
+const DefaultQueueSize = 1024
+
+var (
+	ErrQueueFull   = errors.New("archive queue full")
+	ErrQueueClosed = errors.New("archive closed")
+)
+
+// Queue stores entries in a by a background goroutine, so a slow archive does
+// not delay the caller. Failures of a are only logged. Call Close to store the
+// queued entries.
+type Queue struct {
+	archive Archive
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan queueItem
+	done   chan struct{}
+}
+
+var _ Archive = new(Queue)
+
+type queueItem struct {
+	ctx   context.Context
+	entry Entry
+}
+
+// NewQueue returns a Queue holding up to size entries, or DefaultQueueSize if
+// size is not positive.
+func NewQueue(a Archive, size int) *Queue {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	q := &Queue{
+		archive: a,
+		queue:   make(chan queueItem, size),
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+	for item := range q.queue {
+		if err := q.archive.Store(item.ctx, item.entry); err != nil {
+			slog.ErrorContext(item.ctx, "archiving request failed", "error", err)
+		}
+	}
+}
+
+// Store queues e without waiting for it to be stored. It returns ErrQueueFull
+// or ErrQueueClosed if e is dropped.
+func (q *Queue) Store(ctx context.Context, e Entry) error {
+	// the context of a request is usually canceled before e is stored
+	ctx = context.WithoutCancel(ctx)
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	select {
+	case q.queue <- queueItem{ctx, e}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops queueing entries and waits until the queued ones are stored or
+// ctx is done.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
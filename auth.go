@@ -0,0 +1,73 @@
+package alpinebits
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/HGV/alpinebits/version"
+)
+
+var ErrUnauthorized = errors.New("unauthorized")
+
+type Credentials struct {
+	Username string
+	Password string
+	ClientID string
+}
+
+type Principal interface {
+	AuthorizeHotelCode(hotelCode string) bool
+}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, c Credentials) (Principal, error)
+}
+
+type AuthenticatorFunc func(ctx context.Context, c Credentials) (Principal, error)
+
+var _ Authenticator = (AuthenticatorFunc)(nil)
+
+func (fn AuthenticatorFunc) Authenticate(ctx context.Context, c Credentials) (Principal, error) {
+	return fn(ctx, c)
+}
+
+func WithAuthenticator(a Authenticator) RouterFunc {
+	return func(r *Router) {
+		r.authenticator = a
+	}
+}
+
+func (router *Router) authenticate(r *http.Request, clientID string) (Principal, error) {
+	if router.authenticator == nil {
+		return nil, nil
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	principal, err := router.authenticator.Authenticate(r.Context(), Credentials{
+		Username: username,
+		Password: password,
+		ClientID: clientID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if principal == nil {
+		return nil, ErrUnauthorized
+	}
+	return principal, nil
+}
+
+func (r Request) AuthorizeHotelCode() bool {
+	if r.Principal == nil {
+		return true
+	}
+	if p, ok := r.Data.(version.HotelCodeProvider); ok {
+		return r.Principal.AuthorizeHotelCode(p.HotelCode())
+	}
+	return true
+}
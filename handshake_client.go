@@ -13,20 +13,30 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/HGV/alpinebits/internal/compression"
+	"github.com/HGV/alpinebits/observability"
 )
 
 type (
 	HandshakeClient struct {
-		config *HandshakeClientConfig
-		client *http.Client
+		config            *HandshakeClientConfig
+		client            *http.Client
+		observer          observability.Observer
+		serverAcceptsGzip atomic.Bool
 	}
 	HandshakeClientConfig struct {
-		URL           string
-		Username      string
-		Password      string
-		ClientID      string
-		HandshakeData HandshakeData
-		HttpClient    *http.Client
+		URL                  string
+		Username             string
+		Password             string
+		ClientID             string
+		HandshakeData        HandshakeData
+		HttpClient           *http.Client
+		ServerAcceptEncoding string
+		CompressionThreshold int
+		Observer             observability.Observer
 	}
 )
 
@@ -89,10 +99,13 @@ func NewHandshakeClient(config HandshakeClientConfig) (*HandshakeClient, error)
 		return nil, err
 	}
 
-	return &HandshakeClient{
-		config: &config,
-		client: cmp.Or(config.HttpClient, &http.Client{}),
-	}, nil
+	c := &HandshakeClient{
+		config:   &config,
+		client:   cmp.Or(config.HttpClient, &http.Client{}),
+		observer: cmp.Or(config.Observer, observability.Nop),
+	}
+	c.serverAcceptsGzip.Store(compression.AcceptsGzipValue(config.ServerAcceptEncoding))
+	return c, nil
 }
 
 func (c *HandshakeClient) Ping(ctx context.Context) (HandshakeData, *http.Response, error) {
@@ -106,7 +119,12 @@ func (c *HandshakeClient) Ping(ctx context.Context) (HandshakeData, *http.Respon
 		EchoData: echoData{string(b)},
 	}
 
-	req, err := c.newRequest(ctx, "OTA_Ping:Handshaking", pingRQ)
+	obs := observability.Observation{
+		Side:     observability.SideClient,
+		Action:   "OTA_Ping:Handshaking",
+		ClientID: c.config.ClientID,
+	}
+	req, err := c.newRequest(ctx, obs.Action, pingRQ, &obs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -114,33 +132,57 @@ func (c *HandshakeClient) Ping(ctx context.Context) (HandshakeData, *http.Respon
 	var resp *http.Response
 	var lastErr error
 	for version := range c.config.HandshakeData {
-		req.Header.Set(HeaderClientProtocolVersion, version)
-
-		var pingRS pingRS
-		resp, lastErr = c.do(req, &pingRS)
+		var handshakeData HandshakeData
+		handshakeData, resp, lastErr = c.ping(ctx, req, version, obs)
 		if lastErr != nil {
 			continue // retry with lower version
 		}
+		return handshakeData, resp, nil
+	}
+
+	return nil, resp, lastErr
+}
+
+func (c *HandshakeClient) ping(ctx context.Context, req *http.Request, version string, obs observability.Observation) (HandshakeData, *http.Response, error) {
+	obs.Version = version
+	ctx = c.observer.Start(ctx, obs)
+	start := time.Now()
 
+	req.Header.Set(HeaderClientProtocolVersion, version)
+
+	var pingRS pingRS
+	var handshakeData HandshakeData
+	resp, err := c.do(req.WithContext(ctx), &pingRS, &obs)
+	if err == nil {
 		if pingRS.Warning.Status == statusAlpinebitsHandshake {
-			var handshakeData HandshakeData
-			if lastErr = json.Unmarshal([]byte(pingRS.Warning.Intersection), &handshakeData); lastErr != nil {
-				continue // retry with lower version
-			}
-			return handshakeData, resp, nil
+			err = json.Unmarshal([]byte(pingRS.Warning.Intersection), &handshakeData)
+		} else {
+			err = errors.New("no possible version found")
 		}
+	}
 
-		lastErr = errors.New("no possible version found")
+	obs.Duration = time.Since(start)
+	obs.Err = err
+	if obs.Outcome == "" {
+		obs.Outcome = observability.OutcomeSuccess
+		if err != nil {
+			obs.Outcome = observability.OutcomeFailed
+		}
 	}
+	c.observer.End(ctx, obs)
 
-	return nil, resp, lastErr
+	if err != nil {
+		return nil, resp, err
+	}
+	return handshakeData, resp, nil
 }
 
-func (c *HandshakeClient) newRequest(ctx context.Context, action string, request any) (*http.Request, error) {
+func (c *HandshakeClient) newRequest(ctx context.Context, action string, request any, obs *observability.Observation) (*http.Request, error) {
 	xml, err := xml.Marshal(request)
 	if err != nil {
 		return nil, err
 	}
+	obs.RequestSize = len(xml)
 
 	var body bytes.Buffer
 	w := multipart.NewWriter(&body)
@@ -154,7 +196,15 @@ func (c *HandshakeClient) newRequest(ctx context.Context, action string, request
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, &body)
+	reqBody, compressed, err := compression.GzipIf(
+		&body,
+		c.serverAcceptsGzip.Load(),
+		cmp.Or(c.config.CompressionThreshold, compression.DefaultThreshold))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -162,30 +212,46 @@ func (c *HandshakeClient) newRequest(ctx context.Context, action string, request
 	req.SetBasicAuth(c.config.Username, c.config.Password)
 
 	req.Header.Set("Content-Type", w.FormDataContentType())
+	if compressed {
+		req.Header.Set(compression.HeaderContentEncoding, compression.EncodingGzip)
+	}
 	req.Header.Set(HeaderClientID, c.config.ClientID)
 
 	return req, nil
 }
 
-func (c *HandshakeClient) do(req *http.Request, v any) (*http.Response, error) {
+func (c *HandshakeClient) do(req *http.Request, v any, obs *observability.Observation) (*http.Response, error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	// Proxies in between may answer without the header, so only a valid
+	// AlpineBits response can tell that the server stopped accepting gzip.
+	if compression.AcceptsGzip(resp.Header) {
+		c.serverAcceptsGzip.Store(true)
+	}
+
+	obs.StatusCode = resp.StatusCode
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return resp, err
 	}
+	obs.ResponseSize = len(body)
 
 	if sc := resp.StatusCode; sc < 200 || sc > 299 {
+		obs.Outcome = observability.OutcomeFromStatus(sc)
 		return resp, fmt.Errorf("handshake request failed with status code: %d", sc)
 	}
 
 	if err = xml.Unmarshal(body, v); err != nil {
 		return resp, err
 	}
+	if !compression.AcceptsGzip(resp.Header) {
+		c.serverAcceptsGzip.Store(false)
+	}
 
 	return resp, nil
 }
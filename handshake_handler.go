@@ -0,0 +1,88 @@
+package alpinebits
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/HGV/alpinebits/version"
+)
+
+const (
+	handshakeActionName = "action_OTA_Ping"
+	warningTypeAdvisory = 11
+)
+
+func isPingAction(action version.Action) bool {
+	return action.HandshakeName() == handshakeActionName
+}
+
+func PingHandler(r Request) (any, error) {
+	rq, ok := r.Data.(version.EchoDataProvider)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ping request type: %T", r.Data)
+	}
+
+	raw := rq.EchoDataValue()
+	text, err := echoDataText(raw)
+	if err != nil {
+		return nil, &requestError{fmt.Errorf("invalid EchoData: %w", err)}
+	}
+
+	var clientHandshakeData HandshakeData
+	if err := json.Unmarshal([]byte(text), &clientHandshakeData); err != nil {
+		return nil, &requestError{fmt.Errorf("invalid handshake data in EchoData: %w", err)}
+	}
+
+	agreement := r.HandshakeData().Intersect(clientHandshakeData)
+	if r.handshakeStore != nil {
+		if err := r.handshakeStore.Save(r.Context, r.ClientID, agreement); err != nil {
+			return nil, err
+		}
+	}
+
+	intersection, err := json.Marshal(agreement)
+	if err != nil {
+		return nil, err
+	}
+
+	return pingRS{
+		Version: "1.0",
+		Warning: warning{
+			Type:         warningTypeAdvisory,
+			Status:       statusAlpinebitsHandshake,
+			Intersection: string(intersection),
+		},
+		EchoData: echoData{Value: raw},
+	}, nil
+}
+
+func echoDataText(innerXML string) (string, error) {
+	var v struct {
+		Text string `xml:",chardata"`
+	}
+	if err := xml.Unmarshal([]byte("<EchoData>"+innerXML+"</EchoData>"), &v); err != nil {
+		return "", err
+	}
+	return v.Text, nil
+}
+
+// requestError is returned by built-in handlers for requests the client got
+// wrong. It is answered with 400 Bad Request instead of an internal error.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+func isRequestError(err error) bool {
+	var reqErr *requestError
+	return errors.As(err, &reqErr)
+}
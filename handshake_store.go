@@ -0,0 +1,125 @@
+package alpinebits
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// HandshakeStore persists the handshake agreement negotiated with each client
+// during OTA_Ping.
+type HandshakeStore interface {
+	Load(ctx context.Context, clientID string) (HandshakeData, bool, error)
+	Save(ctx context.Context, clientID string, data HandshakeData) error
+}
+
+// WithHandshakeStore makes PingHandler save the negotiated agreement in s and
+// enforces it on later requests of the same client, like a handshake override
+// in the RouteContext. Clients without a stored agreement are not restricted.
+func WithHandshakeStore(s HandshakeStore) RouterFunc {
+	return func(r *Router) {
+		r.handshakeStore = s
+	}
+}
+
+type MemoryHandshakeStore struct {
+	mu         sync.RWMutex
+	agreements map[string]HandshakeData
+}
+
+var _ HandshakeStore = (*MemoryHandshakeStore)(nil)
+
+func NewMemoryHandshakeStore() *MemoryHandshakeStore {
+	return &MemoryHandshakeStore{
+		agreements: make(map[string]HandshakeData),
+	}
+}
+
+func (s *MemoryHandshakeStore) Load(_ context.Context, clientID string) (HandshakeData, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.agreements[clientID]
+	return data, ok, nil
+}
+
+func (s *MemoryHandshakeStore) Save(_ context.Context, clientID string, data HandshakeData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.agreements[clientID] = data
+	return nil
+}
+
+// FileHandshakeStore keeps all agreements in a single JSON file, which is
+// read on creation and replaced atomically on every save.
+type FileHandshakeStore struct {
+	path string
+
+	mu     sync.Mutex
+	memory *MemoryHandshakeStore
+}
+
+var _ HandshakeStore = (*FileHandshakeStore)(nil)
+
+func NewFileHandshakeStore(path string) (*FileHandshakeStore, error) {
+	s := &FileHandshakeStore{
+		path:   path,
+		memory: NewMemoryHandshakeStore(),
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &s.memory.agreements); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileHandshakeStore) Load(ctx context.Context, clientID string) (HandshakeData, bool, error) {
+	return s.memory.Load(ctx, clientID)
+}
+
+func (s *FileHandshakeStore) Save(ctx context.Context, clientID string, data HandshakeData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the agreement only becomes active once it is persisted
+	s.memory.mu.RLock()
+	agreements := maps.Clone(s.memory.agreements)
+	s.memory.mu.RUnlock()
+	agreements[clientID] = data
+
+	b, err := json.Marshal(agreements)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	return s.memory.Save(ctx, clientID, data)
+}
@@ -1,11 +1,17 @@
 package alpinebits
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/HGV/alpinebits/v_2018_10"
 	"github.com/HGV/alpinebits/v_2020_10"
+	"github.com/HGV/alpinebits/v_2020_10/freerooms"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,6 +57,30 @@ func TestNewHandshakeDataFromRouter(t *testing.T) {
 	assert.Equal(t, expected, handshakeData)
 }
 
+func TestNewHandshakeDataFromRouterGuestRequests(t *testing.T) {
+	r := NewRouter()
+
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionReadGuestRequests, nil)
+		s.Action(v_2020_10.ActionNotifReportGuestRequests, nil)
+	})
+	assert.Equal(t, HandshakeData{
+		"2020-10": map[string][]string{
+			"action_OTA_Read": nil,
+		},
+	}, NewHandshakeDataFromRouter(*r, ""))
+
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionHotelResNotifGuestRequests, nil)
+	})
+	assert.Equal(t, HandshakeData{
+		"2020-10": map[string][]string{
+			"action_OTA_HotelResNotif_GuestRequests": nil,
+		},
+	}, NewHandshakeDataFromRouter(*r, ""))
+}
+
 func TestNegotiatedVersion(t *testing.T) {
 	intersected := HandshakeData{
 		"2020-10": map[string][]string{
@@ -236,3 +266,272 @@ func TestIntersect(t *testing.T) {
 
 	assert.Equal(t, expected, serverHandshakeData.Intersect(clientHandshakeData))
 }
+
+func TestPingHandler(t *testing.T) {
+	r := NewRouter()
+
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionPing, nil)
+		s.Action(v_2020_10.ActionHotelInvCountNotif, nil, WithCapabilities(
+			v_2020_10.CapabilityHotelInvCountNotifAcceptRooms,
+			v_2020_10.CapabilityHotelInvCountNotifAcceptDeltas,
+		))
+	})
+
+	v201810, _ := v_2018_10.NewVersion()
+	r.Version(v201810, func(s *Subrouter) {
+		s.Action(v_2018_10.ActionPing, PingHandler)
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	client, err := NewHandshakeClient(HandshakeClientConfig{
+		URL:      srv.URL,
+		Username: "username",
+		Password: "password",
+		ClientID: "client",
+		HandshakeData: HandshakeData{
+			"2022-10": map[string][]string{
+				"action_OTA_Ping": nil,
+			},
+			"2020-10": map[string][]string{
+				"action_OTA_Ping": nil,
+				"action_OTA_HotelInvCountNotif": {
+					"OTA_HotelInvCountNotif_accept_deltas",
+					"OTA_HotelInvCountNotif_accept_out_of_order",
+				},
+			},
+			"2018-10": map[string][]string{
+				"action_OTA_Ping": nil,
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	handshakeData, resp, err := client.Ping(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	expected := HandshakeData{
+		"2020-10": map[string][]string{
+			"action_OTA_Ping": nil,
+			"action_OTA_HotelInvCountNotif": {
+				"OTA_HotelInvCountNotif_accept_deltas",
+			},
+		},
+		"2018-10": map[string][]string{
+			"action_OTA_Ping": nil,
+		},
+	}
+
+	assert.Equal(t, expected, handshakeData)
+}
+
+func TestPingHandlerInvalidEchoData(t *testing.T) {
+	r := NewRouter()
+
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionPing, nil)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionPing.String(),
+		`<OTA_PingRQ xmlns="http://www.opentravel.org/OTA/2003/05" Version="8.000"><EchoData>{"versions": [</EchoData></OTA_PingRQ>`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "ERROR: invalid handshake data in EchoData")
+}
+
+func TestHandshakeClientServerAcceptsGzip(t *testing.T) {
+	r := NewRouter()
+
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionPing, nil)
+	})
+
+	var proxyError bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if proxyError {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+	}))
+	defer srv.Close()
+
+	client, err := NewHandshakeClient(HandshakeClientConfig{
+		URL:                  srv.URL,
+		Username:             "username",
+		Password:             "password",
+		ClientID:             "client",
+		ServerAcceptEncoding: "gzip",
+		HandshakeData: HandshakeData{
+			"2020-10": map[string][]string{"action_OTA_Ping": nil},
+		},
+	})
+	assert.NoError(t, err)
+
+	proxyError = true
+	_, _, err = client.Ping(context.Background())
+	assert.Error(t, err)
+	assert.True(t, client.serverAcceptsGzip.Load())
+
+	proxyError = false
+	_, _, err = client.Ping(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, client.serverAcceptsGzip.Load())
+}
+
+func TestPingHandlerWithHandshakeDataOverride(t *testing.T) {
+	r := NewRouter()
+
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionPing, nil)
+		s.Action(v_2020_10.ActionHotelInvCountNotif, nil, WithCapabilities(
+			v_2020_10.CapabilityHotelInvCountNotifAcceptRooms,
+			v_2020_10.CapabilityHotelInvCountNotifAcceptDeltas,
+		))
+	})
+
+	override := HandshakeData{
+		"2020-10": map[string][]string{
+			"action_OTA_Ping": nil,
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := WithRouteContext(req.Context(), RouteContext{HandshakeDataOverride: override})
+		r.ServeHTTP(w, req.WithContext(ctx))
+	}))
+	defer srv.Close()
+
+	client, err := NewHandshakeClient(HandshakeClientConfig{
+		URL:      srv.URL,
+		Username: "username",
+		Password: "password",
+		ClientID: "client",
+		HandshakeData: HandshakeData{
+			"2020-10": map[string][]string{
+				"action_OTA_Ping": nil,
+				"action_OTA_HotelInvCountNotif": {
+					"OTA_HotelInvCountNotif_accept_deltas",
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	handshakeData, _, err := client.Ping(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, override, handshakeData)
+}
+
+func TestPingHandlerWithHandshakeStore(t *testing.T) {
+	store, err := NewFileHandshakeStore(filepath.Join(t.TempDir(), "handshakes.json"))
+	assert.NoError(t, err)
+
+	var capabilities []string
+	r := NewRouter(WithHandshakeStore(store))
+
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionPing, nil)
+		s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+			capabilities = r.Capabilities
+			rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+			rs.SetSuccess()
+			return rs, nil
+		}, WithCapabilities(
+			v_2020_10.CapabilityHotelInvCountNotifAcceptRooms,
+			v_2020_10.CapabilityHotelInvCountNotifAcceptDeltas,
+		))
+	})
+
+	v201810, _ := v_2018_10.NewVersion()
+	r.Version(v201810, func(s *Subrouter) {
+		s.Action(v_2018_10.ActionPing, nil)
+		s.Action(v_2018_10.ActionHotelAvailNotif, func(r Request) (any, error) {
+			return nil, nil
+		})
+	})
+
+	// Requests are not restricted before the first handshake
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.ElementsMatch(t, []string{
+		"OTA_HotelInvCountNotif_accept_rooms",
+		"OTA_HotelInvCountNotif_accept_deltas",
+	}, capabilities)
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	client, err := NewHandshakeClient(HandshakeClientConfig{
+		URL:      srv.URL,
+		Username: "username",
+		Password: "password",
+		ClientID: "client",
+		HandshakeData: HandshakeData{
+			"2020-10": map[string][]string{
+				"action_OTA_Ping": nil,
+				"action_OTA_HotelInvCountNotif": {
+					"OTA_HotelInvCountNotif_accept_deltas",
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	agreement, _, err := client.Ping(context.Background())
+	assert.NoError(t, err)
+
+	stored, ok, err := store.Load(context.Background(), "client")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, agreement, stored)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"OTA_HotelInvCountNotif_accept_deltas"}, capabilities)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, newTestRequest(t, "2018-10", v_2018_10.ActionHotelAvailNotif.String(), testPingRQ))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "was not included in the handshake agreement")
+
+	// OTA_Ping stays available to renegotiate
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, newTestRequest(t, "2018-10", v_2018_10.ActionPing.String(), testPingRQ))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	reopened, err := NewFileHandshakeStore(filepath.Join(filepath.Dir(store.path), "handshakes.json"))
+	assert.NoError(t, err)
+	stored, ok, err = reopened.Load(context.Background(), "client")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, HandshakeData{"2020-10": map[string][]string{"action_OTA_Ping": nil}}, stored)
+}
+
+func TestFileHandshakeStoreSaveError(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	assert.NoError(t, os.Mkdir(dir, 0o755))
+	store, err := NewFileHandshakeStore(filepath.Join(dir, "handshakes.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, os.Remove(dir))
+
+	data := HandshakeData{"2020-10": map[string][]string{"action_OTA_Ping": nil}}
+	assert.Error(t, store.Save(context.Background(), "client", data))
+
+	_, ok, err := store.Load(context.Background(), "client")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,247 @@
+package alpinebits
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HGV/alpinebits/version"
+)
+
+// DefaultIdempotencyTTL covers the retries of a client after a timeout, but
+// not resends of the same message meant to be applied again.
+const DefaultIdempotencyTTL = 15 * time.Minute
+
+// IdempotencyStore keeps the responses of idempotent routes by key.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, response []byte) error
+}
+
+// IdempotencyKeyFunc identifies a message among the requests of the same
+// client and action.
+type IdempotencyKeyFunc func(data any, payload io.Reader) (string, error)
+
+// PayloadHashKey identifies a message by the SHA-256 hash of its payload.
+func PayloadHashKey(_ any, payload io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, payload); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// UniqueIDKey identifies a message by its hotel code and UniqueID, falling back
+// to PayloadHashKey for messages without UniqueID. It must only be used for
+// clients that send a distinct UniqueID with every message.
+func UniqueIDKey(data any, payload io.Reader) (string, error) {
+	p, ok := data.(version.UniqueIDProvider)
+	if !ok || p.UniqueIDValue() == "" {
+		return PayloadHashKey(data, payload)
+	}
+
+	var hotelCode string
+	if h, ok := data.(version.HotelCodeProvider); ok {
+		hotelCode = h.HotelCode()
+	}
+	return "unique_id:" + hotelCode + ":" + p.UniqueIDValue(), nil
+}
+
+// WithIdempotencyStore replaces the in-memory store used by idempotent routes,
+// which keeps responses for DefaultIdempotencyTTL.
+func WithIdempotencyStore(s IdempotencyStore) RouterFunc {
+	return func(r *Router) {
+		r.idempotencyStore = s
+	}
+}
+
+// WithIdempotency answers repeated requests of a client with the response of
+// the first successful one, without calling the handler or the middlewares
+// again. Requests are identified by client ID, version, action and fn, which
+// defaults to PayloadHashKey. Concurrent duplicates wait for the first request
+// to complete.
+//
+// With PayloadHashKey a message sent again on purpose, such as free rooms
+// going back to a previous state, is replayed instead of applied while the
+// first response is stored, see DefaultIdempotencyTTL.
+func WithIdempotency(fn IdempotencyKeyFunc) RouteFunc {
+	return func(r *Route) {
+		if fn == nil {
+			fn = PayloadHashKey
+		}
+		r.idempotencyKey = fn
+	}
+}
+
+type (
+	// rawResponse is a marshalled and validated response.
+	rawResponse []byte
+	// replayedResponse is a rawResponse returned for a repeated request.
+	replayedResponse []byte
+)
+
+func (router *Router) idempotent(v version.Version[version.Action], route Route, payload io.Reader, next HandlerFunc) HandlerFunc {
+	return func(r Request) (any, error) {
+		k, err := route.idempotencyKey(r.Data, payload)
+		if err != nil {
+			return nil, err
+		}
+		key := idempotencyKey(r.ClientID, v.String(), route.action.String(), k)
+		unlock, err := router.idempotencyLocks.Lock(r.Context, key)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
+		b, ok, err := router.idempotencyStore.Get(r.Context, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return replayedResponse(b), nil
+		}
+
+		resp, err := next(r)
+		if err != nil {
+			return nil, err
+		}
+		if b, err = marshalResponse(v, resp); err != nil {
+			return nil, err
+		}
+		if err := router.idempotencyStore.Set(r.Context, key, b); err != nil {
+			slog.ErrorContext(r.Context, "storing idempotent response failed", "error", err)
+		}
+		return rawResponse(b), nil
+	}
+}
+
+// idempotencyKey includes the version, as responses are serialized for the
+// version negotiated by the request.
+func idempotencyKey(clientID, version, action, key string) string {
+	return strings.Join([]string{clientID, version, action, key}, "\x00")
+}
+
+type MemoryIdempotencyStore struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	responses map[string]cachedResponse
+	lastPrune time.Time
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+type cachedResponse struct {
+	response  []byte
+	expiresAt time.Time
+}
+
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		ttl:       ttl,
+		responses: make(map[string]cachedResponse),
+	}
+}
+
+func (s *MemoryIdempotencyStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached, ok := s.responses[key]
+	if !ok || time.Now().After(cached.expiresAt) {
+		return nil, false, nil
+	}
+	return cached.response, true, nil
+}
+
+func (s *MemoryIdempotencyStore) Set(_ context.Context, key string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastPrune) > s.ttl {
+		for k, cached := range s.responses {
+			if now.After(cached.expiresAt) {
+				delete(s.responses, k)
+			}
+		}
+		s.lastPrune = now
+	}
+
+	s.responses[key] = cachedResponse{
+		response:  response,
+		expiresAt: now.Add(s.ttl),
+	}
+	return nil
+}
+
+// keyedMutex serialises requests with the same key. Waiting requests acquire
+// the lock in the order they arrived.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	held    bool
+	waiters []chan struct{}
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*keyedLock)}
+}
+
+// Lock waits until the lock for key is acquired or ctx is done.
+func (m *keyedMutex) Lock(ctx context.Context, key string) (unlock func(), err error) {
+	m.mu.Lock()
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		m.locks[key] = l
+	}
+	unlock = func() { m.unlock(key, l) }
+	if !l.held {
+		l.held = true
+		m.mu.Unlock()
+		return unlock, nil
+	}
+	ready := make(chan struct{})
+	l.waiters = append(l.waiters, ready)
+	m.mu.Unlock()
+
+	select {
+	case <-ready:
+		return unlock, nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		if i := slices.Index(l.waiters, ready); i >= 0 {
+			l.waiters = slices.Delete(l.waiters, i, i+1)
+			m.mu.Unlock()
+			return nil, ctx.Err()
+		}
+		m.mu.Unlock()
+		// The lock has been handed over in the meantime
+		unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (m *keyedMutex) unlock(key string, l *keyedLock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(l.waiters) > 0 {
+		ready := l.waiters[0]
+		l.waiters = l.waiters[1:]
+		close(ready)
+		return
+	}
+	l.held = false
+	delete(m.locks, key)
+}
@@ -0,0 +1,65 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	HeaderServerAcceptEncoding = "X-AlpineBits-Server-Accept-Encoding"
+	HeaderContentEncoding      = "Content-Encoding"
+	EncodingGzip               = "gzip"
+	DefaultThreshold           = 64 << 10
+)
+
+func AcceptsGzip(h http.Header) bool {
+	for _, v := range h.Values(HeaderServerAcceptEncoding) {
+		if AcceptsGzipValue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func AcceptsGzipValue(v string) bool {
+	for _, enc := range strings.Split(v, ",") {
+		if strings.EqualFold(strings.TrimSpace(enc), EncodingGzip) {
+			return true
+		}
+	}
+	return false
+}
+
+func IsGzipEncoded(h http.Header) bool {
+	return strings.EqualFold(strings.TrimSpace(h.Get(HeaderContentEncoding)), EncodingGzip)
+}
+
+func Gzip(b []byte) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+func GzipIf(b *bytes.Buffer, acceptsGzip bool, threshold int) (*bytes.Buffer, bool, error) {
+	if !acceptsGzip || b.Len() < threshold {
+		return b, false, nil
+	}
+	compressed, err := Gzip(b.Bytes())
+	if err != nil {
+		return nil, false, err
+	}
+	return compressed, true, nil
+}
+
+func Gunzip(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
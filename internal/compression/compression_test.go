@@ -0,0 +1,45 @@
+package compression
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"GZIP", true},
+		{"deflate, gzip", true},
+		{"deflate", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			h := make(http.Header)
+			if tt.value != "" {
+				h.Set(HeaderServerAcceptEncoding, tt.value)
+			}
+			assert.Equal(t, tt.expected, AcceptsGzip(h))
+		})
+	}
+}
+
+func TestGzipRoundTrip(t *testing.T) {
+	buf, err := Gzip([]byte("<OTA_PingRQ/>"))
+	assert.NoError(t, err)
+
+	r, err := Gunzip(buf)
+	assert.NoError(t, err)
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "<OTA_PingRQ/>", string(b))
+}
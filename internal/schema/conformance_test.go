@@ -0,0 +1,198 @@
+//go:build cgo && !purego
+
+package schema
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var versions = []string{"v_2018_10", "v_2020_10", "v_2022_10", "v_2024_10"}
+
+// attributeValues replace the values of attributes to produce invalid and
+// borderline documents.
+var attributeValues = []string{
+	"", " ", "x", "0", "-1", "+1", "01", "1.5", " 2 ", "99999999999999999999",
+	"true", "2024-02-29", "2023-02-29", "2024-13-01", "2024-01-01Z", "2024-01-01+15:00",
+	"2024-01-01T12:00:00", "2024-01-01T24:00:00", "12:30:00", "de", "de-AT", "d3",
+	"https://example.com", "a@b", "P1N", "EUR", "ALPINEBITS_HANDSHAKE",
+}
+
+const valuesPerAttribute = 3
+
+// TestConformance validates the documents in the test data of the versions,
+// and variants of them with an element or attribute changed, with both
+// backends and checks that they agree on whether they are valid.
+func TestConformance(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			buf, err := os.ReadFile(filepath.Join("..", "..", version, "alpinebits.xsd"))
+			assert.NoError(t, err)
+			libxml2, err := ParseBackend(buf, BackendLibxml2)
+			assert.NoError(t, err)
+			purego, err := ParseBackend(buf, BackendPureGo)
+			assert.NoError(t, err)
+
+			files, err := filepath.Glob(filepath.Join("..", "..", version, "*", "test", "data", "*.xml"))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, files)
+
+			for _, file := range files {
+				b, err := os.ReadFile(file)
+				assert.NoError(t, err)
+				doc, err := parseTestDocument(b)
+				assert.NoError(t, err)
+
+				for _, variant := range append([]string{string(b)}, doc.variants()...) {
+					errLibxml2 := libxml2.Validate(variant)
+					errPureGo := purego.Validate(variant)
+					if (errLibxml2 == nil) != (errPureGo == nil) {
+						t.Errorf("%s: backends disagree\nlibxml2: %v\npurego: %v\n%s", filepath.Base(file), errLibxml2, errPureGo, variant)
+					}
+				}
+			}
+		})
+	}
+}
+
+type testNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []any
+}
+
+func parseTestDocument(b []byte) (*testNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(b))
+	root := &testNode{}
+	stack := []*testNode{root}
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			return root.children[0].(*testNode), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &testNode{name: t.Name, attrs: t.Attr}
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 1 {
+				parent.children = append(parent.children, string(t))
+			}
+		}
+	}
+}
+
+func (n *testNode) String() string {
+	var b strings.Builder
+	n.write(&b)
+	return b.String()
+}
+
+func (n *testNode) write(b *strings.Builder) {
+	b.WriteString("<" + rawName(n.name))
+	for _, a := range n.attrs {
+		b.WriteString(" " + rawName(a.Name) + `="`)
+		xml.EscapeText(b, []byte(a.Value))
+		b.WriteString(`"`)
+	}
+	b.WriteString(">")
+	for _, child := range n.children {
+		switch c := child.(type) {
+		case *testNode:
+			c.write(b)
+		case string:
+			xml.EscapeText(b, []byte(c))
+		}
+	}
+	b.WriteString("</" + rawName(n.name) + ">")
+}
+
+func rawName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+// variants returns the document with one change each: an element removed,
+// repeated, renamed or given text, or an attribute removed or replaced. Each
+// attribute is replaced by a few of the attributeValues, taking turns.
+func (n *testNode) variants() []string {
+	var variants []string
+	root := n
+	next := 0
+	var walk func(n *testNode)
+	walk = func(n *testNode) {
+		for i, a := range n.attrs {
+			if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+				continue
+			}
+			attrs := n.attrs
+			n.attrs = append(append([]xml.Attr{}, attrs[:i]...), attrs[i+1:]...)
+			variants = append(variants, root.String())
+			for range valuesPerAttribute {
+				n.attrs = append([]xml.Attr{}, attrs...)
+				n.attrs[i].Value = attributeValues[next%len(attributeValues)]
+				variants = append(variants, root.String())
+				next++
+			}
+			n.attrs = attrs
+		}
+		n.attrs = append(n.attrs, xml.Attr{Name: xml.Name{Local: "Unknown"}, Value: "1"})
+		variants = append(variants, root.String())
+		n.attrs = n.attrs[:len(n.attrs)-1]
+
+		children := n.children
+		n.children = append([]any{"x"}, children...)
+		variants = append(variants, root.String())
+		for i, child := range children {
+			c, ok := child.(*testNode)
+			if !ok {
+				continue
+			}
+			n.children = append(append([]any{}, children[:i]...), children[i+1:]...)
+			variants = append(variants, root.String())
+			n.children = append(append([]any{}, children[:i+1]...), children[i:]...)
+			variants = append(variants, root.String())
+
+			renamed := *c
+			renamed.name.Local = "Unknown"
+			n.children = append([]any{}, children...)
+			n.children[i] = &renamed
+			variants = append(variants, root.String())
+
+			n.children = children
+			walk(c)
+		}
+		n.children = children
+	}
+	walk(n)
+	return variants
+}
+
+func TestConformanceVariants(t *testing.T) {
+	doc, err := parseTestDocument([]byte(`<a xmlns="urn:x" b="1"><c/>text</a>`))
+	assert.NoError(t, err)
+	variants := doc.variants()
+	assert.Contains(t, variants, `<a xmlns="urn:x"><c></c>text</a>`)
+	assert.Contains(t, variants, `<a xmlns="urn:x" b="1"><c></c><c></c>text</a>`)
+	assert.Contains(t, variants, `<a xmlns="urn:x" b="1"><Unknown></Unknown>text</a>`)
+	assert.Contains(t, variants, `<a xmlns="urn:x" b=" "><c></c>text</a>`)
+	assert.Len(t, variants, 1+valuesPerAttribute+1+1+3+2)
+}
@@ -0,0 +1,50 @@
+//go:build cgo && !purego
+
+package schema
+
+import (
+	"errors"
+	"strings"
+	"unsafe"
+
+	"github.com/lestrrat-go/libxml2"
+	"github.com/lestrrat-go/libxml2/xsd"
+)
+
+func init() {
+	backends[BackendLibxml2] = parseLibxml2
+	defaultBackend = BackendLibxml2
+}
+
+// libxml2Schema parses each document into a fresh libxml2 tree, as the binding
+// exposes no parser context that could be reset and reused. Only the parsed
+// schema is shared between validations.
+type libxml2Schema struct {
+	xsd *xsd.Schema
+}
+
+func (s *libxml2Schema) Validate(doc []byte) error {
+	// the binding copies the document into C memory, so it is not retained
+	d, err := libxml2.ParseString(unsafe.String(unsafe.SliceData(doc), len(doc)))
+	if err != nil {
+		return err
+	}
+	defer d.Free()
+
+	if err := s.xsd.Validate(d); err != nil {
+		var errs []string
+		for _, err := range err.(xsd.SchemaValidationError).Errors() {
+			errs = append(errs, err.Error())
+		}
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func parseLibxml2(buf []byte) (Validator, error) {
+	s, err := xsd.Parse(buf)
+	if err != nil {
+		return nil, err
+	}
+	return &libxml2Schema{xsd: s}, nil
+}
@@ -1,38 +1,103 @@
 package schema
 
 import (
-	"errors"
-	"strings"
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"unsafe"
+)
+
+// Validator validates XML documents against a schema. Implementations are
+// safe for concurrent use and neither modify nor retain doc.
+type Validator interface {
+	Validate(doc []byte) error
+}
+
+// readerValidator is implemented by backends that validate a document while
+// reading it.
+type readerValidator interface {
+	ValidateReader(r io.Reader) error
+}
+
+// Backend names an implementation of Validator.
+type Backend string
 
-	"github.com/lestrrat-go/libxml2"
-	"github.com/lestrrat-go/libxml2/xsd"
+const (
+	// BackendLibxml2 validates with libxml2 and requires cgo. It is the
+	// default unless built without cgo or with the purego build tag.
+	BackendLibxml2 Backend = "libxml2"
+	// BackendPureGo validates with the implementation of this package, which
+	// covers the XSD features used by the AlpineBits schemas.
+	BackendPureGo Backend = "purego"
 )
 
+// backends are the backends built into the binary.
+var backends = map[Backend]func(buf []byte) (Validator, error){
+	BackendPureGo: parsePureGo,
+}
+
+var defaultBackend = BackendPureGo
+
+// Schema is a parsed XSD. Its methods are safe for concurrent use.
 type Schema struct {
-	xsd *xsd.Schema
+	validator Validator
 }
 
 func (s *Schema) Validate(xml string) error {
-	d, err := libxml2.ParseString(xml)
-	if err != nil {
-		return err
+	return s.validator.Validate(unsafe.Slice(unsafe.StringData(xml), len(xml)))
+}
+
+func (s *Schema) ValidateBytes(xml []byte) error {
+	return s.validator.Validate(xml)
+}
+
+var buffers = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// maxPooledBuffer is the size above which buffers of ValidateReader are left
+// to the garbage collector instead of being reused.
+const maxPooledBuffer = 4 << 20
+
+// ValidateReader validates the document read from r. Backends that cannot
+// validate while reading get the document from a pooled buffer.
+func (s *Schema) ValidateReader(r io.Reader) error {
+	if v, ok := s.validator.(readerValidator); ok {
+		return v.ValidateReader(r)
 	}
-	defer d.Free()
 
-	if err := s.xsd.Validate(d); err != nil {
-		var errs []string
-		for _, err := range err.(xsd.SchemaValidationError).Errors() {
-			errs = append(errs, err.Error())
+	buf := buffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			buffers.Put(buf)
 		}
-		return errors.New(strings.Join(errs, "\n"))
+	}()
+	if _, err := buf.ReadFrom(r); err != nil {
+		return err
 	}
-	return nil
+	return s.validator.Validate(buf.Bytes())
 }
 
+// Parse parses an XSD with the default backend.
 func Parse(buf []byte) (*Schema, error) {
-	s, err := xsd.Parse(buf)
+	return ParseBackend(buf, "")
+}
+
+// ParseBackend parses an XSD with backend, or with the default backend if
+// backend is empty.
+func ParseBackend(buf []byte, backend Backend) (*Schema, error) {
+	if backend == "" {
+		backend = defaultBackend
+	}
+	parse, ok := backends[backend]
+	if !ok {
+		return nil, fmt.Errorf("schema: backend %s not available in this build", backend)
+	}
+	v, err := parse(buf)
 	if err != nil {
 		return nil, err
 	}
-	return &Schema{xsd: s}, nil
+	return &Schema{validator: v}, nil
 }
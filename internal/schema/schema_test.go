@@ -0,0 +1,141 @@
+package schema
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseTestSchema(t testing.TB, backend Backend) *Schema {
+	buf, err := os.ReadFile(filepath.Join("..", "..", "v_2024_10", "alpinebits.xsd"))
+	assert.NoError(t, err)
+	s, err := ParseBackend(buf, backend)
+	assert.NoError(t, err)
+	return s
+}
+
+// testDocuments returns the test data of a version, and each of them with an
+// unknown attribute added to the root element.
+func testDocuments(t testing.TB) [][]byte {
+	files, err := filepath.Glob(filepath.Join("..", "..", "v_2024_10", "*", "test", "data", "*.xml"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+
+	var docs [][]byte
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		invalid := bytes.Replace(b, []byte(` Version="`), []byte(` Unknown="1" Version="`), 1)
+		docs = append(docs, b, invalid, b[:len(b)/2])
+	}
+	return docs
+}
+
+// largeRatePlans returns a HotelRatePlanNotifRQ with the rate plans of the
+// test data repeated n times.
+func largeRatePlans(t testing.TB, n int) []byte {
+	b, err := os.ReadFile(filepath.Join("..", "..", "v_2024_10", "rateplans", "test", "data", "RatePlans-OTA_HotelRatePlanNotifRQ.xml"))
+	assert.NoError(t, err)
+	start := bytes.Index(b, []byte("<RatePlan "))
+	end := bytes.LastIndex(b, []byte("</RatePlan>")) + len("</RatePlan>")
+	var buf bytes.Buffer
+	buf.Write(b[:start])
+	for range n {
+		buf.Write(b[start:end])
+	}
+	buf.Write(b[end:])
+	return buf.Bytes()
+}
+
+func TestSchemaValidate(t *testing.T) {
+	for _, backend := range slices.Sorted(maps.Keys(backends)) {
+		t.Run(string(backend), func(t *testing.T) {
+			s := parseTestSchema(t, backend)
+			for _, doc := range testDocuments(t) {
+				err := s.Validate(string(doc))
+				if err == nil {
+					assert.NoError(t, s.ValidateBytes(doc))
+					assert.NoError(t, s.ValidateReader(bytes.NewReader(doc)))
+				} else {
+					assert.EqualError(t, s.ValidateBytes(doc), err.Error())
+					assert.EqualError(t, s.ValidateReader(bytes.NewReader(doc)), err.Error())
+				}
+			}
+			err := s.ValidateBytes(nil)
+			assert.Error(t, err)
+			assert.NotContains(t, err.Error(), "line")
+		})
+	}
+}
+
+// TestSchemaConcurrent checks that validations running at the same time
+// return the same results as when run one after another.
+func TestSchemaConcurrent(t *testing.T) {
+	docs := testDocuments(t)
+	docs = append(docs, largeRatePlans(t, 20))
+
+	for _, backend := range slices.Sorted(maps.Keys(backends)) {
+		t.Run(string(backend), func(t *testing.T) {
+			s := parseTestSchema(t, backend)
+			want := make([]string, len(docs))
+			for i, doc := range docs {
+				if err := s.ValidateBytes(doc); err != nil {
+					want[i] = err.Error()
+				}
+			}
+
+			var wg sync.WaitGroup
+			for g := range 16 {
+				wg.Go(func() {
+					for i := range 4 * len(docs) {
+						i := (g + i) % len(docs)
+						var got string
+						if err := s.ValidateBytes(docs[i]); err != nil {
+							got = err.Error()
+						}
+						assert.Equal(t, want[i], got)
+					}
+				})
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	doc := largeRatePlans(b, 200)
+	for _, backend := range slices.Sorted(maps.Keys(backends)) {
+		s := parseTestSchema(b, backend)
+		assert.NoError(b, s.ValidateBytes(doc))
+
+		b.Run(string(backend), func(b *testing.B) {
+			b.SetBytes(int64(len(doc)))
+			b.ReportAllocs()
+			for b.Loop() {
+				s.ValidateBytes(doc)
+			}
+		})
+		b.Run(string(backend)+"/parallel", func(b *testing.B) {
+			b.SetBytes(int64(len(doc)))
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					s.ValidateBytes(doc)
+				}
+			})
+		})
+		b.Run(string(backend)+"/reader", func(b *testing.B) {
+			b.SetBytes(int64(len(doc)))
+			b.ReportAllocs()
+			for b.Loop() {
+				s.ValidateReader(bytes.NewReader(doc))
+			}
+		})
+	}
+}
@@ -0,0 +1,527 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	xsdNS = "http://www.w3.org/2001/XMLSchema"
+	xsiNS = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// xsdSchema is a schema compiled by the pure Go backend. It is immutable once
+// compiled.
+type xsdSchema struct {
+	elements map[xml.Name]*elementDecl
+}
+
+type elementDecl struct {
+	name xml.Name
+	// simple is the type of elements with simple content only. If both simple
+	// and complex are nil, the element is of xs:anyType.
+	simple  *simpleType
+	complex *complexType
+}
+
+type complexType struct {
+	mixed        bool
+	anyType      bool
+	content      *automaton
+	simple       *simpleType
+	attributes   []*attributeDecl
+	anyAttribute bool
+}
+
+// anyType accepts any attributes and content.
+var anyType = &complexType{mixed: true, anyType: true}
+
+func (t *complexType) attribute(name string) *attributeDecl {
+	for _, a := range t.attributes {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+type attributeDecl struct {
+	name     string
+	typ      *simpleType
+	required bool
+}
+
+type wildcard struct {
+	// namespaces holds the allowed namespaces, if not any.
+	namespaces      []string
+	other           string
+	processContents string
+}
+
+func (w *wildcard) matches(ns string) bool {
+	switch {
+	case w.other != "":
+		return ns != "" && ns != w.other
+	case w.namespaces != nil:
+		for _, n := range w.namespaces {
+			if n == ns {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// particle is a node of a content model before it is compiled into an
+// automaton.
+type particle struct {
+	min, max int // max < 0 means unbounded
+	element  *elementDecl
+	wildcard *wildcard
+	choice   bool
+	children []*particle
+}
+
+func parsePureGo(buf []byte) (Validator, error) {
+	root, err := parseXSDNode(buf)
+	if err != nil {
+		return nil, err
+	}
+	return compile(root)
+}
+
+// xsdNode is an element of the XSD document.
+type xsdNode struct {
+	name     string
+	attrs    map[string]string
+	ns       map[string]string
+	children []*xsdNode
+}
+
+func (n *xsdNode) attr(name string) string {
+	return n.attrs[name]
+}
+
+// qname resolves a QName attribute value against the namespaces in scope.
+func (n *xsdNode) qname(v string) xml.Name {
+	prefix, local, ok := strings.Cut(v, ":")
+	if !ok {
+		prefix, local = "", v
+	}
+	return xml.Name{Space: n.ns[prefix], Local: local}
+}
+
+func parseXSDNode(buf []byte) (*xsdNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(buf))
+	var stack []*xsdNode
+	var root *xsdNode
+	skip := 0
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("schema: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if skip > 0 {
+				skip++
+				continue
+			}
+			n := &xsdNode{attrs: make(map[string]string), ns: make(map[string]string)}
+			if len(stack) > 0 {
+				for k, v := range stack[len(stack)-1].ns {
+					n.ns[k] = v
+				}
+			}
+			for _, a := range t.Attr {
+				switch {
+				case a.Name.Space == "xmlns":
+					n.ns[a.Name.Local] = a.Value
+				case a.Name.Space == "" && a.Name.Local == "xmlns":
+					n.ns[""] = a.Value
+				case a.Name.Space == "":
+					n.attrs[a.Name.Local] = a.Value
+				}
+			}
+			if n.ns[t.Name.Space] != xsdNS {
+				return nil, fmt.Errorf("schema: unexpected element %s:%s", t.Name.Space, t.Name.Local)
+			}
+			n.name = t.Name.Local
+			if n.name == "annotation" {
+				skip = 1
+				continue
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if skip > 0 {
+				skip--
+				continue
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if root == nil || root.name != "schema" {
+		return nil, fmt.Errorf("schema: missing element schema")
+	}
+	return root, nil
+}
+
+type compiler struct {
+	targetNS     string
+	qualified    bool
+	simpleTypes  map[string]*xsdNode
+	complexTypes map[string]*xsdNode
+	groups       map[string]*xsdNode
+	simple       map[string]*simpleType
+	complex      map[string]*complexType
+	groupContent map[string]*particle
+}
+
+func compile(root *xsdNode) (*xsdSchema, error) {
+	c := &compiler{
+		targetNS:     root.attr("targetNamespace"),
+		qualified:    root.attr("elementFormDefault") == "qualified",
+		simpleTypes:  make(map[string]*xsdNode),
+		complexTypes: make(map[string]*xsdNode),
+		groups:       make(map[string]*xsdNode),
+		simple:       make(map[string]*simpleType),
+		complex:      make(map[string]*complexType),
+		groupContent: make(map[string]*particle),
+	}
+	if root.attr("attributeFormDefault") == "qualified" {
+		return nil, unsupported(root, "attributeFormDefault")
+	}
+
+	var elements []*xsdNode
+	for _, n := range root.children {
+		switch n.name {
+		case "element":
+			elements = append(elements, n)
+		case "simpleType":
+			c.simpleTypes[n.attr("name")] = n
+		case "complexType":
+			c.complexTypes[n.attr("name")] = n
+		case "group":
+			c.groups[n.attr("name")] = n
+		default:
+			return nil, unsupported(n, "")
+		}
+	}
+
+	s := &xsdSchema{elements: make(map[xml.Name]*elementDecl)}
+	for _, n := range elements {
+		decl, err := c.element(n, true)
+		if err != nil {
+			return nil, err
+		}
+		s.elements[decl.name] = decl
+	}
+	return s, nil
+}
+
+func unsupported(n *xsdNode, attr string) error {
+	if attr != "" {
+		return fmt.Errorf("schema: unsupported attribute %s of xs:%s", attr, n.name)
+	}
+	return fmt.Errorf("schema: unsupported element xs:%s", n.name)
+}
+
+func (c *compiler) element(n *xsdNode, global bool) (*elementDecl, error) {
+	for _, attr := range []string{"ref", "substitutionGroup", "abstract", "nillable", "fixed", "default"} {
+		if _, ok := n.attrs[attr]; ok {
+			return nil, unsupported(n, attr)
+		}
+	}
+
+	decl := &elementDecl{name: xml.Name{Local: n.attr("name")}}
+	form := n.attr("form")
+	if global || form == "qualified" || (form == "" && c.qualified) {
+		decl.name.Space = c.targetNS
+	}
+
+	var err error
+	if typ := n.attr("type"); typ != "" {
+		decl.simple, decl.complex, err = c.typeByName(n.qname(typ))
+		return decl, err
+	}
+	for _, child := range n.children {
+		switch child.name {
+		case "simpleType":
+			decl.simple, err = c.simpleType(child, "")
+		case "complexType":
+			decl.complex, err = c.complexType(child)
+		default:
+			return nil, unsupported(child, "")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return decl, nil
+}
+
+// typeByName returns either the simple or the complex type called name.
+func (c *compiler) typeByName(name xml.Name) (*simpleType, *complexType, error) {
+	if name.Space == xsdNS {
+		if name.Local == "anyType" {
+			return nil, anyType, nil
+		}
+		t, err := builtinType(name.Local)
+		return t, nil, err
+	}
+	if name.Space == c.targetNS {
+		if _, ok := c.simpleTypes[name.Local]; ok {
+			t, err := c.namedSimpleType(name.Local)
+			return t, nil, err
+		}
+		if _, ok := c.complexTypes[name.Local]; ok {
+			t, err := c.namedComplexType(name.Local)
+			return nil, t, err
+		}
+	}
+	return nil, nil, fmt.Errorf("schema: type {%s}%s not found", name.Space, name.Local)
+}
+
+func (c *compiler) simpleTypeByName(n *xsdNode, qname string) (*simpleType, error) {
+	simple, complex, err := c.typeByName(n.qname(qname))
+	if err != nil {
+		return nil, err
+	}
+	if complex != nil {
+		if complex.simple == nil {
+			return nil, fmt.Errorf("schema: %s is not a simple type", qname)
+		}
+		return complex.simple, nil
+	}
+	return simple, nil
+}
+
+func (c *compiler) namedSimpleType(name string) (*simpleType, error) {
+	if t, ok := c.simple[name]; ok {
+		if t == nil {
+			return nil, fmt.Errorf("schema: simple type %s is circular", name)
+		}
+		return t, nil
+	}
+	c.simple[name] = nil
+	t, err := c.simpleType(c.simpleTypes[name], "{"+c.targetNS+"}"+name)
+	c.simple[name] = t
+	return t, err
+}
+
+func (c *compiler) namedComplexType(name string) (*complexType, error) {
+	if t, ok := c.complex[name]; ok {
+		if t == nil {
+			return nil, fmt.Errorf("schema: complex type %s is circular", name)
+		}
+		return t, nil
+	}
+	c.complex[name] = nil
+	t, err := c.complexType(c.complexTypes[name])
+	c.complex[name] = t
+	return t, err
+}
+
+func (c *compiler) complexType(n *xsdNode) (*complexType, error) {
+	t := &complexType{mixed: n.attr("mixed") == "true"}
+	var content *particle
+	for _, child := range n.children {
+		switch child.name {
+		case "sequence", "choice", "group":
+			p, err := c.particle(child)
+			if err != nil {
+				return nil, err
+			}
+			content = p
+		case "attribute", "anyAttribute":
+			if err := c.attribute(t, child); err != nil {
+				return nil, err
+			}
+		case "simpleContent":
+			if err := c.simpleContent(t, child); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, unsupported(child, "")
+		}
+	}
+	if content != nil {
+		t.content = newAutomaton(content)
+	}
+	return t, nil
+}
+
+func (c *compiler) simpleContent(t *complexType, n *xsdNode) error {
+	if len(n.children) != 1 || n.children[0].name != "extension" {
+		return unsupported(n, "")
+	}
+	ext := n.children[0]
+	simple, complex, err := c.typeByName(ext.qname(ext.attr("base")))
+	if err != nil {
+		return err
+	}
+	if complex != nil {
+		if complex.simple == nil {
+			return fmt.Errorf("schema: base %s of simple content has complex content", ext.attr("base"))
+		}
+		simple = complex.simple
+		t.attributes = append(t.attributes, complex.attributes...)
+		t.anyAttribute = complex.anyAttribute
+	}
+	t.simple = simple
+	for _, child := range ext.children {
+		switch child.name {
+		case "attribute", "anyAttribute":
+			if err := c.attribute(t, child); err != nil {
+				return err
+			}
+		default:
+			return unsupported(child, "")
+		}
+	}
+	return nil
+}
+
+func (c *compiler) attribute(t *complexType, n *xsdNode) error {
+	if n.name == "anyAttribute" {
+		t.anyAttribute = true
+		return nil
+	}
+	for _, attr := range []string{"ref", "fixed", "default", "form"} {
+		if _, ok := n.attrs[attr]; ok {
+			return unsupported(n, attr)
+		}
+	}
+
+	a := &attributeDecl{name: n.attr("name")}
+	switch n.attr("use") {
+	case "required":
+		a.required = true
+	case "prohibited":
+		return nil
+	}
+
+	var err error
+	if typ := n.attr("type"); typ != "" {
+		a.typ, err = c.simpleTypeByName(n, typ)
+	} else if len(n.children) == 1 && n.children[0].name == "simpleType" {
+		a.typ, err = c.simpleType(n.children[0], "")
+	} else {
+		a.typ, err = builtinType("anySimpleType")
+	}
+	if err != nil {
+		return err
+	}
+	t.attributes = append(t.attributes, a)
+	return nil
+}
+
+func (c *compiler) particle(n *xsdNode) (*particle, error) {
+	p := &particle{min: 1, max: 1}
+	if v, ok := n.attrs["minOccurs"]; ok {
+		min, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("schema: invalid minOccurs %q", v)
+		}
+		p.min = min
+	}
+	if v, ok := n.attrs["maxOccurs"]; ok {
+		if v == "unbounded" {
+			p.max = -1
+		} else {
+			max, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("schema: invalid maxOccurs %q", v)
+			}
+			p.max = max
+		}
+	}
+
+	switch n.name {
+	case "element":
+		decl, err := c.element(n, false)
+		if err != nil {
+			return nil, err
+		}
+		p.element = decl
+	case "any":
+		p.wildcard = &wildcard{processContents: n.attr("processContents")}
+		switch ns := n.attr("namespace"); ns {
+		case "", "##any":
+		case "##other":
+			p.wildcard.other = c.targetNS
+		default:
+			p.wildcard.namespaces = []string{}
+			for _, v := range strings.Fields(ns) {
+				switch v {
+				case "##targetNamespace":
+					v = c.targetNS
+				case "##local":
+					v = ""
+				}
+				p.wildcard.namespaces = append(p.wildcard.namespaces, v)
+			}
+		}
+	case "sequence", "choice":
+		p.choice = n.name == "choice"
+		for _, child := range n.children {
+			switch child.name {
+			case "element", "any", "sequence", "choice", "group":
+			default:
+				return nil, unsupported(child, "")
+			}
+			cp, err := c.particle(child)
+			if err != nil {
+				return nil, err
+			}
+			p.children = append(p.children, cp)
+		}
+	case "group":
+		content, err := c.group(n.qname(n.attr("ref")))
+		if err != nil {
+			return nil, err
+		}
+		p.children = []*particle{content}
+	default:
+		return nil, unsupported(n, "")
+	}
+	return p, nil
+}
+
+func (c *compiler) group(name xml.Name) (*particle, error) {
+	if p, ok := c.groupContent[name.Local]; ok {
+		if p == nil {
+			return nil, fmt.Errorf("schema: group %s is circular", name.Local)
+		}
+		return p, nil
+	}
+	n, ok := c.groups[name.Local]
+	if !ok || name.Space != c.targetNS {
+		return nil, fmt.Errorf("schema: group {%s}%s not found", name.Space, name.Local)
+	}
+	if len(n.children) != 1 || (n.children[0].name != "sequence" && n.children[0].name != "choice") {
+		return nil, unsupported(n, "")
+	}
+
+	c.groupContent[name.Local] = nil
+	p, err := c.particle(n.children[0])
+	if err != nil {
+		return nil, err
+	}
+	c.groupContent[name.Local] = p
+	return p, nil
+}
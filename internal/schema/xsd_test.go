@@ -0,0 +1,206 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testXSD = `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+           elementFormDefault="qualified"
+           targetNamespace="urn:test"
+           xmlns="urn:test">
+  <xs:element name="Root">
+    <xs:complexType>
+      <xs:sequence>
+        <xs:element name="Code" type="Code" maxOccurs="2"/>
+        <xs:choice minOccurs="0">
+          <xs:element name="Date" type="DateOrDateTime"/>
+          <xs:element name="Amount">
+            <xs:complexType>
+              <xs:simpleContent>
+                <xs:extension base="Amount">
+                  <xs:attribute name="Currency" use="required">
+                    <xs:simpleType>
+                      <xs:restriction base="xs:string">
+                        <xs:pattern value="[A-Z]{3}"/>
+                      </xs:restriction>
+                    </xs:simpleType>
+                  </xs:attribute>
+                </xs:extension>
+              </xs:simpleContent>
+            </xs:complexType>
+          </xs:element>
+        </xs:choice>
+        <xs:group ref="Extras" minOccurs="0"/>
+      </xs:sequence>
+      <xs:attribute name="Version" use="required"/>
+      <xs:attribute name="Language" type="xs:language"/>
+    </xs:complexType>
+  </xs:element>
+  <xs:group name="Extras">
+    <xs:sequence>
+      <xs:element name="Text">
+        <xs:complexType mixed="true">
+          <xs:attribute name="Type" type="Type"/>
+        </xs:complexType>
+      </xs:element>
+      <xs:element name="Any">
+        <xs:complexType>
+          <xs:sequence>
+            <xs:any processContents="lax" minOccurs="0" maxOccurs="unbounded"/>
+          </xs:sequence>
+          <xs:anyAttribute processContents="lax"/>
+        </xs:complexType>
+      </xs:element>
+    </xs:sequence>
+  </xs:group>
+  <xs:simpleType name="Code">
+    <xs:restriction base="xs:string">
+      <xs:minLength value="1"/>
+      <xs:maxLength value="3"/>
+    </xs:restriction>
+  </xs:simpleType>
+  <xs:simpleType name="Type">
+    <xs:restriction base="xs:integer">
+      <xs:enumeration value="1"/>
+      <xs:enumeration value="3"/>
+    </xs:restriction>
+  </xs:simpleType>
+  <xs:simpleType name="Amount">
+    <xs:restriction base="xs:decimal">
+      <xs:minExclusive value="0"/>
+      <xs:maxInclusive value="1000"/>
+    </xs:restriction>
+  </xs:simpleType>
+  <xs:simpleType name="DateOrDateTime">
+    <xs:union memberTypes="xs:date xs:dateTime"/>
+  </xs:simpleType>
+</xs:schema>`
+
+func TestPureGo(t *testing.T) {
+	s, err := ParseBackend([]byte(testXSD), BackendPureGo)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		xml  string
+		err  string
+	}{
+		{
+			name: "valid",
+			xml: `<Root xmlns="urn:test" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="urn:test test.xsd" Version="1" Language="de-AT">
+				<Code>A</Code><Code> B </Code>
+				<Amount Currency="EUR"> 12.50 </Amount>
+				<Text Type="03">free text</Text>
+				<Any Foo="bar"><Unknown><Code>ABC</Code></Unknown></Any>
+			</Root>`,
+		},
+		{
+			name: "union",
+			xml:  `<Root xmlns="urn:test" Version="1"><Code>A</Code><Date>2024-02-29T10:00:00Z</Date></Root>`,
+		},
+		{
+			name: "syntax error",
+			xml:  `<Root xmlns="urn:test" Version="1">`,
+			err:  "XML syntax error on line 1: unexpected EOF",
+		},
+		{
+			name: "unknown root",
+			xml:  `<Root Version="1"><Code>A</Code></Root>`,
+			err:  "Element 'Root': No matching global declaration available for the validation root.",
+		},
+		{
+			name: "attributes",
+			xml:  `<Root xmlns="urn:test" Language="d3" Foo="1"><Code>A</Code></Root>`,
+			err: "Element '{urn:test}Root', attribute 'Language': 'd3' is not a valid value of the atomic type 'xs:language'.\n" +
+				"Element '{urn:test}Root', attribute 'Foo': The attribute 'Foo' is not allowed.\n" +
+				"Element '{urn:test}Root': The attribute 'Version' is required but missing.",
+		},
+		{
+			name: "missing child",
+			xml:  `<Root xmlns="urn:test" Version="1"></Root>`,
+			err:  "Element '{urn:test}Root': Missing child element(s). Expected is ( {urn:test}Code ).",
+		},
+		{
+			name: "unexpected child",
+			xml:  `<Root xmlns="urn:test" Version="1"><Code>A</Code><Code>B</Code><Code>C</Code></Root>`,
+			err:  "Element '{urn:test}Code': This element is not expected. Expected is one of ( {urn:test}Date, {urn:test}Amount, {urn:test}Text ).",
+		},
+		{
+			name: "incomplete group",
+			xml:  `<Root xmlns="urn:test" Version="1"><Code>A</Code><Text/></Root>`,
+			err:  "Element '{urn:test}Root': Missing child element(s). Expected is ( {urn:test}Any ).",
+		},
+		{
+			name: "facets",
+			xml: `<Root xmlns="urn:test" Version="1"><Code></Code><Code>ABCD</Code><Amount Currency="eur">0</Amount>` +
+				`<Text Type="2"/><Any><Code/><Root/></Any></Root>`,
+			err: "Element '{urn:test}Code': [facet 'minLength'] The value has a length of '0'; this underruns the allowed minimum length of '1'.\n" +
+				"Element '{urn:test}Code': [facet 'maxLength'] The value has a length of '4'; this exceeds the allowed maximum length of '3'.\n" +
+				"Element '{urn:test}Amount', attribute 'Currency': [facet 'pattern'] The value 'eur' is not accepted by the pattern '[A-Z]{3}'.\n" +
+				"Element '{urn:test}Amount': [facet 'minExclusive'] The value '0' must be greater than '0'.\n" +
+				"Element '{urn:test}Text', attribute 'Type': [facet 'enumeration'] The value '2' is not an element of the set {'1', '3'}.\n" +
+				"Element '{urn:test}Root': The attribute 'Version' is required but missing.\n" +
+				"Element '{urn:test}Root': Missing child element(s). Expected is ( {urn:test}Code ).",
+		},
+		{
+			name: "dates",
+			xml:  `<Root xmlns="urn:test" Version="1"><Code>A</Code><Date>2023-02-29</Date></Root>`,
+			err:  "Element '{urn:test}Date': '2023-02-29' is not a valid value of the union type '{urn:test}DateOrDateTime'.",
+		},
+		{
+			name: "content",
+			xml:  `<Root xmlns="urn:test" Version="1">text<Code><b/></Code><Amount Currency="EUR">x</Amount></Root>`,
+			err: "Element '{urn:test}Root': Character content other than whitespace is not allowed because the content type is 'element-only'.\n" +
+				"Element '{urn:test}Code': Element content is not allowed, because the content type is a simple type.\n" +
+				"Element '{urn:test}Amount': 'x' is not a valid value of the atomic type '{urn:test}Amount'.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Validate(tt.xml)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPureGoUnsupported(t *testing.T) {
+	for _, xsd := range []string{
+		`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"><xs:import namespace="urn:other"/></xs:schema>`,
+		`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"><xs:element name="A" type="xs:gYear"/></xs:schema>`,
+		`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"><xs:element name="A" type="A"/><xs:simpleType name="A"><xs:restriction base="xs:string"><xs:pattern value="\p{IsBasicLatin}"/></xs:restriction></xs:simpleType></xs:schema>`,
+	} {
+		_, err := ParseBackend([]byte(xsd), BackendPureGo)
+		assert.Error(t, err)
+	}
+}
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   []string
+		invalid []string
+	}{
+		{`\S+@\S+`, []string{"a@b"}, []string{"a @b", "@b"}},
+		{`[0-9]*\.?[0-9]*`, []string{"", "1.5", "12"}, []string{"1,5"}},
+		{`1|true`, []string{"1", "true"}, []string{"1true"}},
+		{`https?://.+`, []string{"https://example.com"}, []string{"ftp://x", "https://a\nb"}},
+		{`\d{2}$`, []string{"12$"}, []string{"12"}},
+	}
+	for _, tt := range tests {
+		re, err := compilePattern(tt.pattern)
+		assert.NoError(t, err)
+		for _, v := range tt.valid {
+			assert.True(t, re.MatchString(v), "%s should match %q", tt.pattern, v)
+		}
+		for _, v := range tt.invalid {
+			assert.False(t, re.MatchString(v), "%s should not match %q", tt.pattern, v)
+		}
+	}
+}
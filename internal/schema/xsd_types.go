@@ -0,0 +1,514 @@
+package schema
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type whiteSpace int
+
+const (
+	whiteSpacePreserve whiteSpace = iota
+	whiteSpaceReplace
+	whiteSpaceCollapse
+)
+
+func (ws whiteSpace) normalize(v string) string {
+	switch ws {
+	case whiteSpaceReplace:
+		return strings.Map(func(r rune) rune {
+			if r == '\t' || r == '\n' || r == '\r' {
+				return ' '
+			}
+			return r
+		}, v)
+	case whiteSpaceCollapse:
+		return strings.Join(strings.FieldsFunc(v, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		}), " ")
+	}
+	return v
+}
+
+type simpleType struct {
+	// name is the qualified name used in messages, empty for local types.
+	name       string
+	lexical    func(v string) bool
+	numeric    bool
+	whiteSpace whiteSpace
+	base       *simpleType
+	members    []*simpleType
+	facets     facets
+}
+
+type facets struct {
+	enumeration    []string
+	patterns       []*regexp.Regexp
+	patternSources []string
+	length         int
+	minLength      int
+	maxLength      int
+	bounds         []bound
+}
+
+type bound struct {
+	facet string
+	value string
+	rat   *big.Rat
+}
+
+// validate returns why v is not a valid value of t, or "" if it is valid.
+func (t *simpleType) validate(v string) string {
+	v = t.whiteSpace.normalize(v)
+	if t.members != nil {
+		valid := false
+		for _, m := range t.members {
+			if m.validate(v) == "" {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Sprintf("'%s' is not a valid value of %s.", v, t.describe("union"))
+		}
+	} else if !t.lexical(v) {
+		return fmt.Sprintf("'%s' is not a valid value of %s.", v, t.describe("atomic"))
+	}
+	return t.checkFacets(v)
+}
+
+func (t *simpleType) describe(variety string) string {
+	if t.name == "" {
+		return "the local " + variety + " type"
+	}
+	return fmt.Sprintf("the %s type '%s'", variety, t.name)
+}
+
+// checkFacets checks the facets of t and its base types, starting with the
+// built-in type.
+func (t *simpleType) checkFacets(v string) string {
+	if t.base != nil {
+		if msg := t.base.checkFacets(v); msg != "" {
+			return msg
+		}
+	}
+	f := &t.facets
+
+	if f.enumeration != nil {
+		found := false
+		for _, e := range f.enumeration {
+			if t.equal(v, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			set := make([]string, len(f.enumeration))
+			for i, e := range f.enumeration {
+				set[i] = "'" + e + "'"
+			}
+			return fmt.Sprintf("[facet 'enumeration'] The value '%s' is not an element of the set {%s}.", v, strings.Join(set, ", "))
+		}
+	}
+
+	if f.patterns != nil {
+		matched := false
+		for _, p := range f.patterns {
+			if p.MatchString(v) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Sprintf("[facet 'pattern'] The value '%s' is not accepted by the pattern '%s'.", v, strings.Join(f.patternSources, "|"))
+		}
+	}
+
+	if f.length >= 0 || f.minLength >= 0 || f.maxLength >= 0 {
+		n := utf8.RuneCountInString(v)
+		switch {
+		case f.length >= 0 && n != f.length:
+			return fmt.Sprintf("[facet 'length'] The value has a length of '%d'; this differs from the allowed length of '%d'.", n, f.length)
+		case f.minLength >= 0 && n < f.minLength:
+			return fmt.Sprintf("[facet 'minLength'] The value has a length of '%d'; this underruns the allowed minimum length of '%d'.", n, f.minLength)
+		case f.maxLength >= 0 && n > f.maxLength:
+			return fmt.Sprintf("[facet 'maxLength'] The value has a length of '%d'; this exceeds the allowed maximum length of '%d'.", n, f.maxLength)
+		}
+	}
+
+	if f.bounds != nil {
+		r, _ := new(big.Rat).SetString(v)
+		for _, b := range f.bounds {
+			cmp := r.Cmp(b.rat)
+			switch {
+			case b.facet == "minInclusive" && cmp < 0:
+				return fmt.Sprintf("[facet 'minInclusive'] The value '%s' is less than the minimum value allowed ('%s').", v, b.value)
+			case b.facet == "maxInclusive" && cmp > 0:
+				return fmt.Sprintf("[facet 'maxInclusive'] The value '%s' is greater than the maximum value allowed ('%s').", v, b.value)
+			case b.facet == "minExclusive" && cmp <= 0:
+				return fmt.Sprintf("[facet 'minExclusive'] The value '%s' must be greater than '%s'.", v, b.value)
+			case b.facet == "maxExclusive" && cmp >= 0:
+				return fmt.Sprintf("[facet 'maxExclusive'] The value '%s' must be less than '%s'.", v, b.value)
+			}
+		}
+	}
+	return ""
+}
+
+// equal compares values in the value space of t.
+func (t *simpleType) equal(a, b string) bool {
+	if t.numeric {
+		ra, ok1 := new(big.Rat).SetString(a)
+		rb, ok2 := new(big.Rat).SetString(b)
+		return ok1 && ok2 && ra.Cmp(rb) == 0
+	}
+	return a == b
+}
+
+func noFacets() facets {
+	return facets{length: -1, minLength: -1, maxLength: -1}
+}
+
+func (c *compiler) simpleType(n *xsdNode, name string) (*simpleType, error) {
+	if len(n.children) != 1 {
+		return nil, unsupported(n, "")
+	}
+	switch child := n.children[0]; child.name {
+	case "restriction":
+		return c.restriction(child, name)
+	case "union":
+		return c.union(child, name)
+	default:
+		return nil, unsupported(child, "")
+	}
+}
+
+func (c *compiler) restriction(n *xsdNode, name string) (*simpleType, error) {
+	var base *simpleType
+	var err error
+	facetNodes := n.children
+	if qname := n.attr("base"); qname != "" {
+		base, err = c.simpleTypeByName(n, qname)
+	} else if len(n.children) > 0 && n.children[0].name == "simpleType" {
+		base, err = c.simpleType(n.children[0], "")
+		facetNodes = n.children[1:]
+	} else {
+		err = fmt.Errorf("schema: restriction without base type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t := &simpleType{
+		name:       name,
+		lexical:    base.lexical,
+		numeric:    base.numeric,
+		whiteSpace: base.whiteSpace,
+		base:       base,
+		members:    base.members,
+		facets:     noFacets(),
+	}
+	for _, f := range facetNodes {
+		value := f.attr("value")
+		switch f.name {
+		case "enumeration":
+			t.facets.enumeration = append(t.facets.enumeration, value)
+		case "pattern":
+			re, err := compilePattern(value)
+			if err != nil {
+				return nil, err
+			}
+			t.facets.patterns = append(t.facets.patterns, re)
+			t.facets.patternSources = append(t.facets.patternSources, value)
+		case "length", "minLength", "maxLength":
+			l, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("schema: invalid %s %q", f.name, value)
+			}
+			switch f.name {
+			case "length":
+				t.facets.length = l
+			case "minLength":
+				t.facets.minLength = l
+			case "maxLength":
+				t.facets.maxLength = l
+			}
+		case "minInclusive", "maxInclusive", "minExclusive", "maxExclusive":
+			if !t.numeric {
+				return nil, fmt.Errorf("schema: unsupported facet %s of non-numeric type", f.name)
+			}
+			r, ok := new(big.Rat).SetString(value)
+			if !ok {
+				return nil, fmt.Errorf("schema: invalid %s %q", f.name, value)
+			}
+			t.facets.bounds = append(t.facets.bounds, bound{facet: f.name, value: value, rat: r})
+		case "whiteSpace":
+			switch value {
+			case "preserve":
+				t.whiteSpace = whiteSpacePreserve
+			case "replace":
+				t.whiteSpace = whiteSpaceReplace
+			case "collapse":
+				t.whiteSpace = whiteSpaceCollapse
+			}
+		default:
+			return nil, unsupported(f, "")
+		}
+	}
+	return t, nil
+}
+
+func (c *compiler) union(n *xsdNode, name string) (*simpleType, error) {
+	t := &simpleType{name: name, facets: noFacets()}
+	for _, qname := range strings.Fields(n.attr("memberTypes")) {
+		m, err := c.simpleTypeByName(n, qname)
+		if err != nil {
+			return nil, err
+		}
+		t.members = append(t.members, m)
+	}
+	for _, child := range n.children {
+		if child.name != "simpleType" {
+			return nil, unsupported(child, "")
+		}
+		m, err := c.simpleType(child, "")
+		if err != nil {
+			return nil, err
+		}
+		t.members = append(t.members, m)
+	}
+	return t, nil
+}
+
+// compilePattern translates an XSD regular expression, which is implicitly
+// anchored and has its own escapes, into a Go one.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case ch == '\\' && i+1 < len(pattern):
+			i++
+			esc := pattern[i]
+			switch {
+			case esc == 'd':
+				b.WriteString(`\p{Nd}`)
+			case esc == 'D' && !inClass:
+				b.WriteString(`\P{Nd}`)
+			case esc == 's' && inClass:
+				b.WriteString(` \t\n\r`)
+			case esc == 's':
+				b.WriteString(`[ \t\n\r]`)
+			case esc == 'S' && !inClass:
+				b.WriteString(`[^ \t\n\r]`)
+			case esc == 'i' && !inClass:
+				b.WriteString(`[\p{L}_:]`)
+			case esc == 'c' && !inClass:
+				b.WriteString(`[\p{L}\p{Nd}\p{Mn}\p{Mc}._:\-]`)
+			case esc == 'w' && !inClass:
+				b.WriteString(`[^\p{P}\p{Z}\p{C}]`)
+			case esc == 'W' && !inClass:
+				b.WriteString(`[\p{P}\p{Z}\p{C}]`)
+			case esc == 'p' || esc == 'P':
+				if strings.HasPrefix(pattern[i+1:], "{Is") {
+					return nil, fmt.Errorf("schema: unsupported block escape in pattern %q", pattern)
+				}
+				b.WriteByte('\\')
+				b.WriteByte(esc)
+			case strings.IndexByte(`nrt\|.-^?*+{}()[]`, esc) >= 0:
+				b.WriteByte('\\')
+				b.WriteByte(esc)
+			default:
+				return nil, fmt.Errorf("schema: unsupported escape \\%c in pattern %q", esc, pattern)
+			}
+		case inClass:
+			if ch == '-' && i+1 < len(pattern) && pattern[i+1] == '[' {
+				return nil, fmt.Errorf("schema: unsupported character class subtraction in pattern %q", pattern)
+			}
+			if ch == ']' {
+				inClass = false
+			}
+			b.WriteByte(ch)
+		case ch == '[':
+			inClass = true
+			b.WriteByte(ch)
+			if i+1 < len(pattern) && pattern[i+1] == '^' {
+				i++
+				b.WriteByte('^')
+			}
+		case ch == '.':
+			b.WriteString(`[^\n\r]`)
+		case ch == '^' || ch == '$':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	re, err := regexp.Compile(`^(?:` + b.String() + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("schema: invalid pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+var builtinTypes = map[string]*simpleType{}
+
+func init() {
+	add := func(name string, ws whiteSpace, numeric bool, lexical func(string) bool) {
+		builtinTypes[name] = &simpleType{
+			name:       "xs:" + name,
+			lexical:    lexical,
+			numeric:    numeric,
+			whiteSpace: ws,
+			facets:     noFacets(),
+		}
+	}
+	anyValue := func(string) bool { return true }
+
+	add("anySimpleType", whiteSpacePreserve, false, anyValue)
+	add("string", whiteSpacePreserve, false, anyValue)
+	add("normalizedString", whiteSpaceReplace, false, anyValue)
+	add("token", whiteSpaceCollapse, false, anyValue)
+	add("anyURI", whiteSpaceCollapse, false, anyValue)
+	add("language", whiteSpaceCollapse, false, languagePattern.MatchString)
+	add("boolean", whiteSpaceCollapse, false, func(v string) bool {
+		return v == "true" || v == "false" || v == "1" || v == "0"
+	})
+	add("decimal", whiteSpaceCollapse, true, decimalPattern.MatchString)
+	add("date", whiteSpaceCollapse, false, isDate)
+	add("dateTime", whiteSpaceCollapse, false, isDateTime)
+	add("time", whiteSpaceCollapse, false, isTime)
+
+	integers := []struct {
+		name     string
+		min, max string
+	}{
+		{"integer", "", ""},
+		{"nonNegativeInteger", "0", ""},
+		{"positiveInteger", "1", ""},
+		{"nonPositiveInteger", "", "0"},
+		{"negativeInteger", "", "-1"},
+		{"long", "-9223372036854775808", "9223372036854775807"},
+		{"int", "-2147483648", "2147483647"},
+		{"short", "-32768", "32767"},
+		{"byte", "-128", "127"},
+		{"unsignedLong", "0", "18446744073709551615"},
+		{"unsignedInt", "0", "4294967295"},
+		{"unsignedShort", "0", "65535"},
+		{"unsignedByte", "0", "255"},
+	}
+	for _, i := range integers {
+		min, _ := new(big.Int).SetString(i.min, 10)
+		max, _ := new(big.Int).SetString(i.max, 10)
+		add(i.name, whiteSpaceCollapse, true, func(v string) bool {
+			if !integerPattern.MatchString(v) {
+				return false
+			}
+			n, _ := new(big.Int).SetString(strings.TrimPrefix(v, "+"), 10)
+			return (min == nil || n.Cmp(min) >= 0) && (max == nil || n.Cmp(max) <= 0)
+		})
+	}
+}
+
+func builtinType(name string) (*simpleType, error) {
+	t, ok := builtinTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("schema: unsupported built-in type xs:%s", name)
+	}
+	return t, nil
+}
+
+var (
+	languagePattern = regexp.MustCompile(`^[a-zA-Z]{1,8}(-[a-zA-Z0-9]{1,8})*$`)
+	decimalPattern  = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+	integerPattern  = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	datePattern     = regexp.MustCompile(`^(-?[0-9]{4,})-([0-9]{2})-([0-9]{2})$`)
+	timePattern     = regexp.MustCompile(`^([0-9]{2}):([0-9]{2}):([0-9]{2})(\.[0-9]+)?$`)
+	zonePattern     = regexp.MustCompile(`(Z|[+-]([0-9]{2}):([0-9]{2}))$`)
+)
+
+func isDate(v string) bool {
+	v, ok := trimZone(v)
+	return ok && isDateValue(v)
+}
+
+func isTime(v string) bool {
+	v, ok := trimZone(v)
+	return ok && isTimeValue(v)
+}
+
+func isDateTime(v string) bool {
+	v, ok := trimZone(v)
+	if !ok {
+		return false
+	}
+	date, time, found := strings.Cut(v, "T")
+	return found && isDateValue(date) && isTimeValue(time)
+}
+
+// trimZone removes a valid time zone from v.
+func trimZone(v string) (string, bool) {
+	m := zonePattern.FindStringSubmatchIndex(v)
+	if m == nil {
+		return v, true
+	}
+	if m[4] >= 0 {
+		hh, _ := strconv.Atoi(v[m[4]:m[5]])
+		mm, _ := strconv.Atoi(v[m[6]:m[7]])
+		if hh > 14 || mm > 59 || (hh == 14 && mm > 0) {
+			return v, false
+		}
+	}
+	return v[:m[0]], true
+}
+
+func isDateValue(v string) bool {
+	m := datePattern.FindStringSubmatch(v)
+	if m == nil {
+		return false
+	}
+	digits := strings.TrimPrefix(m[1], "-")
+	if len(digits) > 4 && digits[0] == '0' {
+		return false
+	}
+	year, err := strconv.Atoi(m[1])
+	if err != nil || year == 0 {
+		return false
+	}
+	month, _ := strconv.Atoi(m[2])
+	day, _ := strconv.Atoi(m[3])
+	return month >= 1 && month <= 12 && day >= 1 && day <= daysIn(month, year)
+}
+
+func daysIn(month, year int) int {
+	switch month {
+	case 2:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
+
+func isTimeValue(v string) bool {
+	m := timePattern.FindStringSubmatch(v)
+	if m == nil {
+		return false
+	}
+	hh, _ := strconv.Atoi(m[1])
+	mm, _ := strconv.Atoi(m[2])
+	ss, _ := strconv.Atoi(m[3])
+	if hh == 24 {
+		return mm == 0 && ss == 0 && strings.Trim(m[4], ".0") == ""
+	}
+	return hh < 24 && mm < 60 && ss < 60
+}
@@ -0,0 +1,505 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// automaton is a nondeterministic finite automaton matching the child
+// elements of a complex type.
+type automaton struct {
+	states   []state
+	start    int
+	accept   int
+	matchers sync.Pool
+}
+
+type state struct {
+	eps     []int
+	edges   []edge
+	closure []int
+}
+
+type edge struct {
+	element  *elementDecl
+	wildcard *wildcard
+	to       int
+}
+
+func (e edge) matches(name xml.Name) bool {
+	if e.element != nil {
+		return e.element.name == name
+	}
+	return e.wildcard.matches(name.Space)
+}
+
+func newAutomaton(p *particle) *automaton {
+	a := &automaton{}
+	a.start, a.accept = a.fragment(p)
+	for i := range a.states {
+		a.states[i].closure = a.epsClosure(i)
+	}
+	return a
+}
+
+func (a *automaton) newState() int {
+	a.states = append(a.states, state{})
+	return len(a.states) - 1
+}
+
+func (a *automaton) eps(from, to int) {
+	a.states[from].eps = append(a.states[from].eps, to)
+}
+
+// fragment adds the states matching p with its occurrences.
+func (a *automaton) fragment(p *particle) (start, end int) {
+	start = a.newState()
+	end = start
+	for range p.min {
+		s, e := a.term(p)
+		a.eps(end, s)
+		end = e
+	}
+	final := a.newState()
+	if p.max < 0 {
+		s, e := a.term(p)
+		a.eps(end, s)
+		a.eps(e, s)
+		a.eps(e, final)
+	} else {
+		for range p.max - p.min {
+			a.eps(end, final)
+			s, e := a.term(p)
+			a.eps(end, s)
+			end = e
+		}
+	}
+	a.eps(end, final)
+	return start, final
+}
+
+// term adds the states matching a single occurrence of p.
+func (a *automaton) term(p *particle) (start, end int) {
+	start = a.newState()
+	switch {
+	case p.element != nil || p.wildcard != nil:
+		end = a.newState()
+		a.states[start].edges = append(a.states[start].edges, edge{element: p.element, wildcard: p.wildcard, to: end})
+	case p.choice:
+		end = a.newState()
+		for _, child := range p.children {
+			s, e := a.fragment(child)
+			a.eps(start, s)
+			a.eps(e, end)
+		}
+	default:
+		end = start
+		for _, child := range p.children {
+			s, e := a.fragment(child)
+			a.eps(end, s)
+			end = e
+		}
+	}
+	return start, end
+}
+
+func (a *automaton) epsClosure(from int) []int {
+	seen := map[int]bool{from: true}
+	stack := []int{from}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, to := range a.states[s].eps {
+			if !seen[to] {
+				seen[to] = true
+				stack = append(stack, to)
+			}
+		}
+	}
+	closure := make([]int, 0, len(seen))
+	for s := range seen {
+		// states without edges are only needed to find the accepting state
+		if len(a.states[s].edges) > 0 || s == a.accept {
+			closure = append(closure, s)
+		}
+	}
+	slices.Sort(closure)
+	return closure
+}
+
+// matcher tracks the states of an automaton while matching child elements.
+type matcher struct {
+	a       *automaton
+	current []int
+	next    []int
+	marks   []bool
+}
+
+func (a *automaton) matcher() *matcher {
+	m, ok := a.matchers.Get().(*matcher)
+	if !ok {
+		m = &matcher{a: a, marks: make([]bool, len(a.states))}
+	}
+	m.current = append(m.current[:0], a.states[a.start].closure...)
+	return m
+}
+
+func (a *automaton) release(m *matcher) {
+	a.matchers.Put(m)
+}
+
+// step advances by an element called name and returns the edge matching it,
+// or false and keeps its states if the element is not expected.
+func (m *matcher) step(name xml.Name) (edge, bool) {
+	var matched edge
+	found := false
+	m.next = m.next[:0]
+	for _, s := range m.current {
+		for _, e := range m.a.states[s].edges {
+			if !e.matches(name) {
+				continue
+			}
+			if !found {
+				matched, found = e, true
+			}
+			for _, t := range m.a.states[e.to].closure {
+				if !m.marks[t] {
+					m.marks[t] = true
+					m.next = append(m.next, t)
+				}
+			}
+		}
+	}
+	if !found {
+		return edge{}, false
+	}
+	for _, t := range m.next {
+		m.marks[t] = false
+	}
+	m.current, m.next = m.next, m.current
+	return matched, true
+}
+
+func (m *matcher) accepts() bool {
+	return slices.Contains(m.current, m.a.accept)
+}
+
+// expected describes the elements allowed next, like libxml2 does.
+func (m *matcher) expected() string {
+	var names []string
+	for _, s := range m.current {
+		for _, e := range m.a.states[s].edges {
+			name := "##any"
+			if e.element != nil {
+				name = formatName(e.element.name)
+			}
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return " Expected is ( " + names[0] + " )."
+	}
+	return " Expected is one of ( " + strings.Join(names, ", ") + " )."
+}
+
+// node is an element of the validated document.
+type node struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*node
+	text     []byte
+}
+
+const nodesPerChunk = 256
+
+// maxPooledNodes is the number of nodes above which a document is left to the
+// garbage collector instead of being reused.
+const maxPooledNodes = 1 << 16
+
+// document holds the nodes and errors of a validation. Its memory is reused by
+// later validations.
+type document struct {
+	reader bytes.Reader
+	chunks [][]node
+	used   int
+	stack  []*node
+	errs   []string
+}
+
+var documents = sync.Pool{
+	New: func() any { return new(document) },
+}
+
+func (d *document) newNode(name xml.Name, attrs []xml.Attr) *node {
+	i := d.used / nodesPerChunk
+	if i == len(d.chunks) {
+		d.chunks = append(d.chunks, make([]node, nodesPerChunk))
+	}
+	n := &d.chunks[i][d.used%nodesPerChunk]
+	d.used++
+	n.name, n.attrs = name, attrs
+	n.children, n.text = n.children[:0], n.text[:0]
+	return n
+}
+
+func (d *document) release() {
+	if d.used > maxPooledNodes {
+		return
+	}
+	for _, chunk := range d.chunks {
+		for i := range chunk {
+			chunk[i].attrs = nil
+			clear(chunk[i].children)
+		}
+	}
+	d.reader.Reset(nil)
+	d.used = 0
+	d.stack = d.stack[:0]
+	d.errs = d.errs[:0]
+	documents.Put(d)
+}
+
+func (d *document) parse(r io.Reader) (*node, error) {
+	dec := xml.NewDecoder(r)
+	stack := d.stack[:0]
+	defer func() { d.stack = stack }()
+	var root *node
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			for i, a := range t.Attr {
+				for _, b := range t.Attr[:i] {
+					if a.Name == b.Name {
+						return nil, fmt.Errorf("XML syntax error: attribute %s redefined", a.Name.Local)
+					}
+				}
+			}
+			n := d.newNode(t.Name, t.Attr)
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root != nil {
+				return nil, errors.New("XML syntax error: extra content at the end of the document")
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				n := stack[len(stack)-1]
+				n.text = append(n.text, t...)
+			} else if !isWhiteSpace(t) {
+				return nil, errors.New("XML syntax error: content outside of the root element")
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("XML syntax error: document is empty")
+	}
+	return root, nil
+}
+
+func isWhiteSpace(v []byte) bool {
+	return len(bytes.Trim(v, " \t\n\r")) == 0
+}
+
+func formatName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+func (s *xsdSchema) Validate(doc []byte) error {
+	d := documents.Get().(*document)
+	defer d.release()
+	d.reader.Reset(doc)
+	return s.validate(d, &d.reader)
+}
+
+func (s *xsdSchema) ValidateReader(r io.Reader) error {
+	d := documents.Get().(*document)
+	defer d.release()
+	return s.validate(d, r)
+}
+
+func (s *xsdSchema) validate(d *document, r io.Reader) error {
+	root, err := d.parse(r)
+	if err != nil {
+		return err
+	}
+
+	v := validation{schema: s, errs: d.errs}
+	if decl, ok := s.elements[root.name]; ok {
+		v.element(root, decl)
+	} else {
+		v.errorf(root, "No matching global declaration available for the validation root.")
+	}
+	d.errs = v.errs
+	if len(v.errs) > 0 {
+		return errors.New(strings.Join(v.errs, "\n"))
+	}
+	return nil
+}
+
+type validation struct {
+	schema *xsdSchema
+	errs   []string
+}
+
+func (v *validation) errorf(n *node, format string, a ...any) {
+	v.errs = append(v.errs, fmt.Sprintf("Element '%s': ", formatName(n.name))+fmt.Sprintf(format, a...))
+}
+
+func (v *validation) attrErrorf(n *node, attr xml.Name, format string, a ...any) {
+	v.errs = append(v.errs, fmt.Sprintf("Element '%s', attribute '%s': ", formatName(n.name), formatName(attr))+fmt.Sprintf(format, a...))
+}
+
+func (v *validation) element(n *node, decl *elementDecl) {
+	if decl.simple != nil {
+		v.attributes(n, nil)
+		if len(n.children) > 0 {
+			v.errorf(n, "Element content is not allowed, because the content type is a simple type.")
+			return
+		}
+		if msg := decl.simple.validate(string(n.text)); msg != "" {
+			v.errorf(n, "%s", msg)
+		}
+		return
+	}
+
+	t := decl.complex
+	if t == nil {
+		t = anyType
+	}
+	if t.anyType {
+		v.lax(n.children)
+		return
+	}
+
+	v.attributes(n, t)
+	switch {
+	case t.simple != nil:
+		if len(n.children) > 0 {
+			v.errorf(n, "Element content is not allowed, because the content type is a simple type.")
+			return
+		}
+		if msg := t.simple.validate(string(n.text)); msg != "" {
+			v.errorf(n, "%s", msg)
+		}
+	case t.content == nil:
+		if !t.mixed && !isWhiteSpace(n.text) {
+			v.errorf(n, "Character content is not allowed, because the content type is empty.")
+		}
+		if len(n.children) > 0 {
+			v.errorf(n.children[0], "This element is not expected.")
+		}
+	default:
+		if !t.mixed && !isWhiteSpace(n.text) {
+			v.errorf(n, "Character content other than whitespace is not allowed because the content type is 'element-only'.")
+		}
+		v.content(n, t.content)
+	}
+}
+
+func (v *validation) content(n *node, a *automaton) {
+	m := a.matcher()
+	defer a.release(m)
+	for _, child := range n.children {
+		e, ok := m.step(child.name)
+		if !ok {
+			v.errorf(child, "This element is not expected.%s", m.expected())
+			return
+		}
+		if e.element != nil {
+			v.element(child, e.element)
+			continue
+		}
+		switch e.wildcard.processContents {
+		case "skip":
+		case "lax":
+			v.lax([]*node{child})
+		default:
+			if decl, ok := v.schema.elements[child.name]; ok {
+				v.element(child, decl)
+			} else {
+				v.errorf(child, "No matching global element declaration available, but demanded by the strict wildcard.")
+			}
+		}
+	}
+	if !m.accepts() {
+		v.errorf(n, "Missing child element(s).%s", m.expected())
+	}
+}
+
+// lax validates elements with a global declaration and looks for them in the
+// children of the others.
+func (v *validation) lax(nodes []*node) {
+	for _, n := range nodes {
+		if decl, ok := v.schema.elements[n.name]; ok {
+			v.element(n, decl)
+		} else {
+			v.lax(n.children)
+		}
+	}
+}
+
+func (v *validation) attributes(n *node, t *complexType) {
+	for _, attr := range n.attrs {
+		switch {
+		case attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns"):
+			continue
+		case attr.Name.Space == xsiNS && (attr.Name.Local == "schemaLocation" || attr.Name.Local == "noNamespaceSchemaLocation"):
+			continue
+		}
+
+		var decl *attributeDecl
+		if t != nil && attr.Name.Space == "" {
+			decl = t.attribute(attr.Name.Local)
+		}
+		switch {
+		case decl != nil:
+			if msg := decl.typ.validate(attr.Value); msg != "" {
+				v.attrErrorf(n, attr.Name, "%s", msg)
+			}
+		case t != nil && t.anyAttribute:
+		default:
+			v.attrErrorf(n, attr.Name, "The attribute '%s' is not allowed.", formatName(attr.Name))
+		}
+	}
+
+	if t == nil {
+		return
+	}
+	for _, decl := range t.attributes {
+		if !decl.required {
+			continue
+		}
+		found := slices.ContainsFunc(n.attrs, func(attr xml.Attr) bool {
+			return attr.Name.Space == "" && attr.Name.Local == decl.name
+		})
+		if !found {
+			v.errorf(n, "The attribute '%s' is required but missing.", decl.name)
+		}
+	}
+}
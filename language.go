@@ -0,0 +1,30 @@
+package alpinebits
+
+import "github.com/HGV/alpinebits/version"
+
+// LanguageFunc returns the language of the error messages answered to r, such
+// as "de" or "it". An empty language keeps the messages in English.
+type LanguageFunc func(r Request) string
+
+// WithErrorLanguage translates the messages of the errors returned by
+// validations and handlers into the language returned by fn, for actions
+// implementing version.ErrorLocalizer.
+func WithErrorLanguage(fn LanguageFunc) RouterFunc {
+	return func(r *Router) {
+		r.errorLanguage = fn
+	}
+}
+
+func (router *Router) localizeError(action version.Action, req Request, err error) error {
+	if router.errorLanguage == nil {
+		return err
+	}
+	localizer, ok := action.(version.ErrorLocalizer)
+	if !ok {
+		return err
+	}
+	if lang := router.errorLanguage(req); lang != "" {
+		return localizer.LocalizeError(err, lang)
+	}
+	return err
+}
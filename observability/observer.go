@@ -0,0 +1,106 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Observer is notified about every request handled by the router or sent by a
+// client. Start is called before the request is processed and may return a
+// derived context, e.g. carrying a span. End is called with the same context
+// once the request has completed.
+type Observer interface {
+	Start(ctx context.Context, o Observation) context.Context
+	End(ctx context.Context, o Observation)
+}
+
+type Side string
+
+const (
+	SideServer Side = "server"
+	SideClient Side = "client"
+)
+
+type Outcome string
+
+const (
+	OutcomeSuccess          Outcome = "success"
+	OutcomeErrorResponse    Outcome = "error_response"
+	OutcomeReplayed         Outcome = "replayed"
+	OutcomeUnauthorized     Outcome = "unauthorized"
+	OutcomeRateLimited      Outcome = "rate_limited"
+	OutcomeRejected         Outcome = "rejected"
+	OutcomeInvalidXML       Outcome = "invalid_xml"
+	OutcomeValidationFailed Outcome = "validation_failed"
+	OutcomeFailed           Outcome = "failed"
+)
+
+// OutcomeFromStatus derives the outcome of a request from its HTTP status
+// code, for requests that did not record a more specific outcome.
+func OutcomeFromStatus(code int) Outcome {
+	switch {
+	case code >= 200 && code <= 299:
+		return OutcomeSuccess
+	case code == http.StatusUnauthorized:
+		return OutcomeUnauthorized
+	case code == http.StatusTooManyRequests:
+		return OutcomeRateLimited
+	case code >= 400 && code <= 499:
+		return OutcomeRejected
+	default:
+		return OutcomeFailed
+	}
+}
+
+// Observation describes a single request. Version and Action are empty if the
+// request was rejected before they were resolved. On the server, ClientID is
+// only set once the client has been authenticated.
+type Observation struct {
+	Side         Side
+	Version      string
+	Action       string
+	ClientID     string
+	Duration     time.Duration
+	RequestSize  int
+	ResponseSize int
+	StatusCode   int
+	Outcome      Outcome
+	Err          error
+}
+
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Attributes returns the observation as key value pairs named after the
+// OpenTelemetry semantic conventions, for use as span or metric attributes.
+func (o Observation) Attributes() []Attribute {
+	attrs := []Attribute{
+		{"alpinebits.side", string(o.Side)},
+		{"alpinebits.version", o.Version},
+		{"alpinebits.action", o.Action},
+		{"alpinebits.client_id", o.ClientID},
+		{"alpinebits.outcome", string(o.Outcome)},
+		{"http.request.body.size", o.RequestSize},
+		{"http.response.body.size", o.ResponseSize},
+	}
+	if o.StatusCode != 0 {
+		attrs = append(attrs, Attribute{"http.response.status_code", o.StatusCode})
+	}
+	if o.Err != nil {
+		attrs = append(attrs, Attribute{"error.type", fmt.Sprintf("%T", o.Err)})
+	}
+	return attrs
+}
+
+// Nop is an Observer that does nothing.
+var Nop Observer = nopObserver{}
+
+type nopObserver struct{}
+
+func (nopObserver) Start(ctx context.Context, _ Observation) context.Context { return ctx }
+
+func (nopObserver) End(context.Context, Observation) {}
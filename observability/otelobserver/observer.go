@@ -0,0 +1,139 @@
+// Package otelobserver reports the observations of routers and clients as
+// OpenTelemetry spans and metrics.
+package otelobserver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/HGV/alpinebits/observability"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/HGV/alpinebits/observability/otelobserver"
+
+type Observer struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+
+	tracer       trace.Tracer
+	duration     metric.Float64Histogram
+	requestSize  metric.Int64Histogram
+	responseSize metric.Int64Histogram
+}
+
+var _ observability.Observer = (*Observer)(nil)
+
+type ObserverFunc func(*Observer)
+
+// WithTracerProvider sets the provider of the tracer creating the spans. It
+// defaults to the global provider.
+func WithTracerProvider(p trace.TracerProvider) ObserverFunc {
+	return func(o *Observer) {
+		o.tracerProvider = p
+	}
+}
+
+// WithMeterProvider sets the provider of the meter recording the metrics. It
+// defaults to the global provider.
+func WithMeterProvider(p metric.MeterProvider) ObserverFunc {
+	return func(o *Observer) {
+		o.meterProvider = p
+	}
+}
+
+func New(opts ...ObserverFunc) (*Observer, error) {
+	o := &Observer{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	o.tracer = o.tracerProvider.Tracer(instrumentationName)
+	meter := o.meterProvider.Meter(instrumentationName)
+
+	var err error
+	o.duration, err = meter.Float64Histogram("alpinebits.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of AlpineBits requests."))
+	if err != nil {
+		return nil, err
+	}
+	o.requestSize, err = meter.Int64Histogram("alpinebits.request.body.size",
+		metric.WithUnit("By"),
+		metric.WithDescription("Size of AlpineBits request bodies."))
+	if err != nil {
+		return nil, err
+	}
+	o.responseSize, err = meter.Int64Histogram("alpinebits.response.body.size",
+		metric.WithUnit("By"),
+		metric.WithDescription("Size of AlpineBits response bodies."))
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Start starts a span, which End names after the action once it is known.
+func (o *Observer) Start(ctx context.Context, obs observability.Observation) context.Context {
+	kind := trace.SpanKindServer
+	if obs.Side == observability.SideClient {
+		kind = trace.SpanKindClient
+	}
+	ctx, _ = o.tracer.Start(ctx, spanName(obs), trace.WithSpanKind(kind))
+	return ctx
+}
+
+func (o *Observer) End(ctx context.Context, obs observability.Observation) {
+	attrs := attributes(obs)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetName(spanName(obs))
+	span.SetAttributes(attrs...)
+	if obs.Err != nil {
+		span.RecordError(obs.Err)
+	}
+	switch obs.Outcome {
+	case observability.OutcomeSuccess, observability.OutcomeReplayed:
+	default:
+		span.SetStatus(codes.Error, string(obs.Outcome))
+	}
+	span.End()
+
+	// the body sizes are recorded as values, not as attributes of the series
+	set := metric.WithAttributeSet(attribute.NewSet(slices.DeleteFunc(attrs, func(kv attribute.KeyValue) bool {
+		return kv.Key == "http.request.body.size" || kv.Key == "http.response.body.size"
+	})...))
+	o.duration.Record(ctx, obs.Duration.Seconds(), set)
+	o.requestSize.Record(ctx, int64(obs.RequestSize), set)
+	o.responseSize.Record(ctx, int64(obs.ResponseSize), set)
+}
+
+func spanName(obs observability.Observation) string {
+	if obs.Action == "" {
+		return "alpinebits"
+	}
+	return "alpinebits " + obs.Action
+}
+
+func attributes(obs observability.Observation) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 8)
+	for _, a := range obs.Attributes() {
+		switch v := a.Value.(type) {
+		case string:
+			attrs = append(attrs, attribute.String(a.Key, v))
+		case int:
+			attrs = append(attrs, attribute.Int(a.Key, v))
+		default:
+			attrs = append(attrs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return attrs
+}
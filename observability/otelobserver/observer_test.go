@@ -0,0 +1,81 @@
+package otelobserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HGV/alpinebits/observability"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestObserver(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	o, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	assert.NoError(t, err)
+
+	observe := func(obs observability.Observation) {
+		ctx := o.Start(context.Background(), observability.Observation{Side: obs.Side})
+		o.End(ctx, obs)
+	}
+	observe(observability.Observation{
+		Side:         observability.SideServer,
+		Version:      "2020-10",
+		Action:       "OTA_HotelInvCountNotif:FreeRooms",
+		ClientID:     "client",
+		Duration:     500 * time.Millisecond,
+		RequestSize:  100,
+		ResponseSize: 10,
+		StatusCode:   http.StatusOK,
+		Outcome:      observability.OutcomeSuccess,
+	})
+	observe(observability.Observation{
+		Side:       observability.SideClient,
+		Duration:   time.Second,
+		StatusCode: http.StatusBadGateway,
+		Outcome:    observability.OutcomeFailed,
+		Err:        errors.New("bad gateway"),
+	})
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 2)
+	assert.Equal(t, "alpinebits OTA_HotelInvCountNotif:FreeRooms", ended[0].Name())
+	assert.Equal(t, trace.SpanKindServer, ended[0].SpanKind())
+	assert.Equal(t, codes.Unset, ended[0].Status().Code)
+	assert.Contains(t, ended[0].Attributes(), attribute.String("alpinebits.client_id", "client"))
+	assert.Contains(t, ended[0].Attributes(), attribute.Int("http.request.body.size", 100))
+	assert.Equal(t, "alpinebits", ended[1].Name())
+	assert.Equal(t, trace.SpanKindClient, ended[1].SpanKind())
+	assert.Equal(t, codes.Error, ended[1].Status().Code)
+	assert.Len(t, ended[1].Events(), 1)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+	duration := metrics["alpinebits.request.duration"].(metricdata.Histogram[float64])
+	assert.Len(t, duration.DataPoints, 2)
+	for _, dp := range duration.DataPoints {
+		_, ok := dp.Attributes.Value("http.request.body.size")
+		assert.False(t, ok)
+	}
+	requestSize := metrics["alpinebits.request.body.size"].(metricdata.Histogram[int64])
+	assert.Equal(t, int64(100), requestSize.DataPoints[0].Sum+requestSize.DataPoints[1].Sum)
+}
@@ -0,0 +1,238 @@
+package observability
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the request duration
+// histogram.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// DefaultMaxClientIDs is the number of client IDs a Registry tracks as
+// separate series by default.
+const DefaultMaxClientIDs = 1000
+
+// OtherClientID is the client ID label of the requests of all clients beyond
+// the maximum tracked by a Registry.
+const OtherClientID = "other"
+
+// Registry is an in-process Observer that aggregates observations into
+// counters and histograms and exposes them in the Prometheus text format.
+type Registry struct {
+	mu           sync.Mutex
+	buckets      []float64
+	maxClientIDs int
+	clientIDs    map[string]struct{}
+	series       map[seriesKey]*seriesValue
+}
+
+var _ Observer = (*Registry)(nil)
+var _ http.Handler = (*Registry)(nil)
+
+type RegistryFunc func(*Registry)
+
+func WithBuckets(buckets ...float64) RegistryFunc {
+	return func(r *Registry) {
+		r.buckets = slices.Sorted(slices.Values(buckets))
+	}
+}
+
+// WithMaxClientIDs limits the client IDs tracked as separate series. Client
+// IDs come from a request header, so without a limit the number of series
+// would be up to the clients.
+func WithMaxClientIDs(n int) RegistryFunc {
+	return func(r *Registry) {
+		r.maxClientIDs = n
+	}
+}
+
+type seriesKey struct {
+	side     Side
+	version  string
+	action   string
+	clientID string
+	outcome  Outcome
+}
+
+type seriesValue struct {
+	count         uint64
+	buckets       []uint64
+	durationSum   float64
+	requestBytes  uint64
+	responseBytes uint64
+}
+
+func NewRegistry(opts ...RegistryFunc) *Registry {
+	r := &Registry{
+		buckets:      DefaultBuckets,
+		maxClientIDs: DefaultMaxClientIDs,
+		clientIDs:    make(map[string]struct{}),
+		series:       make(map[seriesKey]*seriesValue),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Registry) Start(ctx context.Context, _ Observation) context.Context {
+	return ctx
+}
+
+func (r *Registry) End(_ context.Context, o Observation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := seriesKey{
+		side:     o.Side,
+		version:  o.Version,
+		action:   o.Action,
+		clientID: r.clientIDLabel(o.ClientID),
+		outcome:  o.Outcome,
+	}
+
+	v, ok := r.series[key]
+	if !ok {
+		v = &seriesValue{buckets: make([]uint64, len(r.buckets))}
+		r.series[key] = v
+	}
+
+	seconds := o.Duration.Seconds()
+	v.count++
+	v.durationSum += seconds
+	for i, le := range r.buckets {
+		if seconds <= le {
+			v.buckets[i]++
+		}
+	}
+	v.requestBytes += uint64(max(o.RequestSize, 0))
+	v.responseBytes += uint64(max(o.ResponseSize, 0))
+}
+
+func (r *Registry) clientIDLabel(clientID string) string {
+	if _, ok := r.clientIDs[clientID]; ok || clientID == "" {
+		return clientID
+	}
+	if len(r.clientIDs) >= r.maxClientIDs {
+		return OtherClientID
+	}
+	r.clientIDs[clientID] = struct{}{}
+	return clientID
+}
+
+// Count returns the number of observed requests matching the given labels.
+// Empty version, action or client ID match any value.
+func (r *Registry) Count(side Side, version, action, clientID string, outcome Outcome) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count uint64
+	for k, v := range r.series {
+		if k.side == side && k.outcome == outcome &&
+			(version == "" || k.version == version) &&
+			(action == "" || k.action == action) &&
+			(clientID == "" || k.clientID == clientID) {
+			count += v.count
+		}
+	}
+	return count
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := slices.SortedFunc(maps.Keys(r.series), func(a, b seriesKey) int {
+		return cmp.Or(
+			cmp.Compare(a.side, b.side),
+			cmp.Compare(a.version, b.version),
+			cmp.Compare(a.action, b.action),
+			cmp.Compare(a.clientID, b.clientID),
+			cmp.Compare(a.outcome, b.outcome),
+		)
+	})
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	fmt.Fprintln(cw, "# HELP alpinebits_requests_total Number of AlpineBits requests.")
+	fmt.Fprintln(cw, "# TYPE alpinebits_requests_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(cw, "alpinebits_requests_total{%s} %d\n", k.labels(), r.series[k].count)
+	}
+
+	fmt.Fprintln(cw, "# HELP alpinebits_request_duration_seconds Duration of AlpineBits requests.")
+	fmt.Fprintln(cw, "# TYPE alpinebits_request_duration_seconds histogram")
+	for _, k := range keys {
+		v, labels := r.series[k], k.labels()
+		for i, le := range r.buckets {
+			fmt.Fprintf(cw, "alpinebits_request_duration_seconds_bucket{%s,le=\"%g\"} %d\n", labels, le, v.buckets[i])
+		}
+		fmt.Fprintf(cw, "alpinebits_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, v.count)
+		fmt.Fprintf(cw, "alpinebits_request_duration_seconds_sum{%s} %g\n", labels, v.durationSum)
+		fmt.Fprintf(cw, "alpinebits_request_duration_seconds_count{%s} %d\n", labels, v.count)
+	}
+
+	fmt.Fprintln(cw, "# HELP alpinebits_request_size_bytes_total Size of AlpineBits request payloads.")
+	fmt.Fprintln(cw, "# TYPE alpinebits_request_size_bytes_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(cw, "alpinebits_request_size_bytes_total{%s} %d\n", k.labels(), r.series[k].requestBytes)
+	}
+
+	fmt.Fprintln(cw, "# HELP alpinebits_response_size_bytes_total Size of AlpineBits response payloads.")
+	fmt.Fprintln(cw, "# TYPE alpinebits_response_size_bytes_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(cw, "alpinebits_response_size_bytes_total{%s} %d\n", k.labels(), r.series[k].responseBytes)
+	}
+
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// ServeHTTP serves the metrics, e.g. as a Prometheus scrape target.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+func (k seriesKey) labels() string {
+	return fmt.Sprintf(`side="%s",version="%s",action="%s",client_id="%s",outcome="%s"`,
+		escapeLabel(string(k.side)),
+		escapeLabel(k.version),
+		escapeLabel(k.action),
+		escapeLabel(k.clientID),
+		escapeLabel(string(k.outcome)))
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
@@ -0,0 +1,77 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(WithBuckets(1, 0.1))
+
+	observe := func(d time.Duration, outcome Outcome) {
+		o := Observation{
+			Side:         SideServer,
+			Version:      "2020-10",
+			Action:       "OTA_HotelInvCountNotif:FreeRooms",
+			ClientID:     `client "a"`,
+			Duration:     d,
+			RequestSize:  100,
+			ResponseSize: 10,
+			StatusCode:   http.StatusOK,
+			Outcome:      outcome,
+		}
+		ctx := r.Start(context.Background(), o)
+		r.End(ctx, o)
+	}
+	observe(50*time.Millisecond, OutcomeSuccess)
+	observe(500*time.Millisecond, OutcomeSuccess)
+	observe(2*time.Second, OutcomeValidationFailed)
+
+	assert.Equal(t, uint64(2), r.Count(SideServer, "2020-10", "", "", OutcomeSuccess))
+	assert.Equal(t, uint64(1), r.Count(SideServer, "", "", `client "a"`, OutcomeValidationFailed))
+	assert.Equal(t, uint64(0), r.Count(SideClient, "", "", "", OutcomeSuccess))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	metrics := w.Body.String()
+
+	labels := `side="server",version="2020-10",action="OTA_HotelInvCountNotif:FreeRooms",client_id="client \"a\"",outcome="success"`
+	for _, line := range []string{
+		`alpinebits_requests_total{` + labels + `} 2`,
+		`alpinebits_request_duration_seconds_bucket{` + labels + `,le="0.1"} 1`,
+		`alpinebits_request_duration_seconds_bucket{` + labels + `,le="1"} 2`,
+		`alpinebits_request_duration_seconds_bucket{` + labels + `,le="+Inf"} 2`,
+		`alpinebits_request_duration_seconds_sum{` + labels + `} 0.55`,
+		`alpinebits_request_size_bytes_total{` + labels + `} 200`,
+		`alpinebits_response_size_bytes_total{` + labels + `} 20`,
+	} {
+		assert.Contains(t, metrics, line+"\n")
+	}
+	assert.Equal(t, 4, strings.Count(metrics, "# TYPE"))
+}
+
+func TestRegistryMaxClientIDs(t *testing.T) {
+	r := NewRegistry(WithMaxClientIDs(2))
+	for _, clientID := range []string{"a", "b", "c", "d", "a"} {
+		r.End(context.Background(), Observation{Side: SideServer, ClientID: clientID, Outcome: OutcomeSuccess})
+	}
+
+	assert.Equal(t, uint64(2), r.Count(SideServer, "", "", "a", OutcomeSuccess))
+	assert.Equal(t, uint64(1), r.Count(SideServer, "", "", "b", OutcomeSuccess))
+	assert.Equal(t, uint64(0), r.Count(SideServer, "", "", "c", OutcomeSuccess))
+	assert.Equal(t, uint64(2), r.Count(SideServer, "", "", OtherClientID, OutcomeSuccess))
+}
+
+func TestOutcomeFromStatus(t *testing.T) {
+	assert.Equal(t, OutcomeSuccess, OutcomeFromStatus(http.StatusOK))
+	assert.Equal(t, OutcomeUnauthorized, OutcomeFromStatus(http.StatusUnauthorized))
+	assert.Equal(t, OutcomeRateLimited, OutcomeFromStatus(http.StatusTooManyRequests))
+	assert.Equal(t, OutcomeRejected, OutcomeFromStatus(http.StatusBadRequest))
+	assert.Equal(t, OutcomeFailed, OutcomeFromStatus(http.StatusInternalServerError))
+}
@@ -0,0 +1,10 @@
+package alpinebits
+
+import "github.com/HGV/alpinebits/observability"
+
+// WithObserver reports every request handled by the router to o.
+func WithObserver(o observability.Observer) RouterFunc {
+	return func(r *Router) {
+		r.observer = o
+	}
+}
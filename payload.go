@@ -0,0 +1,187 @@
+package alpinebits
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	DefaultMaxRequestSize = 64 << 20
+	DefaultMemoryLimit    = 1 << 20
+
+	maxActionSize     = 1 << 10
+	multipartOverhead = 64 << 10
+)
+
+var errPayloadTooLarge = errors.New("payload too large")
+
+// WithMaxRequestSize limits the size of request bodies after decompression.
+// Larger requests are answered with 413 Request Entity Too Large.
+func WithMaxRequestSize(n int64) RouterFunc {
+	return func(r *Router) {
+		r.maxRequestSize = n
+	}
+}
+
+// WithMemoryLimit sets the size above which request payloads are buffered in
+// a temporary file while they are received.
+func WithMemoryLimit(n int64) RouterFunc {
+	return func(r *Router) {
+		r.memoryLimit = n
+	}
+}
+
+// WithMaxPayloadSize limits the size of the request XML of the route instead
+// of the router's maximum request size, e.g. to accept large CompleteSet pushes
+// for a single action only.
+func WithMaxPayloadSize(n int64) RouteFunc {
+	return func(r *Route) {
+		r.maxPayloadSize = n
+	}
+}
+
+// maxBodySize returns the maximum request size, raised to fit the largest
+// payload accepted by any action.
+func (routes Routes) maxBodySize(maxRequestSize int64) int64 {
+	n := maxRequestSize
+	for _, route := range routes.actionRoutes {
+		if route.maxPayloadSize > 0 {
+			n = max(n, route.maxPayloadSize+multipartOverhead)
+		}
+	}
+	return n
+}
+
+type multipartForm struct {
+	action  string
+	request *spillBuffer
+}
+
+// readMultipartForm reads the action and request parts of r without holding
+// more than memoryLimit bytes of the request in memory. maxPayloadSize returns
+// the limit for the request part of an action, which may be empty if the
+// request part precedes the action part.
+func readMultipartForm(r *http.Request, memoryLimit int64, maxPayloadSize func(action string) int64) (*multipartForm, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	form := &multipartForm{request: &spillBuffer{limit: memoryLimit}}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			form.Close()
+			return nil, err
+		}
+
+		err = form.readPart(part, maxPayloadSize)
+		part.Close()
+		if err != nil {
+			form.Close()
+			return nil, err
+		}
+	}
+
+	if form.request.Size() > maxPayloadSize(form.action) {
+		form.Close()
+		return nil, errPayloadTooLarge
+	}
+	return form, nil
+}
+
+func (f *multipartForm) readPart(part *multipart.Part, maxPayloadSize func(action string) int64) error {
+	switch part.FormName() {
+	case "action":
+		b, err := io.ReadAll(io.LimitReader(part, maxActionSize))
+		if err != nil {
+			return err
+		}
+		f.action = string(b)
+	case "request":
+		limit := maxPayloadSize(f.action)
+		n, err := io.Copy(f.request, io.LimitReader(part, limit+1))
+		if err != nil {
+			return err
+		}
+		if n > limit {
+			return errPayloadTooLarge
+		}
+	}
+	return nil
+}
+
+func (f *multipartForm) Close() error {
+	return f.request.Close()
+}
+
+// spillBuffer keeps up to limit bytes in memory and moves its content to a
+// temporary file once it grows larger.
+type spillBuffer struct {
+	limit int64
+	size  int64
+	mem   bytes.Buffer
+	file  *os.File
+}
+
+func (b *spillBuffer) Write(p []byte) (int, error) {
+	if b.file == nil && b.size+int64(len(p)) > b.limit {
+		file, err := os.CreateTemp("", "alpinebits-*.xml")
+		if err != nil {
+			return 0, err
+		}
+		b.file = file
+		if _, err := b.mem.WriteTo(file); err != nil {
+			return 0, err
+		}
+	}
+
+	var n int
+	var err error
+	if b.file != nil {
+		n, err = b.file.Write(p)
+	} else {
+		n, err = b.mem.Write(p)
+	}
+	b.size += int64(n)
+	return n, err
+}
+
+func (b *spillBuffer) Size() int64 {
+	return b.size
+}
+
+// Reader returns a reader over the content from its start. Readers are
+// independent of each other and of later writes.
+func (b *spillBuffer) Reader() io.Reader {
+	if b.file == nil {
+		return bytes.NewReader(b.mem.Bytes())
+	}
+	return io.NewSectionReader(b.file, 0, b.size)
+}
+
+// Prefix returns up to n bytes from the start of the content.
+func (b *spillBuffer) Prefix(n int64) (string, error) {
+	var sb strings.Builder
+	sb.Grow(int(min(n, b.size)))
+	if _, err := io.Copy(&sb, io.LimitReader(b.Reader(), n)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func (b *spillBuffer) Close() error {
+	if b.file == nil {
+		return nil
+	}
+	b.file.Close()
+	return os.Remove(b.file.Name())
+}
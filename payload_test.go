@@ -0,0 +1,77 @@
+package alpinebits
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpillBuffer(t *testing.T) {
+	b := &spillBuffer{limit: 4}
+	_, err := b.Write([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Nil(t, b.file)
+
+	_, err = b.Write([]byte("defg"))
+	assert.NoError(t, err)
+	assert.NotNil(t, b.file)
+	assert.Equal(t, int64(7), b.Size())
+
+	assert.Equal(t, "abcdefg", string(mustReadAll(t, b.Reader())))
+	assert.Equal(t, "abcdefg", string(mustReadAll(t, b.Reader())))
+
+	prefix, err := b.Prefix(5)
+	assert.NoError(t, err)
+	assert.Equal(t, "abcde", prefix)
+
+	name := b.file.Name()
+	assert.NoError(t, b.Close())
+	_, err = os.Stat(name)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadMultipartForm(t *testing.T) {
+	newRequest := func(fields ...string) *http.Request {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		for i := 0; i < len(fields); i += 2 {
+			assert.NoError(t, w.WriteField(fields[i], fields[i+1]))
+		}
+		assert.NoError(t, w.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/", &body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req
+	}
+	maxPayloadSize := func(action string) int64 {
+		if action == "small" {
+			return 4
+		}
+		return 8
+	}
+
+	form, err := readMultipartForm(newRequest("action", "small", "request", "1234"), 2, maxPayloadSize)
+	assert.NoError(t, err)
+	assert.Equal(t, "small", form.action)
+	assert.Equal(t, "1234", string(mustReadAll(t, form.request.Reader())))
+	assert.NoError(t, form.Close())
+
+	_, err = readMultipartForm(newRequest("action", "small", "request", "12345"), 2, maxPayloadSize)
+	assert.ErrorIs(t, err, errPayloadTooLarge)
+
+	// The action part may follow the request part
+	_, err = readMultipartForm(newRequest("request", "12345", "action", "small"), 2, maxPayloadSize)
+	assert.ErrorIs(t, err, errPayloadTooLarge)
+
+	_, err = readMultipartForm(newRequest("action", "large", "request", strings.Repeat("x", 9)), 2, maxPayloadSize)
+	assert.ErrorIs(t, err, errPayloadTooLarge)
+
+	_, err = readMultipartForm(httptest.NewRequest(http.MethodPost, "/", nil), 2, maxPayloadSize)
+	assert.ErrorIs(t, err, http.ErrNotMultipart)
+}
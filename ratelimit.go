@@ -0,0 +1,127 @@
+package alpinebits
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit allows Burst requests at once and refills at Rate requests per
+// second. Without a Rate, a key gets Burst requests again after an idle hour.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// WithClientRateLimit limits the requests of each client ID across all
+// versions and actions.
+func WithClientRateLimit(l RateLimit) RouterFunc {
+	return func(r *Router) {
+		r.clientLimiter = newLimiter(l)
+	}
+}
+
+// WithHotelRateLimit limits the requests for each hotel code across all
+// clients. Requests without hotel code are not limited.
+func WithHotelRateLimit(l RateLimit) RouterFunc {
+	return func(r *Router) {
+		r.hotelLimiter = newLimiter(l)
+	}
+}
+
+// WithRateLimit limits the requests of each client ID for the route.
+func WithRateLimit(l RateLimit) RouteFunc {
+	return func(r *Route) {
+		r.limiter = newLimiter(l)
+	}
+}
+
+// WithHotelSerialization runs the handlers of routes with this option one at a
+// time for the same hotel code, in the order the requests arrived, so that
+// deltas are applied in order.
+func WithHotelSerialization() RouteFunc {
+	return func(r *Route) {
+		r.serializeHotel = true
+	}
+}
+
+func serialized(locks *keyedMutex, hotelCode string, next HandlerFunc) HandlerFunc {
+	return func(r Request) (any, error) {
+		unlock, err := locks.Lock(r.Context, hotelCode)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+		return next(r)
+	}
+}
+
+// limiter keeps a token bucket per key.
+type limiter struct {
+	limit RateLimit
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(l RateLimit) *limiter {
+	return &limiter{
+		limit:   l,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// allow takes a token from the bucket of key. If the bucket is empty, it
+// returns false and the time until the next token is available.
+func (l *limiter) allow(key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.prune(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(float64(l.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*l.limit.Rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		if l.limit.Rate <= 0 {
+			return idleBucketTTL, false
+		}
+		return time.Duration((1 - b.tokens) / l.limit.Rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// idleBucketTTL is the time after which buckets that never refill are
+// removed.
+const idleBucketTTL = time.Hour
+
+// prune removes the buckets that have been refilled completely, as they are
+// equivalent to new ones, and those that never refill once idle for
+// idleBucketTTL.
+func (l *limiter) prune(now time.Time) {
+	idle := idleBucketTTL
+	if l.limit.Rate > 0 {
+		idle = time.Duration(float64(l.limit.Burst) / l.limit.Rate * float64(time.Second))
+	}
+	if now.Sub(l.lastPrune) < max(idle, time.Minute) {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= idle {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPrune = now
+}
@@ -0,0 +1,89 @@
+package alpinebits
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(RateLimit{Rate: 50, Burst: 2})
+
+	for range 2 {
+		_, ok := l.allow("a")
+		assert.True(t, ok)
+	}
+	retryAfter, ok := l.allow("a")
+	assert.False(t, ok)
+	assert.Greater(t, retryAfter, time.Duration(0))
+	assert.LessOrEqual(t, retryAfter, 20*time.Millisecond)
+
+	_, ok = l.allow("b")
+	assert.True(t, ok)
+
+	time.Sleep(retryAfter)
+	_, ok = l.allow("a")
+	assert.True(t, ok)
+}
+
+func TestKeyedMutex(t *testing.T) {
+	m := newKeyedMutex()
+	ctx := context.Background()
+
+	unlock, err := m.Lock(ctx, "a")
+	assert.NoError(t, err)
+
+	order := make(chan int, 3)
+	done := make(chan struct{})
+	for i := range 3 {
+		go func() {
+			unlock, err := m.Lock(ctx, "a")
+			assert.NoError(t, err)
+			order <- i
+			unlock()
+			if i == 2 {
+				close(done)
+			}
+		}()
+		// Wait until the goroutine is queued
+		assert.Eventually(t, func() bool {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			return len(m.locks["a"].waiters) == i+1
+		}, time.Second, time.Millisecond)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = m.Lock(canceled, "a")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	unlock()
+	<-done
+	close(order)
+	var got []int
+	for i := range order {
+		got = append(got, i)
+	}
+	assert.Equal(t, []int{0, 1, 2}, got)
+	assert.Empty(t, m.locks)
+}
+
+func TestLimiterPrune(t *testing.T) {
+	for _, l := range []RateLimit{{Rate: 50, Burst: 2}, {Burst: 2}} {
+		l := newLimiter(l)
+		now := time.Now()
+		for _, key := range []string{"a", "b"} {
+			_, ok := l.allow(key)
+			assert.True(t, ok)
+		}
+		l.buckets["a"].last = now.Add(-2 * idleBucketTTL)
+		l.lastPrune = now.Add(-2 * idleBucketTTL)
+
+		l.prune(now)
+		assert.NotContains(t, l.buckets, "a")
+		assert.Contains(t, l.buckets, "b")
+	}
+}
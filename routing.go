@@ -1,20 +1,30 @@
 package alpinebits
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"maps"
+	"math"
 	"net/http"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/HGV/alpinebits/archive"
+	"github.com/HGV/alpinebits/internal/compression"
+	"github.com/HGV/alpinebits/observability"
 	"github.com/HGV/alpinebits/version"
+	"github.com/juliangruber/go-intersect/v2"
 )
 
 const (
-	HeaderServerAcceptEncoding  = "X-AlpineBits-Server-Accept-Encoding"
+	HeaderServerAcceptEncoding  = compression.HeaderServerAcceptEncoding
 	HeaderClientID              = "X-AlpineBits-ClientID"
 	HeaderClientProtocolVersion = "X-AlpineBits-ClientProtocolVersion"
 )
@@ -22,18 +32,56 @@ const (
 type Router struct {
 	http.Handler
 
-	versionRoutes map[string]Routes
+	versionRoutes  map[string]Routes
+	authenticator  Authenticator
+	middlewares    []MiddlewareFunc
+	observer       observability.Observer
+	archive        archive.Archive
+	archiver       *archive.Queue
+	handshakeStore HandshakeStore
+	maxRequestSize int64
+	memoryLimit    int64
+	errorLanguage  LanguageFunc
+
+	archiveQueueSize      int
+	archiveMaxRequestSize int64
+
+	idempotencyStore IdempotencyStore
+	idempotencyLocks *keyedMutex
+
+	clientLimiter *limiter
+	hotelLimiter  *limiter
+	hotelLocks    *keyedMutex
 }
 
+type RouterFunc func(*Router)
+
 type Routes struct {
 	version      version.Version[version.Action]
 	actionRoutes map[string]Route
+	middlewares  []MiddlewareFunc
 }
 
-func NewRouter() *Router {
-	return &Router{
-		versionRoutes: make(map[string]Routes),
+func NewRouter(opts ...RouterFunc) *Router {
+	router := &Router{
+		versionRoutes:  make(map[string]Routes),
+		observer:       observability.Nop,
+		maxRequestSize: DefaultMaxRequestSize,
+		memoryLimit:    DefaultMemoryLimit,
+
+		archiveQueueSize: DefaultArchiveQueueSize,
+
+		idempotencyStore: NewMemoryIdempotencyStore(DefaultIdempotencyTTL),
+		idempotencyLocks: newKeyedMutex(),
+		hotelLocks:       newKeyedMutex(),
+	}
+	for _, opt := range opts {
+		opt(router)
 	}
+	if router.archive != nil {
+		router.archiver = archive.NewQueue(router.archive, router.archiveQueueSize)
+	}
+	return router
 }
 
 func (r *Router) Version(version version.Version[version.Action], fn func(s *Subrouter)) *Router {
@@ -42,12 +90,21 @@ func (r *Router) Version(version version.Version[version.Action], fn func(s *Sub
 	r.versionRoutes[version.String()] = Routes{
 		version:      version,
 		actionRoutes: subrouter.actionRoutes,
+		middlewares:  subrouter.middlewares,
 	}
 	return r
 }
 
+// Use appends middlewares that wrap the handlers of all versions. They run
+// before the middlewares of a version and of a single action.
+func (r *Router) Use(mws ...MiddlewareFunc) *Router {
+	r.middlewares = append(r.middlewares, mws...)
+	return r
+}
+
 type Subrouter struct {
 	actionRoutes map[string]Route
+	middlewares  []MiddlewareFunc
 }
 
 func newSubrouter() *Subrouter {
@@ -57,6 +114,10 @@ func newSubrouter() *Subrouter {
 }
 
 func (s *Subrouter) Action(action version.Action, handlerFn HandlerFunc, opts ...RouteFunc) {
+	if handlerFn == nil && isPingAction(action) {
+		handlerFn = PingHandler
+	}
+
 	route := Route{
 		handler: handlerFn,
 		action:  action,
@@ -67,13 +128,20 @@ func (s *Subrouter) Action(action version.Action, handlerFn HandlerFunc, opts ..
 	s.actionRoutes[action.String()] = route
 }
 
+// Use appends middlewares that wrap the handlers of all actions of the version.
+func (s *Subrouter) Use(mws ...MiddlewareFunc) {
+	s.middlewares = append(s.middlewares, mws...)
+}
+
 type Request struct {
 	Context      context.Context
 	ClientID     string
+	Principal    Principal
 	Data         any
 	Capabilities []string
 
 	handshakeDataFromRouter func() HandshakeData
+	handshakeStore          HandshakeStore
 }
 
 func (r Request) HandshakeData() HandshakeData {
@@ -83,15 +151,41 @@ func (r Request) HandshakeData() HandshakeData {
 	return r.handshakeDataFromRouter()
 }
 
+// HandlerFunc handles a decoded request. Errors the version can render, such
+// as *common.Error or *common.Warning, are answered with the action's response
+// message; any other error results in 500 Internal Server Error.
 type HandlerFunc func(r Request) (any, error)
 
+// MiddlewareFunc wraps a handler. Middlewares run after the request has been
+// decoded and validated, so they can inspect Request.Data, and may answer
+// without calling next.
+type MiddlewareFunc func(next HandlerFunc) HandlerFunc
+
+func chain(h HandlerFunc, mws ...[]MiddlewareFunc) HandlerFunc {
+	all := slices.Concat(mws...)
+	for i := len(all) - 1; i >= 0; i-- {
+		h = all[i](h)
+	}
+	return h
+}
+
 type Route struct {
 	action               version.Action
 	handler              HandlerFunc
 	capabilities         []string
 	excludeFromHandshake func(clientID string) bool
+	validate             ValidateFunc
+	middlewares          []MiddlewareFunc
+	maxPayloadSize       int64
+	idempotencyKey       IdempotencyKeyFunc
+	limiter              *limiter
+	serializeHotel       bool
 }
 
+// ValidateFunc validates the decoded request data of an action against the
+// capabilities negotiated for the route.
+type ValidateFunc func(action version.Action, data any, capabilities []string) error
+
 type RouteFunc func(*Route)
 
 func WithCapabilities[C ~string](caps ...C) RouteFunc {
@@ -108,7 +202,98 @@ func WithExcludeFromHandshake(fn func(clientID string) bool) RouteFunc {
 	}
 }
 
+// WithValidation runs fn before the handler is called. If fn fails, the
+// handler is skipped and the client receives the action's response message
+// carrying the error, provided the action implements version.ErrorResponder
+// and the error is one the version can render.
+func WithValidation(fn ValidateFunc) RouteFunc {
+	return func(r *Route) {
+		r.validate = fn
+	}
+}
+
+// WithMiddleware appends middlewares that wrap the handler of the route. They
+// run after the middlewares of the router and of the version.
+func WithMiddleware(mws ...MiddlewareFunc) RouteFunc {
+	return func(r *Route) {
+		r.middlewares = append(r.middlewares, mws...)
+	}
+}
+
 func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ex := exchange{
+		Observation: observability.Observation{
+			Side: observability.SideServer,
+		},
+	}
+	ctx := router.observer.Start(r.Context(), ex.Observation)
+
+	start := time.Now()
+	rw := &responseRecorder{ResponseWriter: w}
+	if router.archive != nil {
+		rw.body = new(bytes.Buffer)
+	}
+	router.serveHTTP(rw, r.WithContext(ctx), &ex)
+	end := time.Now()
+
+	ex.Duration = end.Sub(start)
+	ex.StatusCode = rw.status()
+	ex.ResponseSize = rw.size
+	if ex.Outcome == "" {
+		ex.Outcome = observability.OutcomeFromStatus(ex.StatusCode)
+	}
+	router.observer.End(ctx, ex.Observation)
+
+	if router.archive != nil && ex.request != "" {
+		router.archiveExchange(ctx, ex, start, end, rw.body.String())
+	}
+}
+
+// exchange collects what is known about a request while it is served.
+type exchange struct {
+	observability.Observation
+
+	request   string
+	hotelCode string
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+
+	statusCode int
+	size       int
+	body       *bytes.Buffer
+}
+
+func (rw *responseRecorder) WriteHeader(statusCode int) {
+	if rw.statusCode == 0 {
+		rw.statusCode = statusCode
+	}
+	rw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rw *responseRecorder) Write(b []byte) (int, error) {
+	if rw.statusCode == 0 {
+		rw.statusCode = http.StatusOK
+	}
+	n, err := rw.ResponseWriter.Write(b)
+	rw.size += n
+	if rw.body != nil {
+		rw.body.Write(b[:n])
+	}
+	return n, err
+}
+
+func (rw *responseRecorder) status() int {
+	if rw.statusCode == 0 {
+		return http.StatusOK
+	}
+	return rw.statusCode
+}
+
+func (router *Router) serveHTTP(w http.ResponseWriter, r *http.Request, ex *exchange) {
+	w.Header().Set(HeaderServerAcceptEncoding, compression.EncodingGzip)
+
 	if r.Method != http.MethodPost {
 		preconditionErrorf(w, "expected http method POST, got %s", r.Method)
 		return
@@ -120,6 +305,25 @@ func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	principal, err := router.authenticate(r, clientID)
+	if err != nil {
+		if errors.Is(err, ErrUnauthorized) {
+			unauthorizedError(w)
+		} else {
+			internalServerError(w, r, err)
+		}
+		return
+	}
+	ex.ClientID = clientID
+
+	if router.clientLimiter != nil {
+		if retryAfter, ok := router.clientLimiter.allow(clientID); !ok {
+			ex.Outcome = observability.OutcomeRateLimited
+			tooManyRequestsError(w, retryAfter)
+			return
+		}
+	}
+
 	requestedVersion := r.Header.Get(HeaderClientProtocolVersion)
 	if requestedVersion == "" {
 		preconditionErrorf(w, "missing http header: %s", HeaderClientProtocolVersion)
@@ -139,30 +343,72 @@ func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			strings.Join(supportedVersions, ", "))
 		return
 	}
+	ex.Version = requestedVersion
 
 	rctx, hasRouteCtx := RouteContextFrom(r.Context())
 
 	if hasRouteCtx {
 		// Check if the requested version is disabled by a handshake override
 		if _, ok := rctx.HandshakeDataOverride[requestedVersion]; !ok {
-			preconditionErrorf(w,
-				"your current version of '%s' was not included in the handshake agreement. Please retry the handshake to ensure compatibility.",
-				requestedVersion)
+			versionNotAgreedError(w, requestedVersion)
 			return
 		}
 	}
 
-	if err := r.ParseMultipartForm(1 << 20); err != nil {
-		preconditionError(w, err.Error())
+	if compression.IsGzipEncoded(r.Header) {
+		body, err := compression.Gunzip(r.Body)
+		if err != nil {
+			preconditionError(w, err.Error())
+			return
+		}
+		defer body.Close()
+		r.Body = body
+		r.Header.Del(compression.HeaderContentEncoding)
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, routes.maxBodySize(router.maxRequestSize))
+
+	form, err := readMultipartForm(r, router.memoryLimit, func(action string) int64 {
+		if route, ok := routes.actionRoutes[action]; ok && route.maxPayloadSize > 0 {
+			return route.maxPayloadSize
+		}
+		return router.maxRequestSize
+	})
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.Is(err, errPayloadTooLarge) || errors.As(err, &maxBytesErr) {
+			requestTooLargeError(w)
+		} else {
+			preconditionError(w, err.Error())
+		}
 		return
 	}
+	defer form.Close()
 
-	requestedAction := r.Form.Get("action")
+	requestedAction := form.action
 	route, ok := routes.actionRoutes[requestedAction]
 	if !ok {
 		preconditionError(w, "unknown or missing action")
 		return
 	}
+	ex.Action = requestedAction
+
+	// OTA_Ping is exempt from stored agreements, so clients can renegotiate
+	if !hasRouteCtx && router.handshakeStore != nil && !isPingAction(route.action) {
+		agreement, ok, err := router.handshakeStore.Load(r.Context(), clientID)
+		if err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+		if ok {
+			if _, ok := agreement[requestedVersion]; !ok {
+				versionNotAgreedError(w, requestedVersion)
+				return
+			}
+			rctx, hasRouteCtx = RouteContext{HandshakeDataOverride: agreement}, true
+			r = r.WithContext(WithRouteContext(r.Context(), rctx))
+		}
+	}
 
 	if hasRouteCtx {
 		// Check if the action is disabled by a handshake override
@@ -172,8 +418,19 @@ func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	payload := r.Form.Get("request")
-	if err := routes.version.ValidateXML(payload); err != nil {
+	ex.RequestSize = int(form.request.Size())
+	if router.archive != nil {
+		n := form.request.Size()
+		if router.archiveMaxRequestSize > 0 {
+			n = min(n, router.archiveMaxRequestSize)
+		}
+		if ex.request, err = form.request.Prefix(n); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
+	}
+	if err := version.ValidateXMLReader(routes.version, form.request.Reader()); err != nil {
+		ex.Outcome = observability.OutcomeInvalidXML
 		preconditionErrorf(w,
 			"XML validation error for action %s\n\n%s",
 			requestedAction,
@@ -181,42 +438,151 @@ func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := route.action.Unmarshal([]byte(payload))
+	data, err := decode(route.action, form.request.Reader())
 	if err != nil {
 		internalServerError(w, r, err)
 		return
 	}
+	if p, ok := data.(version.HotelCodeProvider); ok {
+		ex.hotelCode = p.HotelCode()
+	}
+
+	capabilities := route.capabilities
+	if hasRouteCtx {
+		capabilities = intersect.SimpleGeneric(
+			capabilities,
+			rctx.HandshakeDataOverride[requestedVersion][route.action.HandshakeName()],
+		)
+	}
 
 	req := Request{
 		Context:      r.Context(),
 		ClientID:     clientID,
+		Principal:    principal,
 		Data:         data,
-		Capabilities: route.capabilities,
+		Capabilities: capabilities,
 		handshakeDataFromRouter: func() HandshakeData {
 			return NewHandshakeDataFromRouter(*router, clientID)
 		},
+		handshakeStore: router.handshakeStore,
 	}
-	resp, err := route.handler(req)
+
+	if router.hotelLimiter != nil && ex.hotelCode != "" {
+		if retryAfter, ok := router.hotelLimiter.allow(ex.hotelCode); !ok {
+			ex.Outcome = observability.OutcomeRateLimited
+			router.tooManyRequests(w, r, routes.version, route.action, req, retryAfter)
+			return
+		}
+	}
+	if route.limiter != nil {
+		if retryAfter, ok := route.limiter.allow(clientID); !ok {
+			ex.Outcome = observability.OutcomeRateLimited
+			router.tooManyRequests(w, r, routes.version, route.action, req, retryAfter)
+			return
+		}
+	}
+
+	if route.validate != nil {
+		if err := route.validate(route.action, data, capabilities); err != nil {
+			ex.Outcome = observability.OutcomeValidationFailed
+			err = router.localizeError(route.action, req, err)
+			resp, ok := errorResponse(route.action, data, err)
+			if !ok {
+				preconditionErrorf(w,
+					"validation error for action %s\n\n%s",
+					requestedAction,
+					err.Error())
+				return
+			}
+			writeResponse(w, r, routes.version, resp)
+			return
+		}
+	}
+
+	handler := route.handler
+	if route.serializeHotel && ex.hotelCode != "" {
+		handler = serialized(router.hotelLocks, ex.hotelCode, handler)
+	}
+	handler = chain(handler, router.middlewares, routes.middlewares, route.middlewares)
+	if route.idempotencyKey != nil {
+		handler = router.idempotent(routes.version, route, form.request.Reader(), handler)
+	}
+	resp, err := handler(req)
 	if err != nil {
-		internalServerError(w, r, err)
-		return
+		ex.Err = err
+		errResp, ok := errorResponse(route.action, data, router.localizeError(route.action, req, err))
+		if !ok {
+			if isRequestError(err) {
+				preconditionError(w, err.Error())
+			} else {
+				internalServerError(w, r, err)
+			}
+			return
+		}
+		ex.Outcome = observability.OutcomeErrorResponse
+		resp = errResp
+	}
+	if _, ok := resp.(replayedResponse); ok {
+		ex.Outcome = observability.OutcomeReplayed
 	}
 
-	b, err := xml.Marshal(resp)
+	writeResponse(w, r, routes.version, resp)
+}
+
+func decode(action version.Action, payload io.Reader) (any, error) {
+	if d, ok := action.(version.Decoder); ok {
+		return d.Decode(payload)
+	}
+	b, err := io.ReadAll(payload)
 	if err != nil {
-		internalServerError(w, r, err)
-		return
+		return nil, err
 	}
-	if err = routes.version.ValidateXML(string(b)); err != nil {
-		internalServerError(w, r, err)
-		return
+	return action.Unmarshal(b)
+}
+
+func writeResponse(w http.ResponseWriter, r *http.Request, v version.Version[version.Action], resp any) {
+	writeResponseStatus(w, r, v, resp, http.StatusOK)
+}
+
+func writeResponseStatus(w http.ResponseWriter, r *http.Request, v version.Version[version.Action], resp any, status int) {
+	var b []byte
+	switch resp := resp.(type) {
+	case rawResponse:
+		b = resp
+	case replayedResponse:
+		b = resp
+	default:
+		var err error
+		if b, err = marshalResponse(v, resp); err != nil {
+			internalServerError(w, r, err)
+			return
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
 	w.Write([]byte(xml.Header))
 	w.Write(b)
 }
 
+func marshalResponse(v version.Version[version.Action], resp any) ([]byte, error) {
+	b, err := xml.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err = version.ValidateXMLBytes(v, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func errorResponse(action version.Action, data any, err error) (any, bool) {
+	if responder, ok := action.(version.ErrorResponder); ok {
+		return responder.ErrorResponse(data, err)
+	}
+	return nil, false
+}
+
 func preconditionError(w http.ResponseWriter, msg string) {
 	http.Error(w, fmt.Sprintf("ERROR: %s", msg), http.StatusBadRequest)
 }
@@ -225,6 +591,43 @@ func preconditionErrorf(w http.ResponseWriter, msg string, a ...any) {
 	preconditionError(w, fmt.Sprintf(msg, a...))
 }
 
+func versionNotAgreedError(w http.ResponseWriter, version string) {
+	preconditionErrorf(w,
+		"your current version of '%s' was not included in the handshake agreement. Please retry the handshake to ensure compatibility.",
+		version)
+}
+
+func requestTooLargeError(w http.ResponseWriter) {
+	http.Error(w, "ERROR: request too large", http.StatusRequestEntityTooLarge)
+}
+
+// tooManyRequests answers a request above a rate limit with the action's OTA
+// error response, or the plain error if the action has none.
+func (router *Router) tooManyRequests(w http.ResponseWriter, r *http.Request, v version.Version[version.Action], action version.Action, req Request, retryAfter time.Duration) {
+	err := router.localizeError(action, req, version.ErrTooManyRequests)
+	resp, ok := errorResponse(action, req.Data, err)
+	if !ok {
+		tooManyRequestsError(w, retryAfter)
+		return
+	}
+	setRetryAfter(w, retryAfter)
+	writeResponseStatus(w, r, v, resp, http.StatusTooManyRequests)
+}
+
+func tooManyRequestsError(w http.ResponseWriter, retryAfter time.Duration) {
+	setRetryAfter(w, retryAfter)
+	http.Error(w, "ERROR: too many requests", http.StatusTooManyRequests)
+}
+
+func setRetryAfter(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+}
+
+func unauthorizedError(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="AlpineBits"`)
+	http.Error(w, "ERROR: unauthorized", http.StatusUnauthorized)
+}
+
 func internalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	slog.ErrorContext(r.Context(), err.Error())
 	http.Error(w, "", http.StatusInternalServerError)
@@ -0,0 +1,1200 @@
+package alpinebits
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HGV/alpinebits/archive"
+	"github.com/HGV/alpinebits/internal/compression"
+	"github.com/HGV/alpinebits/observability"
+	"github.com/HGV/alpinebits/v_2020_10"
+	"github.com/HGV/alpinebits/v_2020_10/common"
+	"github.com/HGV/alpinebits/v_2020_10/freerooms"
+	"github.com/HGV/alpinebits/v_2020_10/guestrequests"
+	"github.com/HGV/alpinebits/v_2020_10/handshake"
+	"github.com/HGV/alpinebits/v_2020_10/inventory"
+	"github.com/HGV/alpinebits/v_2020_10/rateplans"
+	"github.com/HGV/alpinebits/v_2020_10/validationutil"
+	"github.com/HGV/alpinebits/v_2022_10"
+	freerooms202210 "github.com/HGV/alpinebits/v_2022_10/freerooms"
+	"github.com/HGV/alpinebits/v_2024_10"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPingRQ = `<?xml version="1.0" encoding="UTF-8"?>
+<OTA_PingRQ xmlns="http://www.opentravel.org/OTA/2003/05" Version="1.0">
+	<EchoData>{"versions":[{"version":"2020-10","actions":[{"action":"action_OTA_Ping"}]}]}</EchoData>
+</OTA_PingRQ>`
+
+const testHotelInvCountNotifRQ = `<?xml version="1.0" encoding="UTF-8"?>
+<OTA_HotelInvCountNotifRQ xmlns="http://www.opentravel.org/OTA/2003/05" Version="4">
+	<Inventories HotelCode="123">
+		<Inventory>
+			<StatusApplicationControl Start="2020-08-01" End="2020-08-10" InvTypeCode="DOUBLE"/>
+			<InvCounts>
+				<InvCount CountType="2" Count="3"/>
+			</InvCounts>
+		</Inventory>
+	</Inventories>
+</OTA_HotelInvCountNotifRQ>`
+
+func newTestRequest(t *testing.T, version, action, payload string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	assert.NoError(t, w.WriteField("action", action))
+	assert.NoError(t, w.WriteField("request", payload))
+	assert.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set(HeaderClientID, "client")
+	req.Header.Set(HeaderClientProtocolVersion, version)
+	return req
+}
+
+type testPrincipal struct {
+	hotelCodes []string
+}
+
+func (p testPrincipal) AuthorizeHotelCode(hotelCode string) bool {
+	return slices.Contains(p.hotelCodes, hotelCode)
+}
+
+func TestRouterAuthenticator(t *testing.T) {
+	authenticator := AuthenticatorFunc(func(ctx context.Context, c Credentials) (Principal, error) {
+		if c.Username == "username" && c.Password == "password" && c.ClientID == "client" {
+			return testPrincipal{hotelCodes: []string{"9000"}}, nil
+		}
+		return nil, ErrUnauthorized
+	})
+
+	var principal Principal
+	registry := observability.NewRegistry()
+	r := NewRouter(WithAuthenticator(authenticator), WithObserver(registry))
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionPing, func(r Request) (any, error) {
+			principal = r.Principal
+			return PingHandler(r)
+		})
+	})
+
+	tests := []struct {
+		name       string
+		username   string
+		password   string
+		statusCode int
+	}{
+		{"missing credentials", "", "", http.StatusUnauthorized},
+		{"invalid credentials", "username", "invalid", http.StatusUnauthorized},
+		{"valid credentials", "username", "password", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newTestRequest(t, "2020-10", v_2020_10.ActionPing.String(), testPingRQ)
+			if tt.username != "" {
+				req.SetBasicAuth(tt.username, tt.password)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+
+	assert.Equal(t, testPrincipal{hotelCodes: []string{"9000"}}, principal)
+	assert.Equal(t, uint64(2), registry.Count(observability.SideServer, "", "", "", observability.OutcomeUnauthorized))
+	assert.Equal(t, uint64(0), registry.Count(observability.SideServer, "", "", "client", observability.OutcomeUnauthorized))
+	assert.Equal(t, uint64(1), registry.Count(observability.SideServer, "", "", "client", observability.OutcomeSuccess))
+}
+
+func TestRequestAuthorizeHotelCode(t *testing.T) {
+	principal := testPrincipal{hotelCodes: []string{"9000"}}
+
+	assert.True(t, Request{Data: &handshake.PingRQ{}}.AuthorizeHotelCode())
+	assert.True(t, Request{Principal: principal, Data: &handshake.PingRQ{}}.AuthorizeHotelCode())
+	assert.True(t, Request{Principal: principal, Data: testHotelCodeProvider("9000")}.AuthorizeHotelCode())
+	assert.False(t, Request{Principal: principal, Data: testHotelCodeProvider("9001")}.AuthorizeHotelCode())
+}
+
+type testHotelCodeProvider string
+
+func (p testHotelCodeProvider) HotelCode() string {
+	return string(p)
+}
+
+func TestRouterGzip(t *testing.T) {
+	r := NewRouter()
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionPing, nil)
+	})
+
+	req := newTestRequest(t, "2020-10", v_2020_10.ActionPing.String(), testPingRQ)
+	body, err := compression.Gzip(mustReadAll(t, req.Body))
+	assert.NoError(t, err)
+	req.Body = io.NopCloser(body)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get(HeaderServerAcceptEncoding))
+	assert.Contains(t, w.Body.String(), "ALPINEBITS_HANDSHAKE")
+}
+
+func mustReadAll(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	return b
+}
+
+func TestRouterVersions(t *testing.T) {
+	v202210, _ := v_2022_10.NewVersion()
+	v202410, _ := v_2024_10.NewVersion()
+
+	r := NewRouter()
+	r.Version(v202210, func(s *Subrouter) {
+		s.Action(v_2022_10.ActionPing, nil)
+	})
+	r.Version(v202410, func(s *Subrouter) {
+		s.Action(v_2024_10.ActionPing, nil)
+	})
+
+	for _, version := range []string{"2022-10", "2024-10"} {
+		t.Run(version, func(t *testing.T) {
+			req := newTestRequest(t, version, "OTA_Ping:Handshaking", testPingRQ)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
+
+func TestRouterBaseRates(t *testing.T) {
+	const payload = `<?xml version="1.0" encoding="UTF-8"?>
+<OTA_HotelRatePlanRQ xmlns="http://www.opentravel.org/OTA/2003/05" Version="1.000">
+	<RatePlans>
+		<RatePlan>
+			<RatePlanCandidates>
+				<RatePlanCandidate RatePlanCode="123456-xyz"/>
+			</RatePlanCandidates>
+			<HotelRef HotelCode="123"/>
+		</RatePlan>
+	</RatePlans>
+</OTA_HotelRatePlanRQ>`
+
+	r := NewRouter()
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionHotelRatePlanBaseRates, func(r Request) (any, error) {
+			rq := r.Data.(*rateplans.HotelRatePlanRQ)
+			if err := rateplans.NewHotelRatePlanValidator().Validate(*rq); err != nil {
+				return nil, err
+			}
+
+			rs := rateplans.HotelRatePlanRS{
+				Version: "1.000",
+				RatePlans: &rateplans.RatePlans{
+					HotelCode: rq.HotelCode(),
+					RatePlans: []rateplans.RatePlan{
+						{RatePlanCode: rq.RatePlan.RatePlanCandidates[0].RatePlanCode, CurrencyCode: "EUR"},
+					},
+				},
+			}
+			rs.SetSuccess()
+			return rs, nil
+		})
+	})
+
+	req := newTestRequest(t, "2020-10", v_2020_10.ActionHotelRatePlanBaseRates.String(), payload)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var rs rateplans.HotelRatePlanRS
+	assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+	assert.NotNil(t, rs.Success)
+	assert.Equal(t, "123", rs.RatePlans.HotelCode)
+	assert.Equal(t, "123456-xyz", rs.RatePlans.RatePlans[0].RatePlanCode)
+}
+
+func TestRouterHotelDescriptiveInfo(t *testing.T) {
+	const payload = `<?xml version="1.0" encoding="UTF-8"?>
+<OTA_HotelDescriptiveInfoRQ xmlns="http://www.opentravel.org/OTA/2003/05" Version="8.000">
+	<HotelDescriptiveInfos>
+		<HotelDescriptiveInfo HotelCode="123"/>
+	</HotelDescriptiveInfos>
+</OTA_HotelDescriptiveInfoRQ>`
+
+	r := NewRouter()
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionHotelDescriptiveInfoInventory, func(r Request) (any, error) {
+			rq := r.Data.(*inventory.HotelDescriptiveInfoRQ)
+			if err := inventory.NewHotelDescriptiveInfoValidator().Validate(*rq); err != nil {
+				return nil, err
+			}
+
+			rs := inventory.HotelDescriptiveInfoRS{
+				Version: "8.000",
+				HotelDescriptiveContent: &inventory.HotelDescriptiveContent{
+					HotelCode:  rq.HotelCode(),
+					HotelName:  "Frangart Inn",
+					GuestRooms: []inventory.GuestRoom{{Code: "DZ", MinOccupancy: 1, MaxOccupancy: 2}},
+				},
+			}
+			rs.SetSuccess()
+			return rs, nil
+		})
+	})
+
+	req := newTestRequest(t, "2020-10", v_2020_10.ActionHotelDescriptiveInfoInventory.String(), payload)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var rs inventory.HotelDescriptiveInfoRS
+	assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+	assert.NotNil(t, rs.Success)
+	assert.Equal(t, "123", rs.HotelDescriptiveContent.HotelCode)
+	assert.Equal(t, "DZ", rs.HotelDescriptiveContent.GuestRooms[0].Code)
+}
+
+func TestRouterHotelResNotif(t *testing.T) {
+	const payload = `<?xml version="1.0" encoding="UTF-8"?>
+<OTA_HotelResNotifRQ xmlns="http://www.opentravel.org/OTA/2003/05" Version="7.000">
+	<HotelReservations>
+		<HotelReservation CreateDateTime="2020-03-21T15:00:00+01:00" ResStatus="Cancelled">
+			<UniqueID Type="15" ID="6b34fe24ac2ff810"/>
+			<ResGlobalInfo>
+				<BasicPropertyInfo HotelCode="123"/>
+			</ResGlobalInfo>
+		</HotelReservation>
+	</HotelReservations>
+</OTA_HotelResNotifRQ>`
+
+	r := NewRouter()
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionHotelResNotifGuestRequests, func(r Request) (any, error) {
+			rq := r.Data.(*guestrequests.HotelResNotifRQ)
+			if err := guestrequests.NewHotelResNotifValidator().Validate(*rq); err != nil {
+				return nil, err
+			}
+
+			acks := make([]guestrequests.Acknowledgement, 0, len(rq.HotelReservations))
+			for _, res := range rq.HotelReservations {
+				acks = append(acks, guestrequests.Acknowledgement{UniqueID: res.UniqueID})
+			}
+			rs := guestrequests.HotelResNotifRS{
+				Version:           "7.000",
+				HotelReservations: &acks,
+			}
+			rs.SetSuccess()
+			return rs, nil
+		})
+	})
+
+	req := newTestRequest(t, "2020-10", v_2020_10.ActionHotelResNotifGuestRequests.String(), payload)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var rs guestrequests.HotelResNotifRS
+	assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+	assert.NotNil(t, rs.Success)
+	assert.Equal(t, "6b34fe24ac2ff810", (*rs.HotelReservations)[0].UniqueID.ID)
+}
+
+func TestRouterValidation(t *testing.T) {
+	newRouter := func(called *bool, caps ...v_2020_10.Capability) *Router {
+		r := NewRouter()
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				*called = true
+				rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+				rs.SetSuccess()
+				return rs, nil
+			}, WithCapabilities(caps...), WithValidation(validationutil.ValidateRequest))
+		})
+		return r
+	}
+
+	t.Run("error response", func(t *testing.T) {
+		var called bool
+		r := newRouter(&called)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, called)
+
+		var rs freerooms.HotelInvCountNotifRS
+		assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+		assert.Nil(t, rs.Success)
+		assert.Equal(t, "4", rs.Version)
+		assert.Len(t, *rs.Errors, 1)
+		assert.Equal(t, common.ErrorWarningTypeApplicationError, (*rs.Errors)[0].Type)
+		assert.Equal(t, common.ErrDeltasNotSupported.Value, (*rs.Errors)[0].Value)
+	})
+
+	t.Run("all errors", func(t *testing.T) {
+		r := NewRouter()
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				return nil, nil
+			}, WithCapabilities(v_2020_10.CapabilityHotelInvCountNotifAcceptRooms), WithValidation(validationutil.ValidateRequestAll))
+		})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var rs freerooms.HotelInvCountNotifRS
+		assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+		assert.Nil(t, rs.Success)
+		assert.Equal(t, []common.Error{
+			{Type: common.ErrorWarningTypeApplicationError, Code: common.CodeUnableToProcess, Value: "UniqueID: " + common.ErrDeltasNotSupported.Value},
+			{Type: common.ErrorWarningTypeApplicationError, Code: common.CodeRequiredFieldMissing, Value: "Inventories/Inventory[1]/StatusApplicationControl/@InvCode: " + common.ErrMissingInvCode.Value},
+			{Type: common.ErrorWarningTypeApplicationError, Code: common.CodeInvalidValue, Value: `Inventories/Inventory[1]/InvCounts/InvCount[1]/@Count: ` + common.ErrInvalidCount(3).Value + ` (value "3")`},
+		}, *rs.Errors)
+	})
+
+	t.Run("capability", func(t *testing.T) {
+		var called bool
+		r := newRouter(&called, v_2020_10.CapabilityHotelInvCountNotifAcceptDeltas)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, called)
+	})
+
+	t.Run("negotiated capabilities", func(t *testing.T) {
+		var called bool
+		r := newRouter(&called, v_2020_10.CapabilityHotelInvCountNotifAcceptDeltas)
+		req := newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ)
+		req = req.WithContext(WithRouteContext(req.Context(), RouteContext{
+			HandshakeDataOverride: HandshakeData{
+				"2020-10": {"action_OTA_HotelInvCountNotif": nil},
+			},
+		}))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, called)
+	})
+
+	t.Run("validator options", func(t *testing.T) {
+		payload, err := os.ReadFile("v_2020_10/rateplans/test/data/RatePlans-OTA_HotelRatePlanNotifRQ.xml")
+		assert.NoError(t, err)
+
+		serve := func(validate ValidateFunc) (rateplans.HotelRatePlanNotifRS, bool) {
+			var called bool
+			r := NewRouter()
+			v202010, _ := v_2020_10.NewVersion()
+			r.Version(v202010, func(s *Subrouter) {
+				s.Action(v_2020_10.ActionHotelRatePlanNotifRatePlans, func(r Request) (any, error) {
+					called = true
+					rs := rateplans.HotelRatePlanNotifRS{Version: "1.000"}
+					rs.SetSuccess()
+					return rs, nil
+				}, WithCapabilities(
+					v_2020_10.CapabilityHotelRatePlanNotifAcceptArrivalDOW,
+					v_2020_10.CapabilityHotelRatePlanNotifAcceptDepartureDOW,
+					v_2020_10.CapabilityHotelRatePlanNotifAcceptSupplements,
+				), WithValidation(validate))
+			})
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelRatePlanNotifRatePlans.String(), string(payload)))
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var rs rateplans.HotelRatePlanNotifRS
+			assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+			return rs, called
+		}
+
+		rs, called := serve(validationutil.ValidateRequest)
+		assert.False(t, called)
+		assert.Nil(t, rs.Success)
+		assert.Len(t, *rs.Errors, 1)
+
+		rs, called = serve(validationutil.NewValidateFunc(
+			validationutil.WithRatePlanOptions(rateplans.WithRoomTypeCodes(map[string]rateplans.RoomTypeOccupancySettings{
+				"double": {Std: 2},
+			})),
+		))
+		assert.True(t, called)
+		assert.NotNil(t, rs.Success)
+	})
+}
+
+func TestRouterHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		code   int
+		assert func(t *testing.T, rs freerooms.HotelInvCountNotifRS)
+	}{
+		{
+			name: "error",
+			err:  common.ErrMissingHotelCode,
+			code: http.StatusOK,
+			assert: func(t *testing.T, rs freerooms.HotelInvCountNotifRS) {
+				assert.Nil(t, rs.Success)
+				assert.Equal(t, common.ErrorWarningTypeApplicationError, (*rs.Errors)[0].Type)
+				assert.Equal(t, common.ErrMissingHotelCode.Value, (*rs.Errors)[0].Value)
+			},
+		},
+		{
+			name: "warning",
+			err:  common.NewWarning("room DZ is unknown"),
+			code: http.StatusOK,
+			assert: func(t *testing.T, rs freerooms.HotelInvCountNotifRS) {
+				assert.NotNil(t, rs.Success)
+				assert.Nil(t, rs.Errors)
+				assert.Equal(t, common.ErrorWarningTypeAdvisory, (*rs.Warnings)[0].Type)
+				assert.Equal(t, "room DZ is unknown", (*rs.Warnings)[0].Value)
+			},
+		},
+		{
+			name: "wrapped error",
+			err:  fmt.Errorf("storing inventory: %w", common.ErrInvCodeNotFound("101")),
+			code: http.StatusOK,
+			assert: func(t *testing.T, rs freerooms.HotelInvCountNotifRS) {
+				assert.Equal(t, "inv code not found 101", (*rs.Errors)[0].Value)
+			},
+		},
+		{
+			name: "internal error",
+			err:  errors.New("database unavailable"),
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRouter()
+			v202010, _ := v_2020_10.NewVersion()
+			r.Version(v202010, func(s *Subrouter) {
+				s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+					return nil, tt.err
+				})
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+
+			assert.Equal(t, tt.code, w.Code)
+			if tt.assert != nil {
+				var rs freerooms.HotelInvCountNotifRS
+				assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+				assert.Equal(t, "4", rs.Version)
+				tt.assert(t, rs)
+			}
+		})
+	}
+}
+
+func TestRouterErrorLanguage(t *testing.T) {
+	newRouter := func(lang string, validate ValidateFunc, err error) *Router {
+		r := NewRouter(WithErrorLanguage(func(r Request) string {
+			assert.Equal(t, "client", r.ClientID)
+			return lang
+		}))
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				return nil, err
+			}, WithCapabilities(v_2020_10.CapabilityHotelInvCountNotifAcceptRooms), WithValidation(validate))
+		})
+		return r
+	}
+	serve := func(t *testing.T, r *Router) []common.Error {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var rs freerooms.HotelInvCountNotifRS
+		assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+		return *rs.Errors
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		errs := serve(t, newRouter("de", validationutil.ValidateRequestAll, nil))
+		assert.Equal(t, []common.Error{
+			{Type: common.ErrorWarningTypeApplicationError, Code: common.CodeUnableToProcess, Value: "UniqueID: Deltas werden nicht unterstützt"},
+			{Type: common.ErrorWarningTypeApplicationError, Code: common.CodeRequiredFieldMissing, Value: "Inventories/Inventory[1]/StatusApplicationControl/@InvCode: Pflichtattribut InvCode fehlt"},
+			{Type: common.ErrorWarningTypeApplicationError, Code: common.CodeInvalidValue, Value: `Inventories/Inventory[1]/InvCounts/InvCount[1]/@Count: Anzahl muss 1 sein, erhalten 3 (value "3")`},
+		}, errs)
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		err := fmt.Errorf("storing inventory: %w", common.ErrInvCodeNotFound("101"))
+
+		errs := serve(t, newRouter("it-IT", nil, err))
+		assert.Equal(t, "InvCode 101 non trovato", errs[0].Value)
+
+		errs = serve(t, newRouter("", nil, err))
+		assert.Equal(t, "inv code not found 101", errs[0].Value)
+	})
+
+	t.Run("escaped values", func(t *testing.T) {
+		payload := strings.Replace(testHotelInvCountNotifRQ, `InvTypeCode="DOUBLE"`, `InvTypeCode="A&amp;B&lt;x"`, 1)
+		for _, lang := range []string{"", "de"} {
+			r := NewRouter(WithErrorLanguage(func(r Request) string { return lang }))
+			v202010, _ := v_2020_10.NewVersion()
+			r.Version(v202010, func(s *Subrouter) {
+				s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+					rq := r.Data.(*freerooms.HotelInvCountNotifRQ)
+					return nil, common.ErrInvTypeCodeNotFound(rq.Inventories.Inventories[0].StatusApplicationControl.InvTypeCode)
+				})
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), payload))
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var rs freerooms.HotelInvCountNotifRS
+			assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+			assert.Contains(t, (*rs.Errors)[0].Value, "A&amp;B&lt;x")
+		}
+	})
+}
+
+func TestRouterMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) MiddlewareFunc {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(r Request) (any, error) {
+				calls = append(calls, name)
+				return next(r)
+			}
+		}
+	}
+	errForbidden := &common.Error{Type: common.ErrorWarningTypeApplicationError, Value: "hotel not authorised"}
+	principal := func(hotelCodes ...string) MiddlewareFunc {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(r Request) (any, error) {
+				r.Principal = testPrincipal{hotelCodes: hotelCodes}
+				return next(r)
+			}
+		}
+	}
+	authorize := func(next HandlerFunc) HandlerFunc {
+		return func(r Request) (any, error) {
+			if !r.AuthorizeHotelCode() {
+				return nil, errForbidden
+			}
+			return next(r)
+		}
+	}
+
+	newRouter := func(hotelCodes ...string) *Router {
+		r := NewRouter()
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Use(trace("version"), principal(hotelCodes...))
+			s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				calls = append(calls, "handler")
+				rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+				rs.SetSuccess()
+				return rs, nil
+			}, WithMiddleware(trace("action"), authorize))
+		})
+		r.Use(trace("router"))
+		return r
+	}
+
+	t.Run("order", func(t *testing.T) {
+		calls = nil
+		w := httptest.NewRecorder()
+		newRouter("123").ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"router", "version", "action", "handler"}, calls)
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		calls = nil
+		w := httptest.NewRecorder()
+		newRouter("456").ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"router", "version", "action"}, calls)
+
+		var rs freerooms.HotelInvCountNotifRS
+		assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &rs))
+		assert.Nil(t, rs.Success)
+		assert.Equal(t, errForbidden.Value, (*rs.Errors)[0].Value)
+	})
+}
+
+func TestHandle(t *testing.T) {
+	var hotelCode string
+	r := NewRouter()
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		Handle(s, v_2020_10.ActionHotelInvCountNotif, func(ctx context.Context, r TypedRequest[freerooms.HotelInvCountNotifRQ]) (freerooms.HotelInvCountNotifRS, error) {
+			hotelCode = r.Data.HotelCode()
+			rs := freerooms.HotelInvCountNotifRS{Version: r.Data.Version}
+			rs.SetSuccess()
+			return rs, nil
+		})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "123", hotelCode)
+
+	assert.Panics(t, func() {
+		Handle(newSubrouter(), v_2020_10.ActionHotelInvCountNotif, func(ctx context.Context, r TypedRequest[freerooms.HotelInvCountNotifRQ]) (inventory.HotelDescriptiveInfoRS, error) {
+			return inventory.HotelDescriptiveInfoRS{}, nil
+		})
+	})
+	assert.Panics(t, func() {
+		Handle(newSubrouter(), v_2020_10.ActionHotelInvCountNotif, func(ctx context.Context, r TypedRequest[*freerooms.HotelInvCountNotifRQ]) (freerooms.HotelInvCountNotifRS, error) {
+			return freerooms.HotelInvCountNotifRS{}, nil
+		})
+	})
+}
+
+func TestRouterObserver(t *testing.T) {
+	server := observability.NewRegistry()
+	r := NewRouter(WithObserver(server))
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionPing, nil)
+		s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+			return nil, nil
+		}, WithValidation(validationutil.ValidateRequest))
+	})
+
+	tests := []struct {
+		version string
+		action  string
+		payload string
+		outcome observability.Outcome
+	}{
+		{"2020-10", v_2020_10.ActionPing.String(), testPingRQ, observability.OutcomeSuccess},
+		{"2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ, observability.OutcomeValidationFailed},
+		{"2020-10", v_2020_10.ActionHotelInvCountNotif.String(), `<OTA_HotelInvCountNotifRQ xmlns="http://www.opentravel.org/OTA/2003/05"/>`, observability.OutcomeInvalidXML},
+		{"2030-10", v_2020_10.ActionPing.String(), testPingRQ, observability.OutcomeRejected},
+	}
+	for _, tt := range tests {
+		r.ServeHTTP(httptest.NewRecorder(), newTestRequest(t, tt.version, tt.action, tt.payload))
+	}
+
+	assert.Equal(t, uint64(1), server.Count(observability.SideServer, "2020-10", v_2020_10.ActionPing.String(), "client", observability.OutcomeSuccess))
+	assert.Equal(t, uint64(1), server.Count(observability.SideServer, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), "client", observability.OutcomeValidationFailed))
+	assert.Equal(t, uint64(1), server.Count(observability.SideServer, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), "client", observability.OutcomeInvalidXML))
+	assert.Equal(t, uint64(1), server.Count(observability.SideServer, "", "", "client", observability.OutcomeRejected))
+
+	var metrics strings.Builder
+	_, err := server.WriteTo(&metrics)
+	assert.NoError(t, err)
+	assert.Contains(t, metrics.String(), `alpinebits_requests_total{side="server",version="2020-10",action="OTA_Ping:Handshaking",client_id="client",outcome="success"} 1`)
+	assert.Contains(t, metrics.String(), `alpinebits_requests_total{side="server",version="",action="",client_id="client",outcome="rejected"} 1`)
+
+	t.Run("handshake client", func(t *testing.T) {
+		srv := httptest.NewServer(r)
+		defer srv.Close()
+
+		client := observability.NewRegistry()
+		c, err := NewHandshakeClient(HandshakeClientConfig{
+			URL:           srv.URL,
+			Username:      "username",
+			Password:      "password",
+			ClientID:      "client",
+			HandshakeData: HandshakeData{"2020-10": {"action_OTA_Ping": nil}},
+			Observer:      client,
+		})
+		assert.NoError(t, err)
+
+		_, _, err = c.Ping(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), client.Count(observability.SideClient, "2020-10", "OTA_Ping:Handshaking", "client", observability.OutcomeSuccess))
+		assert.Equal(t, uint64(2), server.Count(observability.SideServer, "2020-10", v_2020_10.ActionPing.String(), "client", observability.OutcomeSuccess))
+	})
+}
+
+func TestRouterArchive(t *testing.T) {
+	a, err := archive.NewFileSystem(t.TempDir())
+	assert.NoError(t, err)
+
+	r := NewRouter(WithArchive(a))
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+			rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+			rs.SetSuccess()
+			return rs, nil
+		})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, r.CloseArchive(context.Background()))
+
+	entries, err := a.Query(context.Background(), archive.Query{HotelCode: "123"})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, archive.SideServer, entries[0].Side)
+	assert.Equal(t, "client", entries[0].ClientID)
+	assert.Equal(t, "2020-10", entries[0].Version)
+	assert.Equal(t, v_2020_10.ActionHotelInvCountNotif.String(), entries[0].Action)
+	assert.Equal(t, http.StatusOK, entries[0].StatusCode)
+	assert.Equal(t, testHotelInvCountNotifRQ, entries[0].Request)
+	assert.Equal(t, int64(len(testHotelInvCountNotifRQ)), entries[0].RequestSize)
+	assert.False(t, entries[0].RequestTruncated)
+	assert.Equal(t, w.Body.String(), entries[0].Response)
+
+	t.Run("max request size", func(t *testing.T) {
+		a, err := archive.NewFileSystem(t.TempDir())
+		assert.NoError(t, err)
+
+		r := NewRouter(WithArchive(a), WithArchiveMaxRequestSize(64))
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+				rs.SetSuccess()
+				return rs, nil
+			})
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, r.CloseArchive(context.Background()))
+
+		entries, err := a.Query(context.Background(), archive.Query{HotelCode: "123"})
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, testHotelInvCountNotifRQ[:64], entries[0].Request)
+		assert.Equal(t, int64(len(testHotelInvCountNotifRQ)), entries[0].RequestSize)
+		assert.True(t, entries[0].RequestTruncated)
+	})
+
+	t.Run("client", func(t *testing.T) {
+		srv := httptest.NewServer(r)
+		defer srv.Close()
+
+		clientArchive, err := archive.NewFileSystem(t.TempDir())
+		assert.NoError(t, err)
+
+		c, err := v_2020_10.NewClient(v_2020_10.ClientConfig{
+			URL:               srv.URL,
+			Username:          "username",
+			Password:          "password",
+			ClientID:          "client",
+			Version:           v202010,
+			NegotiatedVersion: map[string][]string{"action_OTA_HotelInvCountNotif": nil},
+			Archive:           clientArchive,
+		})
+		assert.NoError(t, err)
+
+		var rq freerooms.HotelInvCountNotifRQ
+		assert.NoError(t, xml.Unmarshal([]byte(testHotelInvCountNotifRQ), &rq))
+		rq.Inventories.HotelName = "Frangart Inn"
+		_, err = c.PushHotelInvCountNotif(context.Background(), rq)
+		assert.NoError(t, err)
+		assert.NoError(t, c.CloseArchive(context.Background()))
+
+		entries, err := clientArchive.Query(context.Background(), archive.Query{HotelCode: "123"})
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, archive.SideClient, entries[0].Side)
+		assert.Contains(t, entries[0].Request, "OTA_HotelInvCountNotifRQ")
+		assert.Contains(t, entries[0].Response, "OTA_HotelInvCountNotifRS")
+	})
+
+	t.Run("slow client archive", func(t *testing.T) {
+		srv := httptest.NewServer(r)
+		defer srv.Close()
+
+		a := &blockingArchive{release: make(chan struct{})}
+		c, err := v_2020_10.NewClient(v_2020_10.ClientConfig{
+			URL:               srv.URL,
+			Username:          "username",
+			Password:          "password",
+			ClientID:          "client",
+			Version:           v202010,
+			NegotiatedVersion: map[string][]string{"action_OTA_HotelInvCountNotif": nil},
+			Archive:           a,
+		})
+		assert.NoError(t, err)
+
+		var rq freerooms.HotelInvCountNotifRQ
+		assert.NoError(t, xml.Unmarshal([]byte(testHotelInvCountNotifRQ), &rq))
+		rq.Inventories.HotelName = "Frangart Inn"
+		for range 2 {
+			_, err = c.PushHotelInvCountNotif(context.Background(), rq)
+			assert.NoError(t, err)
+		}
+
+		close(a.release)
+		assert.NoError(t, c.CloseArchive(context.Background()))
+		assert.Equal(t, int32(2), a.stored.Load())
+	})
+
+	t.Run("slow archive", func(t *testing.T) {
+		a := &blockingArchive{release: make(chan struct{})}
+		r := NewRouter(WithArchive(a), WithArchiveQueueSize(1))
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Action(v_2020_10.ActionPing, nil)
+		})
+
+		// the first exchange blocks the archive, the second waits in the
+		// queue and the third is dropped, none of them delays the response
+		for range 3 {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, newTestRequest(t, "2020-10", v_2020_10.ActionPing.String(), testPingRQ))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Eventually(t, func() bool { return a.started.Load() }, time.Second, time.Millisecond)
+		}
+
+		close(a.release)
+		assert.NoError(t, r.CloseArchive(context.Background()))
+		assert.Equal(t, int32(2), a.stored.Load())
+	})
+}
+
+type blockingArchive struct {
+	release chan struct{}
+	started atomic.Bool
+	stored  atomic.Int32
+}
+
+func (a *blockingArchive) Store(ctx context.Context, e archive.Entry) error {
+	a.started.Store(true)
+	<-a.release
+	a.stored.Add(1)
+	return nil
+}
+
+func TestRouterPayloadLimits(t *testing.T) {
+	newRouter := func(opts ...RouterFunc) *Router {
+		r := NewRouter(opts...)
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Action(v_2020_10.ActionPing, nil, WithMaxPayloadSize(64))
+			s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+				rs.SetSuccess()
+				return rs, nil
+			}, WithMaxPayloadSize(1024))
+			s.Action(v_2020_10.ActionHotelDescriptiveInfoInventory, func(r Request) (any, error) {
+				return nil, nil
+			})
+		})
+		return r
+	}
+
+	tests := []struct {
+		name       string
+		router     *Router
+		action     string
+		payload    string
+		statusCode int
+	}{
+		{"spilled to disk", newRouter(WithMemoryLimit(16)), v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ, http.StatusOK},
+		{"max request size", newRouter(WithMaxRequestSize(128)), v_2020_10.ActionHotelDescriptiveInfoInventory.String(), testHotelInvCountNotifRQ, http.StatusRequestEntityTooLarge},
+		{"lower max payload size", newRouter(), v_2020_10.ActionPing.String(), testPingRQ, http.StatusRequestEntityTooLarge},
+		{"higher max payload size", newRouter(WithMaxRequestSize(128)), v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.router.ServeHTTP(w, newTestRequest(t, "2020-10", tt.action, tt.payload))
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func TestRouterIdempotency(t *testing.T) {
+	const completeSet = `<?xml version="1.0" encoding="UTF-8"?>
+<OTA_HotelInvCountNotifRQ xmlns="http://www.opentravel.org/OTA/2003/05" Version="4">
+	<UniqueID Type="16" ID="%s" Instance="CompleteSet"/>
+	<Inventories HotelCode="123">
+		<Inventory>
+			<StatusApplicationControl Start="2020-08-01" End="2020-08-10" InvTypeCode="DOUBLE"/>
+			<InvCounts>
+				<InvCount CountType="2" Count="%d"/>
+			</InvCounts>
+		</Inventory>
+	</Inventories>
+</OTA_HotelInvCountNotifRQ>`
+
+	newRouter := func(calls *atomic.Int32, key IdempotencyKeyFunc) *Router {
+		r := NewRouter()
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				calls.Add(1)
+				time.Sleep(10 * time.Millisecond)
+				rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+				rs.SetSuccess()
+				return rs, nil
+			}, WithIdempotency(key))
+		})
+		return r
+	}
+	serve := func(r *Router, clientID, payload string) *httptest.ResponseRecorder {
+		req := newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), payload)
+		req.Header.Set(HeaderClientID, clientID)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		return w
+	}
+
+	t.Run("payload hash", func(t *testing.T) {
+		var calls atomic.Int32
+		r := newRouter(&calls, nil)
+
+		first := serve(r, "client", fmt.Sprintf(completeSet, "1", 3))
+		second := serve(r, "client", fmt.Sprintf(completeSet, "1", 3))
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, first.Body.String(), second.Body.String())
+
+		serve(r, "client", fmt.Sprintf(completeSet, "1", 4))
+		assert.Equal(t, int32(2), calls.Load())
+
+		serve(r, "other", fmt.Sprintf(completeSet, "1", 3))
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("unique id", func(t *testing.T) {
+		var calls atomic.Int32
+		r := newRouter(&calls, UniqueIDKey)
+
+		serve(r, "client", fmt.Sprintf(completeSet, "1", 3))
+		serve(r, "client", fmt.Sprintf(completeSet, "1", 4))
+		assert.Equal(t, int32(1), calls.Load())
+
+		serve(r, "client", fmt.Sprintf(completeSet, "2", 4))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("versions", func(t *testing.T) {
+		var calls atomic.Int32
+		r := newRouter(&calls, UniqueIDKey)
+		v202210, _ := v_2022_10.NewVersion()
+		r.Version(v202210, func(s *Subrouter) {
+			s.Action(v_2022_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				calls.Add(1)
+				rs := freerooms202210.HotelInvCountNotifRS{Version: "4"}
+				rs.SetSuccess()
+				return rs, nil
+			}, WithIdempotency(UniqueIDKey))
+		})
+
+		serve(r, "client", fmt.Sprintf(completeSet, "1", 3))
+		req := newTestRequest(t, "2022-10", v_2022_10.ActionHotelInvCountNotif.String(), fmt.Sprintf(completeSet, "1", 3))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("middleware", func(t *testing.T) {
+		var responses []any
+		r := NewRouter()
+		r.Use(func(next HandlerFunc) HandlerFunc {
+			return func(r Request) (any, error) {
+				resp, err := next(r)
+				responses = append(responses, resp)
+				return resp, err
+			}
+		})
+		v202010, _ := v_2020_10.NewVersion()
+		r.Version(v202010, func(s *Subrouter) {
+			s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+				rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+				rs.SetSuccess()
+				return rs, nil
+			}, WithIdempotency(nil))
+		})
+
+		first := serve(r, "client", fmt.Sprintf(completeSet, "1", 3))
+		second := serve(r, "client", fmt.Sprintf(completeSet, "1", 3))
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Len(t, responses, 1)
+		assert.IsType(t, freerooms.HotelInvCountNotifRS{}, responses[0])
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var calls atomic.Int32
+		r := newRouter(&calls, nil)
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Go(func() {
+				serve(r, "client", fmt.Sprintf(completeSet, "1", 3))
+			})
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryIdempotencyStore(20 * time.Millisecond)
+	assert.NoError(t, s.Set(ctx, "key", []byte("response")))
+
+	b, ok, err := s.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("response"), b)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok, err = s.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRouterRateLimit(t *testing.T) {
+	otherHotel := strings.Replace(testHotelInvCountNotifRQ, `HotelCode="123"`, `HotelCode="456"`, 1)
+
+	tests := []struct {
+		name       string
+		routerOpts []RouterFunc
+		routeOpts  []RouteFunc
+		requests   []struct{ clientID, payload string }
+		want       []int
+		wantBody   string
+	}{
+		{
+			name:       "client",
+			routerOpts: []RouterFunc{WithClientRateLimit(RateLimit{Rate: 0.001, Burst: 2})},
+			requests: []struct{ clientID, payload string }{
+				{"client", testHotelInvCountNotifRQ},
+				{"client", otherHotel},
+				{"client", testHotelInvCountNotifRQ},
+				{"other", testHotelInvCountNotifRQ},
+			},
+			want:     []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusOK},
+			wantBody: "ERROR: too many requests",
+		},
+		{
+			name:       "hotel",
+			routerOpts: []RouterFunc{WithHotelRateLimit(RateLimit{Rate: 0.001, Burst: 1})},
+			requests: []struct{ clientID, payload string }{
+				{"client", testHotelInvCountNotifRQ},
+				{"other", testHotelInvCountNotifRQ},
+				{"client", otherHotel},
+			},
+			want:     []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK},
+			wantBody: `<Error Type="13" Code="450">too many requests</Error>`,
+		},
+		{
+			name:      "action",
+			routeOpts: []RouteFunc{WithRateLimit(RateLimit{Rate: 0.001, Burst: 1})},
+			requests: []struct{ clientID, payload string }{
+				{"client", testHotelInvCountNotifRQ},
+				{"client", otherHotel},
+				{"other", testHotelInvCountNotifRQ},
+			},
+			want:     []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK},
+			wantBody: `<Error Type="13" Code="450">too many requests</Error>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := observability.NewRegistry()
+			r := NewRouter(append(tt.routerOpts, WithObserver(registry))...)
+			v202010, _ := v_2020_10.NewVersion()
+			r.Version(v202010, func(s *Subrouter) {
+				s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+					rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+					rs.SetSuccess()
+					return rs, nil
+				}, tt.routeOpts...)
+			})
+
+			for i, rq := range tt.requests {
+				req := newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), rq.payload)
+				req.Header.Set(HeaderClientID, rq.clientID)
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+				assert.Equal(t, tt.want[i], w.Code, "request %d", i)
+				if w.Code == http.StatusTooManyRequests {
+					assert.NotEmpty(t, w.Header().Get("Retry-After"))
+					assert.Contains(t, w.Body.String(), tt.wantBody)
+				}
+			}
+
+			assert.Equal(t, uint64(1), registry.Count(observability.SideServer, "", "", "", observability.OutcomeRateLimited))
+		})
+	}
+}
+
+func TestRouterHotelSerialization(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	r := NewRouter()
+	v202010, _ := v_2020_10.NewVersion()
+	r.Version(v202010, func(s *Subrouter) {
+		s.Action(v_2020_10.ActionHotelInvCountNotif, func(r Request) (any, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			rs := freerooms.HotelInvCountNotifRS{Version: "4"}
+			rs.SetSuccess()
+			return rs, nil
+		}, WithHotelSerialization())
+	})
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			req := newTestRequest(t, "2020-10", v_2020_10.ActionHotelInvCountNotif.String(), testHotelInvCountNotifRQ)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), maxRunning.Load())
+}
@@ -0,0 +1,45 @@
+package alpinebits
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/HGV/alpinebits/version"
+)
+
+// TypedRequest is a Request whose Data is the decoded request message.
+type TypedRequest[RQ any] struct {
+	Request
+
+	Data RQ
+}
+
+type TypedHandlerFunc[RQ, RS any] func(ctx context.Context, r TypedRequest[RQ]) (RS, error)
+
+// Handle registers fn for action like Subrouter.Action. It panics if the
+// action does not implement version.MessageTypesProvider or if RQ and RS are
+// not the action's request and response message types.
+func Handle[RQ, RS any](s *Subrouter, action version.Action, fn TypedHandlerFunc[RQ, RS], opts ...RouteFunc) {
+	provider, ok := action.(version.MessageTypesProvider)
+	if !ok {
+		panic(fmt.Sprintf("alpinebits: action %s does not provide its message types", action))
+	}
+	rq, rs := provider.MessageTypes()
+	if rq != reflect.TypeFor[RQ]() || rs != reflect.TypeFor[RS]() {
+		panic(fmt.Sprintf("alpinebits: action %s handles %v and %v, got %v and %v",
+			action, rq, rs, reflect.TypeFor[RQ](), reflect.TypeFor[RS]()))
+	}
+
+	s.Action(action, func(r Request) (any, error) {
+		data, ok := r.Data.(*RQ)
+		if !ok {
+			return nil, fmt.Errorf("unexpected request type for action %s: %T", action, r.Data)
+		}
+		resp, err := fn(r.Context, TypedRequest[RQ]{Request: r, Data: *data})
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}, opts...)
+}
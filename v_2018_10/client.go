@@ -8,11 +8,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/HGV/alpinebits/archive"
+	"github.com/HGV/alpinebits/internal/compression"
+	"github.com/HGV/alpinebits/observability"
 	"github.com/HGV/alpinebits/v_2018_10/common"
 	"github.com/HGV/alpinebits/v_2018_10/freerooms"
 	"github.com/HGV/alpinebits/v_2018_10/guestrequests"
@@ -23,17 +29,25 @@ import (
 
 type (
 	Client struct {
-		config *ClientConfig
-		client *http.Client
+		config            *ClientConfig
+		client            *http.Client
+		observer          observability.Observer
+		archive           *archive.Queue
+		serverAcceptsGzip atomic.Bool
 	}
 	ClientConfig struct {
-		URL               string
-		Username          string
-		Password          string
-		ClientID          string
-		Version           version.Version[version.Action]
-		NegotiatedVersion map[string][]string
-		HttpClient        *http.Client
+		URL                  string
+		Username             string
+		Password             string
+		ClientID             string
+		Version              version.Version[version.Action]
+		NegotiatedVersion    map[string][]string
+		HttpClient           *http.Client
+		ServerAcceptEncoding string
+		CompressionThreshold int
+		Observer             observability.Observer
+		Archive              archive.Archive
+		ArchiveQueueSize     int
 	}
 	ClientResponse[RS any] struct {
 		*http.Response
@@ -50,10 +64,16 @@ func NewClient(config ClientConfig) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{
-		config: &config,
-		client: cmp.Or(config.HttpClient, &http.Client{}),
-	}, nil
+	c := &Client{
+		config:   &config,
+		client:   cmp.Or(config.HttpClient, &http.Client{}),
+		observer: cmp.Or(config.Observer, observability.Nop),
+	}
+	if config.Archive != nil {
+		c.archive = archive.NewQueue(config.Archive, config.ArchiveQueueSize)
+	}
+	c.serverAcceptsGzip.Store(compression.AcceptsGzipValue(config.ServerAcceptEncoding))
+	return c, nil
 }
 
 func (c *ClientConfig) validate() error {
@@ -100,33 +120,122 @@ func (c *Client) PushAcknowledgement(ctx context.Context, r guestrequests.NotifR
 	return sendRequest[guestrequests.NotifReportRS](ctx, c, ActionNotifReportGuestRequests, r)
 }
 
+func (c *Client) PushGuestRequests(ctx context.Context, r guestrequests.HotelResNotifRQ) (*ClientResponse[guestrequests.HotelResNotifRS], error) {
+	return sendRequest[guestrequests.HotelResNotifRS](ctx, c, ActionHotelResNotifGuestRequests, r)
+}
+
 func (c *Client) PushHotelDescriptiveContentNotif(ctx context.Context, r inventory.HotelDescriptiveContentNotifRQ) (*ClientResponse[inventory.HotelDescriptiveContentNotifRS], error) {
 	return sendRequest[inventory.HotelDescriptiveContentNotifRS](ctx, c, ActionHotelDescriptiveContentNotifInventory, r)
 }
 
+func (c *Client) PushHotelInfo(ctx context.Context, r inventory.HotelDescriptiveContentNotifRQ) (*ClientResponse[inventory.HotelDescriptiveContentNotifRS], error) {
+	return sendRequest[inventory.HotelDescriptiveContentNotifRS](ctx, c, ActionHotelDescriptiveContentNotifInfo, r)
+}
+
+func (c *Client) PullHotelDescriptiveInfo(ctx context.Context, r inventory.HotelDescriptiveInfoRQ) (*ClientResponse[inventory.HotelDescriptiveInfoRS], error) {
+	return sendRequest[inventory.HotelDescriptiveInfoRS](ctx, c, ActionHotelDescriptiveInfoInventory, r)
+}
+
+func (c *Client) PullHotelInfo(ctx context.Context, r inventory.HotelDescriptiveInfoRQ) (*ClientResponse[inventory.HotelDescriptiveInfoRS], error) {
+	return sendRequest[inventory.HotelDescriptiveInfoRS](ctx, c, ActionHotelDescriptiveInfoInfo, r)
+}
+
 func (c *Client) PushRatePlans(ctx context.Context, r rateplans.HotelRatePlanNotifRQ) (*ClientResponse[rateplans.HotelRatePlanNotifRS], error) {
 	return sendRequest[rateplans.HotelRatePlanNotifRS](ctx, c, ActionHotelRatePlanNotifRatePlans, r)
 }
 
+func (c *Client) PullBaseRates(ctx context.Context, r rateplans.HotelRatePlanRQ) (*ClientResponse[rateplans.HotelRatePlanRS], error) {
+	return sendRequest[rateplans.HotelRatePlanRS](ctx, c, ActionHotelRatePlanBaseRates, r)
+}
+
 func sendRequest[RS any, RQ any](ctx context.Context, c *Client, action Action, rq RQ) (*ClientResponse[RS], error) {
-	req, err := c.newRequest(ctx, action, rq)
+	ex := exchange{
+		Observation: observability.Observation{
+			Side:     observability.SideClient,
+			Version:  c.config.Version.String(),
+			Action:   action.String(),
+			ClientID: c.config.ClientID,
+		},
+	}
+	if p, ok := any(rq).(version.HotelCodeProvider); ok {
+		ex.hotelCode = p.HotelCode()
+	}
+	ctx = c.observer.Start(ctx, ex.Observation)
+	start := time.Now()
+
+	var rs RS
+	resp, err := c.send(ctx, action, rq, &rs, &ex)
+	end := time.Now()
+
+	ex.Duration = end.Sub(start)
+	ex.Err = err
+	if ex.Outcome == "" {
+		ex.Outcome = observability.OutcomeSuccess
+		if err != nil {
+			ex.Outcome = observability.OutcomeFailed
+		}
+	}
+	c.observer.End(ctx, ex.Observation)
+
+	if c.archive != nil && ex.request != "" {
+		c.archiveExchange(ctx, ex, start, end)
+	}
+
 	if err != nil {
 		return nil, err
 	}
+	return newClientResponse(resp, &rs)
+}
 
-	var rs RS
-	resp, err := c.do(req, &rs)
+// exchange collects what is known about a request while it is sent.
+type exchange struct {
+	observability.Observation
+
+	request   string
+	response  string
+	hotelCode string
+}
+
+func (c *Client) send(ctx context.Context, action Action, rq any, rs any, ex *exchange) (*http.Response, error) {
+	req, err := c.newRequest(ctx, action, rq, ex)
 	if err != nil {
 		return nil, err
 	}
+	return c.do(req, rs, ex)
+}
 
-	return newClientResponse(resp, &rs)
+func (c *Client) archiveExchange(ctx context.Context, ex exchange, start, end time.Time) {
+	err := c.archive.Store(ctx, archive.Entry{
+		Side:        archive.SideClient,
+		ClientID:    ex.ClientID,
+		Version:     ex.Version,
+		Action:      ex.Action,
+		HotelCode:   ex.hotelCode,
+		RequestedAt: start,
+		RespondedAt: end,
+		StatusCode:  ex.StatusCode,
+		Request:     ex.request,
+		Response:    ex.response,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "archiving request failed", "error", err)
+	}
+}
+
+// CloseArchive stops archiving exchanges and waits until the queued ones are
+// stored or ctx is done.
+func (c *Client) CloseArchive(ctx context.Context) error {
+	if c.archive == nil {
+		return nil
+	}
+	return c.archive.Close(ctx)
 }
 
 var ErrUnsupportedAction = errors.New("unsupported action")
 
-func (c *Client) newRequest(ctx context.Context, action Action, request any) (*http.Request, error) {
+func (c *Client) newRequest(ctx context.Context, action Action, request any, ex *exchange) (*http.Request, error) {
 	if _, ok := c.config.NegotiatedVersion[action.HandshakeName()]; !ok {
+		ex.Outcome = observability.OutcomeRejected
 		return nil, ErrUnsupportedAction
 	}
 
@@ -134,8 +243,11 @@ func (c *Client) newRequest(ctx context.Context, action Action, request any) (*h
 	if err != nil {
 		return nil, err
 	}
+	ex.request = string(xml)
+	ex.RequestSize = len(xml)
 
-	if err = c.config.Version.ValidateXML(string(xml)); err != nil {
+	if err = version.ValidateXMLBytes(c.config.Version, xml); err != nil {
+		ex.Outcome = observability.OutcomeInvalidXML
 		return nil, err
 	}
 
@@ -151,7 +263,15 @@ func (c *Client) newRequest(ctx context.Context, action Action, request any) (*h
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, &body)
+	reqBody, compressed, err := compression.GzipIf(
+		&body,
+		c.serverAcceptsGzip.Load(),
+		cmp.Or(c.config.CompressionThreshold, compression.DefaultThreshold))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -159,31 +279,49 @@ func (c *Client) newRequest(ctx context.Context, action Action, request any) (*h
 	req.SetBasicAuth(c.config.Username, c.config.Password)
 
 	req.Header.Set("Content-Type", w.FormDataContentType())
+	if compressed {
+		req.Header.Set(compression.HeaderContentEncoding, compression.EncodingGzip)
+	}
 	req.Header.Set("X-AlpineBits-ClientID", c.config.ClientID)
 	req.Header.Set("X-AlpineBits-ClientProtocolVersion", c.config.Version.String())
 
 	return req, nil
 }
 
-func (c *Client) do(req *http.Request, v any) (*http.Response, error) {
+func (c *Client) do(req *http.Request, v any, ex *exchange) (*http.Response, error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	// Proxies in between may answer without the header, so only a valid
+	// AlpineBits response can tell that the server stopped accepting gzip.
+	if compression.AcceptsGzip(resp.Header) {
+		c.serverAcceptsGzip.Store(true)
+	}
+
+	ex.StatusCode = resp.StatusCode
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return resp, err
 	}
+	ex.response = string(body)
+	ex.ResponseSize = len(body)
 
 	if sc := resp.StatusCode; sc < 200 || sc > 299 {
+		ex.Outcome = observability.OutcomeFromStatus(sc)
 		return nil, fmt.Errorf("request failed with status code: %d", sc)
 	}
 
-	if err = c.config.Version.ValidateXML(string(body)); err != nil {
+	if err = version.ValidateXMLBytes(c.config.Version, body); err != nil {
+		ex.Outcome = observability.OutcomeInvalidXML
 		return nil, fmt.Errorf("xml validation failed: %w", err)
 	}
+	if !compression.AcceptsGzip(resp.Header) {
+		c.serverAcceptsGzip.Store(false)
+	}
 
 	if err = xml.Unmarshal(body, v); err != nil {
 		return resp, err
@@ -10,6 +10,7 @@ import (
 
 type HotelAvailNotifRQ struct {
 	XMLName             xml.Name            `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelAvailNotifRQ"`
+	Version             string              `xml:"Version,attr"`
 	UniqueID            *UniqueID           `xml:"UniqueID,omitempty"`
 	AvailStatusMessages AvailStatusMessages `xml:"AvailStatusMessages"`
 }
@@ -20,6 +21,15 @@ func (h HotelAvailNotifRQ) HotelCode() string {
 	return h.AvailStatusMessages.HotelCode
 }
 
+var _ version.UniqueIDProvider = (*HotelAvailNotifRQ)(nil)
+
+func (h HotelAvailNotifRQ) UniqueIDValue() string {
+	if h.UniqueID == nil {
+		return ""
+	}
+	return h.UniqueID.ID
+}
+
 type UniqueIDType int
 
 const (
@@ -17,6 +17,7 @@ type HotelAvailNotifValidator struct {
 }
 
 var _ common.Validatable[HotelAvailNotifRQ] = (*HotelAvailNotifValidator)(nil)
+var _ common.ValidatableAll[HotelAvailNotifRQ] = (*HotelAvailNotifValidator)(nil)
 
 type HotelAvailNotifValidatorFunc func(*HotelAvailNotifValidator)
 
@@ -65,92 +66,82 @@ func WithBookingThreshold() HotelAvailNotifValidatorFunc {
 }
 
 func (v HotelAvailNotifValidator) Validate(r HotelAvailNotifRQ) error {
-	if err := common.ValidateHotelCode(r.AvailStatusMessages.HotelCode); err != nil {
-		return err
-	}
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
 
-	if err := v.validateUniqueID(r.UniqueID); err != nil {
-		return err
-	}
+func (v HotelAvailNotifValidator) ValidateAll(r HotelAvailNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
 
-	if r.AvailStatusMessages.IsReset() {
-		return nil
-	}
+func (v HotelAvailNotifValidator) validate(p common.Path, r HotelAvailNotifRQ) {
+	p.Elem("AvailStatusMessages").Attr("HotelCode").Add(common.ValidateHotelCode(r.AvailStatusMessages.HotelCode))
 
-	if err := v.validateAvailStatusMessages(r.AvailStatusMessages.AvailStatusMessages); err != nil {
-		return err
-	}
+	v.validateUniqueID(p.Elem("UniqueID"), r.UniqueID)
 
-	if err := v.validateOverlaps(r.AvailStatusMessages.AvailStatusMessages); err != nil {
-		return err
+	if r.AvailStatusMessages.IsReset() {
+		return
 	}
 
-	return nil
+	p = p.Elem("AvailStatusMessages")
+	v.validateAvailStatusMessages(p, r.AvailStatusMessages.AvailStatusMessages)
+	v.validateOverlaps(p, r.AvailStatusMessages.AvailStatusMessages)
 }
 
-func (v HotelAvailNotifValidator) validateUniqueID(uid *UniqueID) error {
+func (v HotelAvailNotifValidator) validateUniqueID(p common.Path, uid *UniqueID) {
 	if uid == nil && !v.supportsDeltas {
-		return common.ErrDeltasNotSupported
+		p.Add(common.ErrDeltasNotSupported)
 	}
-	return nil
 }
 
-func (v HotelAvailNotifValidator) validateAvailStatusMessages(msgs []AvailStatusMessage) error {
-	for _, msg := range msgs {
-		if err := v.validateAvailStatusMessage(msg); err != nil {
-			return err
-		}
+func (v HotelAvailNotifValidator) validateAvailStatusMessages(p common.Path, msgs []AvailStatusMessage) {
+	for i, msg := range msgs {
+		v.validateAvailStatusMessage(p.Index("AvailStatusMessage", i), msg)
 	}
-	return nil
 }
 
-func (v HotelAvailNotifValidator) validateAvailStatusMessage(msg AvailStatusMessage) error {
+func (v HotelAvailNotifValidator) validateAvailStatusMessage(p common.Path, msg AvailStatusMessage) {
 	availableRooms := msg.BookingLimit
 	if availableRooms > 1 && v.supportsRooms {
-		return common.ErrInvalidBookingLimit(availableRooms)
+		p.Attr("BookingLimit").AddValue(common.ErrInvalidBookingLimit(availableRooms), availableRooms)
 	}
 
 	if !v.supportsBookingThreshold && msg.BookingThreshold > 0 {
-		return common.ErrBookingThresholdNotSupported
+		p.Attr("BookingThreshold").AddValue(common.ErrBookingThresholdNotSupported, msg.BookingThreshold)
 	}
 
 	if msg.BookingThreshold > availableRooms {
-		return common.ErrBookingThresholdGreaterThanBookingLimit
-	}
-
-	if err := v.validateStatusApplicationControl(msg.StatusApplicationControl); err != nil {
-		return err
+		p.Attr("BookingThreshold").AddValue(common.ErrBookingThresholdGreaterThanBookingLimit, msg.BookingThreshold)
 	}
 
-	return nil
+	v.validateStatusApplicationControl(p.Elem("StatusApplicationControl"), msg.StatusApplicationControl)
 }
 
-func (v HotelAvailNotifValidator) validateStatusApplicationControl(s StatusApplicationControl) error {
+func (v HotelAvailNotifValidator) validateStatusApplicationControl(p common.Path, s StatusApplicationControl) {
 	if strings.TrimSpace(s.InvTypeCode) == "" {
-		return common.ErrMissingInvTypeCode
+		p.Attr("InvTypeCode").Add(common.ErrMissingInvTypeCode)
+		return
 	}
 
 	if v.supportsRooms {
 		if strings.TrimSpace(s.InvCode) == "" {
-			return common.ErrMissingInvCode
+			p.Attr("InvCode").Add(common.ErrMissingInvCode)
+			return
 		}
 		if v.roomMapping != nil {
 			if _, ok := (*v.roomMapping)[s.InvTypeCode][s.InvCode]; !ok {
-				return common.ErrInvCodeNotFound(s.InvCode)
+				p.Attr("InvCode").AddValue(common.ErrInvCodeNotFound(s.InvCode), s.InvCode)
 			}
 		}
 	} else if v.supportsCategories {
 		if v.categoriesMapping != nil {
 			if _, ok := (*v.categoriesMapping)[s.InvTypeCode]; !ok {
-				return common.ErrInvTypeCodeNotFound(s.InvTypeCode)
+				p.Attr("InvTypeCode").AddValue(common.ErrInvTypeCodeNotFound(s.InvTypeCode), s.InvTypeCode)
 			}
 		}
 	}
-
-	return nil
 }
 
-func (v HotelAvailNotifValidator) validateOverlaps(msgs []AvailStatusMessage) error {
+func (v HotelAvailNotifValidator) validateOverlaps(p common.Path, msgs []AvailStatusMessage) {
 	availsBy := slicesx.GroupByFunc(msgs, func(msg AvailStatusMessage) string {
 		switch {
 		case v.supportsRooms:
@@ -163,10 +154,6 @@ func (v HotelAvailNotifValidator) validateOverlaps(msgs []AvailStatusMessage) er
 	})
 
 	for _, avails := range availsBy {
-		if err := common.ValidateOverlaps(avails); err != nil {
-			return err
-		}
+		p.Add(common.ValidateOverlaps(avails))
 	}
-
-	return nil
 }
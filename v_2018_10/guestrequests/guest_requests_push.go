@@ -0,0 +1,21 @@
+package guestrequests
+
+import (
+	"encoding/xml"
+
+	"github.com/HGV/alpinebits/v_2018_10/common"
+)
+
+type HotelResNotifRQ struct {
+	XMLName           xml.Name           `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelResNotifRQ"`
+	Version           string             `xml:"Version,attr"`
+	HotelReservations []HotelReservation `xml:"HotelReservations>HotelReservation"`
+}
+
+type HotelResNotifRS struct {
+	common.Response
+
+	XMLName           xml.Name           `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelResNotifRS"`
+	Version           string             `xml:"Version,attr"`
+	HotelReservations *[]Acknowledgement `xml:"HotelReservations>HotelReservation"`
+}
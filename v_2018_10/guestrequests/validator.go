@@ -6,18 +6,24 @@ import (
 
 	"github.com/HGV/alpinebits/v_2018_10/common"
 	"github.com/HGV/alpinebits/v_2018_10/rateplans"
-	"github.com/HGV/x/slicesx"
 )
 
 type ReadValidator struct{}
 
 var _ common.Validatable[ReadRQ] = (*ReadValidator)(nil)
+var _ common.ValidatableAll[ReadRQ] = (*ReadValidator)(nil)
 
 func (v ReadValidator) Validate(r ReadRQ) error {
-	if err := common.ValidateHotelCode(r.HotelReadRequest.HotelCode); err != nil {
-		return err
-	}
-	return nil
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v ReadValidator) ValidateAll(r ReadRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v ReadValidator) validate(p common.Path, r ReadRQ) {
+	p = p.Elem("ReadRequests").Elem("HotelReadRequest").Attr("HotelCode")
+	p.Add(common.ValidateHotelCode(r.HotelReadRequest.HotelCode))
 }
 
 type ResRetrieveValidator struct {
@@ -26,6 +32,7 @@ type ResRetrieveValidator struct {
 }
 
 var _ common.Validatable[ResRetrieveRS] = (*ResRetrieveValidator)(nil)
+var _ common.ValidatableAll[ResRetrieveRS] = (*ResRetrieveValidator)(nil)
 
 type ResRetrieveValidatorFunc func(*ResRetrieveValidator)
 
@@ -44,345 +51,290 @@ func WithRoomTypeCodes(mapping map[string]struct{}) ResRetrieveValidatorFunc {
 }
 
 func (v ResRetrieveValidator) Validate(r ResRetrieveRS) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v ResRetrieveValidator) ValidateAll(r ResRetrieveRS) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v ResRetrieveValidator) validate(p common.Path, r ResRetrieveRS) {
 	if r.HotelReservations != nil {
-		for _, res := range *r.HotelReservations {
-			if err := v.validateHotelReservation(res); err != nil {
-				return err
-			}
+		p := p.Elem("ReservationsList")
+		for i, res := range *r.HotelReservations {
+			v.validateHotelReservation(p.Index("HotelReservation", i), res)
 		}
 	}
-	return nil
 }
 
-func (v ResRetrieveValidator) validateHotelReservation(h HotelReservation) error {
-	if err := v.validateUniqueID(h.UniqueID, h.ResStatus); err != nil {
-		return err
-	}
+func (v ResRetrieveValidator) validateHotelReservation(p common.Path, h HotelReservation) {
+	v.validateUniqueID(p.Elem("UniqueID"), h.UniqueID, h.ResStatus)
 	v.resStatuses = append(v.resStatuses, h.ResStatus)
 
-	if err := v.validateRoomStays(h.RoomStays); err != nil {
-		return err
-	}
-
-	if err := v.validateCustomer(h.Customer); err != nil {
-		return err
-	}
-
-	if err := v.validateResGlobalInfo(h.ResGlobalInfo); err != nil {
-		return err
-	}
-
-	return nil
+	v.validateRoomStays(p.Elem("RoomStays"), h.RoomStays)
+	v.validateCustomer(p.Elem("ResGuests").Elem("ResGuest").Elem("Profiles").Elem("ProfileInfo").Elem("Profile").Elem("Customer"), h.Customer)
+	v.validateResGlobalInfo(p.Elem("ResGlobalInfo"), h.ResGlobalInfo)
 }
 
-func (v ResRetrieveValidator) validateUniqueID(uid UniqueID, resStatus ResStatus) error {
+func (v ResRetrieveValidator) validateUniqueID(p common.Path, uid UniqueID, resStatus ResStatus) {
 	switch resStatus {
 	case ResStatusRequested, ResStatusReserved, ResStatusModify:
 		if uid.Type != UniqueIDTypeReservation {
-			return common.ErrInvalidUniqueID(string(resStatus), int(uid.Type))
+			p.Attr("Type").AddValue(common.ErrInvalidUniqueID(string(resStatus), int(uid.Type)), int(uid.Type))
 		}
 	case ResStatusCancelled:
 		if uid.Type != UniqueIDTypeCancellation {
-			return common.ErrInvalidUniqueID(string(resStatus), int(uid.Type))
+			p.Attr("Type").AddValue(common.ErrInvalidUniqueID(string(resStatus), int(uid.Type)), int(uid.Type))
 		}
 	}
 
 	if err := common.ValidateString(uid.ID); err != nil {
-		return common.ErrMissingID
+		p.Attr("ID").Add(common.ErrMissingID)
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateRoomStays(roomStays *[]RoomStay) error {
+func (v ResRetrieveValidator) validateRoomStays(p common.Path, roomStays *[]RoomStay) {
 	if roomStays == nil || len(*roomStays) == 0 {
 		if !v.isCancellation() {
-			return common.ErrMissingRoomStay
+			p.Add(common.ErrMissingRoomStay)
 		}
-		return nil
+		return
 	}
 
-	primaryRoomStays := slicesx.Filter(*roomStays, RoomStay.isPrimaryStay)
-	for _, roomStay := range primaryRoomStays {
-		if err := v.validateRoomStay(roomStay); err != nil {
-			return err
-		}
+	for _, i := range common.IndexesFunc(*roomStays, RoomStay.isPrimaryStay) {
+		v.validateRoomStay(p.Index("RoomStay", i), (*roomStays)[i])
 	}
 
-	alternativeRoomStays := slicesx.Filter(*roomStays, RoomStay.isAlternativeStay)
-	if len(alternativeRoomStays) > 1 {
-		return common.ErrDuplicateAlternativeRoomStay
+	alternatives := common.IndexesFunc(*roomStays, RoomStay.isAlternativeStay)
+	if len(alternatives) > 1 {
+		p.Index("RoomStay", alternatives[1]).Add(common.ErrDuplicateAlternativeRoomStay)
+		return
 	}
-	if len(alternativeRoomStays) == 1 {
-		return v.validateAlternativeRoomStay(alternativeRoomStays[0])
+	if len(alternatives) == 1 {
+		v.validateAlternativeRoomStay(p.Index("RoomStay", alternatives[0]), (*roomStays)[alternatives[0]])
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateRoomStay(roomStay RoomStay) error {
-	if err := v.validateRoomType(roomStay.RoomType); err != nil {
-		return err
-	}
-
-	if err := v.validateRatePlan(roomStay.RatePlan); err != nil {
-		return err
-	}
-
-	if err := v.validateGuestCounts(roomStay.GuestCounts); err != nil {
-		return err
-	}
-
-	if err := v.validateTimeSpan(roomStay.TimeSpan); err != nil {
-		return err
-	}
-
-	if err := v.validateTotal(roomStay.Total); err != nil {
-		return err
-	}
-
-	return nil
+func (v ResRetrieveValidator) validateRoomStay(p common.Path, roomStay RoomStay) {
+	v.validateRoomType(p.Elem("RoomTypes").Elem("RoomType"), roomStay.RoomType)
+	v.validateRatePlan(p.Elem("RatePlans").Elem("RatePlan"), roomStay.RatePlan)
+	v.validateGuestCounts(p.Elem("GuestCounts"), roomStay.GuestCounts)
+	v.validateTimeSpan(p.Elem("TimeSpan"), roomStay.TimeSpan)
+	v.validateTotal(p.Elem("Total"), roomStay.Total)
 }
 
-func (v ResRetrieveValidator) validateRoomType(roomType *ResRoomType) error {
+func (v ResRetrieveValidator) validateRoomType(p common.Path, roomType *ResRoomType) {
 	if roomType == nil {
 		if v.isReservation() {
-			return common.ErrMissingRoomType
+			p.Add(common.ErrMissingRoomType)
 		}
-		return nil
+		return
 	}
 
 	if strings.TrimSpace(roomType.RoomTypeCode) == "" {
-		return common.ErrMissingRoomTypeCode
+		p.Attr("RoomTypeCode").Add(common.ErrMissingRoomTypeCode)
+		return
 	}
 
 	if v.roomTypeCodes != nil {
 		if _, ok := v.roomTypeCodes[roomType.RoomTypeCode]; !ok {
-			return common.ErrInvCodeNotFound(roomType.RoomTypeCode)
+			p.Attr("RoomTypeCode").AddValue(common.ErrInvCodeNotFound(roomType.RoomTypeCode), roomType.RoomTypeCode)
 		}
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateRatePlan(ratePlan *ResRatePlan) error {
+func (v ResRetrieveValidator) validateRatePlan(p common.Path, ratePlan *ResRatePlan) {
 	if ratePlan == nil {
 		if v.isReservation() {
-			return common.ErrMissingRatePlan
+			p.Add(common.ErrMissingRatePlan)
 		}
-		return nil
+		return
 	}
 
 	if strings.TrimSpace(ratePlan.RatePlanCode) == "" {
-		return common.ErrMissingRatePlanCode
+		p.Attr("RatePlanCode").Add(common.ErrMissingRatePlanCode)
 	}
 
 	if c := ratePlan.Commission; c != nil {
-		if err := v.validateCommission(*c); err != nil {
-			return err
-		}
-	}
-
-	if err := v.validateMealsIncluded(ratePlan.MealsIncluded); err != nil {
-		return err
+		v.validateCommission(p.Elem("Commission"), *c)
 	}
 
-	return nil
+	v.validateMealsIncluded(p.Elem("MealsIncluded"), ratePlan.MealsIncluded)
 }
 
-func (v ResRetrieveValidator) validateCommission(commission Commission) error {
+func (v ResRetrieveValidator) validateCommission(p common.Path, commission Commission) {
 	if commission.Percent != nil {
 		if *commission.Percent > 100 {
-			return common.ErrInvalidPercent
+			p.Attr("Percent").AddValue(common.ErrInvalidPercent, *commission.Percent)
 		}
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateMealsIncluded(mealsIncluded *rateplans.MealsIncluded) error {
+func (v ResRetrieveValidator) validateMealsIncluded(p common.Path, mealsIncluded *rateplans.MealsIncluded) {
 	if v.isReservation() && mealsIncluded == nil {
-		return common.ErrMissingMealsIncluded
+		p.Add(common.ErrMissingMealsIncluded)
 	}
-	return nil
 }
 
-func (v ResRetrieveValidator) validateGuestCounts(guestCounts []GuestCount) error {
+func (v ResRetrieveValidator) validateGuestCounts(p common.Path, guestCounts []GuestCount) {
 	if len(guestCounts) == 0 {
-		return common.ErrMissingGuestCount
+		p.Add(common.ErrMissingGuestCount)
+		return
 	}
 
 	adultSeen := false
-	for _, guestCount := range guestCounts {
+	for i, guestCount := range guestCounts {
 		if guestCount.Age == nil && adultSeen {
-			return common.ErrDuplicateAdultGuestCount
+			p.Index("GuestCount", i).Add(common.ErrDuplicateAdultGuestCount)
 		}
 		adultSeen = adultSeen || guestCount.Age == nil
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateTimeSpan(timeSpan TimeSpan) error {
+func (v ResRetrieveValidator) validateTimeSpan(p common.Path, timeSpan TimeSpan) {
 	if v.isReservation() {
-		if err := v.validateTimeSpanFixedPeriod(timeSpan); err != nil {
-			return err
-		}
-	} else {
-		hasFixedPeriod := timeSpan.Start != nil && timeSpan.End != nil
-		hasWindowedPeriod := timeSpan.StartDateWindow != nil && timeSpan.Duration != nil
+		v.validateTimeSpanFixedPeriod(p, timeSpan)
+		return
+	}
 
-		if !hasFixedPeriod && !hasWindowedPeriod {
-			return common.ErrMissingTimeSpan
-		}
+	hasFixedPeriod := timeSpan.Start != nil && timeSpan.End != nil
+	hasWindowedPeriod := timeSpan.StartDateWindow != nil && timeSpan.Duration != nil
 
-		if hasFixedPeriod {
-			if err := v.validateTimeSpanFixedPeriod(timeSpan); err != nil {
-				return err
-			}
-		}
+	if !hasFixedPeriod && !hasWindowedPeriod {
+		p.Add(common.ErrMissingTimeSpan)
+		return
+	}
 
-		if hasWindowedPeriod {
-			if err := v.validateTimeSpanWindowedPeriod(timeSpan); err != nil {
-				return err
-			}
-		}
+	if hasFixedPeriod {
+		v.validateTimeSpanFixedPeriod(p, timeSpan)
 	}
 
-	return nil
+	if hasWindowedPeriod {
+		v.validateTimeSpanWindowedPeriod(p, timeSpan)
+	}
 }
 
-func (v ResRetrieveValidator) validateTimeSpanFixedPeriod(timeSpan TimeSpan) error {
+func (v ResRetrieveValidator) validateTimeSpanFixedPeriod(p common.Path, timeSpan TimeSpan) {
 	if timeSpan.Start == nil {
-		return common.ErrMissingStart
+		p.Attr("Start").Add(common.ErrMissingStart)
+		return
 	}
 
 	if timeSpan.End == nil {
-		return common.ErrMissingEnd
+		p.Attr("End").Add(common.ErrMissingEnd)
+		return
 	}
 
 	if timeSpan.Start.After(*timeSpan.End) {
-		return common.ErrStartAfterEnd
+		p.Attr("Start").AddValue(common.ErrStartAfterEnd, *timeSpan.Start)
 	}
 
 	if timeSpan.StartDateWindow != nil {
-		return common.ErrUnexpectedStartDateWindow
+		p.Elem("StartDateWindow").Add(common.ErrUnexpectedStartDateWindow)
 	}
 
 	if timeSpan.Duration != nil {
-		return common.ErrUnexpectedDuration
+		p.Attr("Duration").Add(common.ErrUnexpectedDuration)
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateTimeSpanWindowedPeriod(timeSpan TimeSpan) error {
+func (v ResRetrieveValidator) validateTimeSpanWindowedPeriod(p common.Path, timeSpan TimeSpan) {
 	w := timeSpan.StartDateWindow
 	if w == nil {
-		return common.ErrMissingStartDateWindow
+		p.Elem("StartDateWindow").Add(common.ErrMissingStartDateWindow)
+		return
 	}
 
 	if w.EarliestDate.After(w.LatestDate) {
-		return common.ErrEarliestDateAfterLatestDate
+		p.Elem("StartDateWindow").Attr("EarliestDate").AddValue(common.ErrEarliestDateAfterLatestDate, w.EarliestDate)
+		return
 	}
 
 	nights := timeSpan.Duration
 	if nights == nil {
-		return common.ErrMissingDuration
+		p.Attr("Duration").Add(common.ErrMissingDuration)
+		return
 	}
 
 	if int(*nights) >= w.LatestDate.DaysSince(w.EarliestDate) {
-		return common.ErrDurationOutOfRange
+		p.Attr("Duration").AddValue(common.ErrDurationOutOfRange, *nights)
 	}
 
 	if timeSpan.Start != nil {
-		return common.ErrUnexpectedStart
+		p.Attr("Start").Add(common.ErrUnexpectedStart)
 	}
 
 	if timeSpan.End != nil {
-		return common.ErrUnexpectedEnd
+		p.Attr("End").Add(common.ErrUnexpectedEnd)
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateTotal(total *Total) error {
+func (v ResRetrieveValidator) validateTotal(p common.Path, total *Total) {
 	if v.isReservation() && total == nil {
-		return common.ErrMissingTotal
+		p.Add(common.ErrMissingTotal)
 	}
-	return nil
 }
 
-func (v ResRetrieveValidator) validateAlternativeRoomStay(roomStay RoomStay) error {
+func (v ResRetrieveValidator) validateAlternativeRoomStay(p common.Path, roomStay RoomStay) {
 	if !v.isQuoteRequest() {
-		return common.ErrUnexpectedAlternativeRoomStay
+		p.Add(common.ErrUnexpectedAlternativeRoomStay)
+		return
 	}
 
-	if err := v.validateTimeSpan(roomStay.TimeSpan); err != nil {
-		return err
-	}
+	v.validateTimeSpan(p.Elem("TimeSpan"), roomStay.TimeSpan)
 
 	if roomStay.RoomType != nil {
-		return common.ErrUnexpectedRoomType
+		p.Elem("RoomTypes").Elem("RoomType").Add(common.ErrUnexpectedRoomType)
 	}
 
 	if roomStay.RatePlan != nil {
-		return common.ErrUnexpectedRatePlan
+		p.Elem("RatePlans").Elem("RatePlan").Add(common.ErrUnexpectedRatePlan)
 	}
 
 	if len(roomStay.GuestCounts) > 0 {
-		return common.ErrUnexpectedGuestCounts
+		p.Elem("GuestCounts").Add(common.ErrUnexpectedGuestCounts)
 	}
 
 	if roomStay.Total != nil {
-		return common.ErrUnexpectedTotal
+		p.Elem("Total").Add(common.ErrUnexpectedTotal)
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateCustomer(customer *Customer) error {
-	if customer == nil && v.isCancellation() {
-		return nil
+func (v ResRetrieveValidator) validateCustomer(p common.Path, customer *Customer) {
+	if customer == nil {
+		if !v.isCancellation() {
+			p.Add(common.ErrMissingCustomer)
+		}
+		return
 	}
 
-	if err := v.validatePersonName(customer.PersonName); err != nil {
-		return err
-	}
+	v.validatePersonName(p.Elem("PersonName"), customer.PersonName)
 
 	if customer.Email != nil {
-		if err := v.validateEmail(*customer.Email); err != nil {
-			return err
-		}
+		p.Elem("Email").AddValue(v.validateEmail(*customer.Email), customer.Email.Value)
 	}
 
 	if customer.Address != nil {
-		if err := v.validateAddress(*customer.Address); err != nil {
-			return err
-		}
+		v.validateAddress(p.Elem("Address"), *customer.Address)
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validatePersonName(personName PersonName) error {
+func (v ResRetrieveValidator) validatePersonName(p common.Path, personName PersonName) {
 	if personName.NamePrefix != nil && strings.TrimSpace(*personName.NamePrefix) == "" {
-		return common.ErrInvalidNamePrefix
+		p.Elem("NamePrefix").Add(common.ErrInvalidNamePrefix)
 	}
 
 	if strings.TrimSpace(personName.GivenName) == "" {
-		return common.ErrMissingGivenName
+		p.Elem("GivenName").Add(common.ErrMissingGivenName)
 	}
 
 	if strings.TrimSpace(personName.Surname) == "" {
-		return common.ErrMissingSurname
+		p.Elem("Surname").Add(common.ErrMissingSurname)
 	}
 
 	if personName.NameTitle != nil && strings.TrimSpace(*personName.NameTitle) == "" {
-		return common.ErrInvalidNameTitle
+		p.Elem("NameTitle").Add(common.ErrInvalidNameTitle)
 	}
-
-	return nil
 }
 
 func (v ResRetrieveValidator) validateEmail(email Email) error {
@@ -390,24 +342,22 @@ func (v ResRetrieveValidator) validateEmail(email Email) error {
 	return err
 }
 
-func (v ResRetrieveValidator) validateAddress(address Address) error {
+func (v ResRetrieveValidator) validateAddress(p common.Path, address Address) {
 	if err := common.ValidateNonNilString(address.AddressLine); err != nil {
-		return common.ErrInvalidAddressLine
+		p.Elem("AddressLine").Add(common.ErrInvalidAddressLine)
 	}
 
 	if err := common.ValidateNonNilString(address.CityName); err != nil {
-		return common.ErrInvalidCityName
+		p.Elem("CityName").Add(common.ErrInvalidCityName)
 	}
 
 	if err := common.ValidateNonNilString(address.PostalCode); err != nil {
-		return common.ErrInvalidPostalCode
+		p.Elem("PostalCode").Add(common.ErrInvalidPostalCode)
 	}
 
 	if err := v.validateCountryName(address.CountryName); err != nil {
-		return common.ErrInvalidCountryNameCode
+		p.Elem("CountryName").Attr("Code").Add(common.ErrInvalidCountryNameCode)
 	}
-
-	return nil
 }
 
 func (v ResRetrieveValidator) validateCountryName(countryName *CountryName) error {
@@ -417,109 +367,93 @@ func (v ResRetrieveValidator) validateCountryName(countryName *CountryName) erro
 	return common.ValidateString(countryName.Code)
 }
 
-func (v ResRetrieveValidator) validateResGlobalInfo(globalInfo *ResGlobalInfo) error {
-	if globalInfo == nil && v.isCancellation() {
-		return nil
+func (v ResRetrieveValidator) validateResGlobalInfo(p common.Path, globalInfo *ResGlobalInfo) {
+	if globalInfo == nil {
+		if !v.isCancellation() {
+			p.Add(common.ErrMissingResGlobalInfo)
+		}
+		return
 	}
 
-	if err := v.validateComments(globalInfo.Comments); err != nil {
-		return err
-	}
+	v.validateComments(p.Elem("Comments"), globalInfo.Comments)
 
 	if v.isReservation() {
 		if err := common.ValidateNonNilString(globalInfo.CancelPenalty); err != nil {
-			return common.ErrInvalidPenaltyDescriptionText
+			p.Elem("CancelPenalties").Elem("CancelPenalty").Elem("PenaltyDescription").Elem("Text").Add(common.ErrInvalidPenaltyDescriptionText)
 		}
 	}
 
-	if err := v.validateHotelReservationID(globalInfo.HotelReservationID); err != nil {
-		return err
-	}
+	v.validateHotelReservationID(p.Elem("HotelReservationIDs").Elem("HotelReservationID"), globalInfo.HotelReservationID)
 
 	if globalInfo.Profile != nil {
-		if err := v.validateCompanyInfo(globalInfo.Profile.CompanyInfo); err != nil {
-			return err
-		}
+		v.validateCompanyInfo(p.Elem("Profiles").Elem("ProfileInfo").Elem("Profile").Elem("CompanyInfo"), globalInfo.Profile.CompanyInfo)
 	}
 
-	if err := common.ValidateHotelCode(globalInfo.BasicPropertyInfo.HotelCode); err != nil && !v.isCancellation() {
-		return err
+	if !v.isCancellation() {
+		p.Elem("BasicPropertyInfo").Attr("HotelCode").Add(common.ValidateHotelCode(globalInfo.BasicPropertyInfo.HotelCode))
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateComments(comments *[]Comment) error {
+func (v ResRetrieveValidator) validateComments(p common.Path, comments *[]Comment) {
 	if comments == nil {
-		return nil
+		return
 	}
 
-	for _, comment := range *comments {
-		for _, listItem := range comment.ListItems {
+	for i, comment := range *comments {
+		p := p.Index("Comment", i)
+		for j, listItem := range comment.ListItems {
 			if err := common.ValidateString(listItem.Value); err != nil {
-				return common.ErrInvalidListItem
+				p.Index("ListItem", j).Add(common.ErrInvalidListItem)
 			}
 		}
 		if comment.Text != nil {
 			if err := common.ValidateString(comment.Text.Value); err != nil {
-				return common.ErrInvalidCommentText
+				p.Elem("Text").Add(common.ErrInvalidCommentText)
 			}
 		}
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateHotelReservationID(id *HotelReservationID) error {
+func (v ResRetrieveValidator) validateHotelReservationID(p common.Path, id *HotelReservationID) {
 	if id == nil {
-		return nil
+		return
 	}
 
 	if err := common.ValidateNonNilString(id.ResIDValue); err != nil {
-		return common.ErrInvalidResIDValue
+		p.Attr("ResID_Value").Add(common.ErrInvalidResIDValue)
 	}
 
 	if err := common.ValidateNonNilString(id.ResIDSource); err != nil {
-		return common.ErrInvalidResIDSource
+		p.Attr("ResID_Source").Add(common.ErrInvalidResIDSource)
 	}
 
 	if err := common.ValidateNonNilString(id.ResIDSourceContext); err != nil {
-		return common.ErrInvalidResIDSourceContext
+		p.Attr("ResID_SourceContext").Add(common.ErrInvalidResIDSourceContext)
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateCompanyInfo(companyInfo CompanyInfo) error {
-	if err := v.validateCompanyName(companyInfo.CompanyName); err != nil {
-		return err
-	}
+func (v ResRetrieveValidator) validateCompanyInfo(p common.Path, companyInfo CompanyInfo) {
+	v.validateCompanyName(p.Elem("CompanyName"), companyInfo.CompanyName)
 
 	if companyInfo.AddressInfo != nil {
-		if err := v.validateAddress(*companyInfo.AddressInfo); err != nil {
-			return err
-		}
+		v.validateAddress(p.Elem("AddressInfo"), *companyInfo.AddressInfo)
 	}
 
 	if companyInfo.Email != nil {
 		if err := v.validateEmail(*companyInfo.Email); err != nil {
-			return common.ErrInvalidEmail
+			p.Elem("Email").AddValue(common.ErrInvalidEmail, companyInfo.Email.Value)
 		}
 	}
-
-	return nil
 }
 
-func (v ResRetrieveValidator) validateCompanyName(companyName CompanyName) error {
+func (v ResRetrieveValidator) validateCompanyName(p common.Path, companyName CompanyName) {
 	if err := common.ValidateString(companyName.Code); err != nil {
-		return common.ErrInvalidCompanyNameCode
+		p.Attr("Code").Add(common.ErrInvalidCompanyNameCode)
 	}
 
 	if err := common.ValidateString(companyName.Value); err != nil {
-		return common.ErrInvalidCompanyNameValue
+		p.Add(common.ErrInvalidCompanyNameValue)
 	}
-
-	return nil
 }
 
 // Returns true if the current guest request being validated is a quote request.
@@ -539,3 +473,31 @@ func (v ResRetrieveValidator) isCancellation() bool {
 	status := v.resStatuses[len(v.resStatuses)-1]
 	return status == ResStatusCancelled
 }
+
+type HotelResNotifValidator struct {
+	resRetrieveValidator ResRetrieveValidator
+}
+
+var _ common.Validatable[HotelResNotifRQ] = (*HotelResNotifValidator)(nil)
+var _ common.ValidatableAll[HotelResNotifRQ] = (*HotelResNotifValidator)(nil)
+
+func NewHotelResNotifValidator(opts ...ResRetrieveValidatorFunc) HotelResNotifValidator {
+	return HotelResNotifValidator{
+		resRetrieveValidator: NewResRetrieveValidator(opts...),
+	}
+}
+
+func (v HotelResNotifValidator) Validate(r HotelResNotifRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelResNotifValidator) ValidateAll(r HotelResNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelResNotifValidator) validate(p common.Path, r HotelResNotifRQ) {
+	p = p.Elem("HotelReservations")
+	for i, res := range r.HotelReservations {
+		v.resRetrieveValidator.validateHotelReservation(p.Index("HotelReservation", i), res)
+	}
+}
@@ -0,0 +1,33 @@
+package inventory
+
+import (
+	"encoding/xml"
+
+	"github.com/HGV/alpinebits/v_2018_10/common"
+	"github.com/HGV/alpinebits/version"
+)
+
+type HotelDescriptiveInfoRQ struct {
+	XMLName              xml.Name             `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelDescriptiveInfoRQ"`
+	Version              string               `xml:"Version,attr"`
+	HotelDescriptiveInfo HotelDescriptiveInfo `xml:"HotelDescriptiveInfos>HotelDescriptiveInfo"`
+}
+
+var _ version.HotelCodeProvider = (*HotelDescriptiveInfoRQ)(nil)
+
+func (h HotelDescriptiveInfoRQ) HotelCode() string {
+	return h.HotelDescriptiveInfo.HotelCode
+}
+
+type HotelDescriptiveInfo struct {
+	HotelCode string `xml:"HotelCode,attr,omitempty"`
+	HotelName string `xml:"HotelName,attr,omitempty"`
+}
+
+type HotelDescriptiveInfoRS struct {
+	common.Response
+
+	XMLName                 xml.Name                 `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelDescriptiveInfoRS"`
+	Version                 string                   `xml:"Version,attr"`
+	HotelDescriptiveContent *HotelDescriptiveContent `xml:"HotelDescriptiveContents>HotelDescriptiveContent"`
+}
@@ -21,10 +21,14 @@ func (h HotelDescriptiveContentNotifRQ) HotelCode() string {
 }
 
 type HotelDescriptiveContent struct {
-	HotelCode  string      `xml:"HotelCode,attr"`
-	HotelName  string      `xml:"HotelName,attr"`
-	AreaID     int         `xml:"AreaID,attr,omitempty"`
-	GuestRooms []GuestRoom `xml:"FacilityInfo>GuestRooms>GuestRoom"`
+	HotelCode       string       `xml:"HotelCode,attr"`
+	HotelName       string       `xml:"HotelName,attr"`
+	AreaID          int          `xml:"AreaID,attr,omitempty"`
+	HotelInfo       *HotelInfo   `xml:"HotelInfo"`
+	GuestRooms      []GuestRoom  `xml:"FacilityInfo>GuestRooms>GuestRoom"`
+	Policies        *[]Policy    `xml:"Policies>Policy"`
+	AffiliationInfo *[]Award     `xml:"AffiliationInfo>Awards>Award"`
+	ContactInfo     *ContactInfo `xml:"ContactInfos>ContactInfo"`
 }
 
 type GuestRoom struct {
@@ -58,7 +62,7 @@ type MultimediaDescriptions []MultimediaDescription
 
 func (mds MultimediaDescriptions) LongNames() []common.Description {
 	for _, md := range mds {
-		if md.InfoCode == InformationTypeLongName {
+		if md.InfoCode == InformationTypeLongName && md.TextItems != nil {
 			return *md.TextItems
 		}
 	}
@@ -67,16 +71,34 @@ func (mds MultimediaDescriptions) LongNames() []common.Description {
 
 func (mds MultimediaDescriptions) Descriptions() []common.Description {
 	for _, md := range mds {
-		if md.InfoCode == InformationTypeDescription {
+		if md.InfoCode == InformationTypeDescription && md.TextItems != nil {
 			return *md.TextItems
 		}
 	}
 	return nil
 }
 
+func (mds MultimediaDescriptions) ShortDescriptions() []common.Description {
+	for _, md := range mds {
+		if md.InfoCode == InformationTypeShortDescription && md.TextItems != nil {
+			return *md.TextItems
+		}
+	}
+	return nil
+}
+
+func (mds MultimediaDescriptions) Videos() []VideoItem {
+	for _, md := range mds {
+		if md.InfoCode == InformationTypeVideos && md.VideoItems != nil {
+			return *md.VideoItems
+		}
+	}
+	return nil
+}
+
 func (mds MultimediaDescriptions) Pictures() []ImageItem {
 	for _, md := range mds {
-		if md.InfoCode == InformationTypePictures {
+		if md.InfoCode == InformationTypePictures && md.ImageItems != nil {
 			return *md.ImageItems
 		}
 	}
@@ -87,14 +109,17 @@ type MultimediaDescription struct {
 	InfoCode   InformationType       `xml:"InfoCode,attr"`
 	TextItems  *[]common.Description `xml:"TextItems>TextItem>Description"`
 	ImageItems *[]ImageItem          `xml:"ImageItems>ImageItem"`
+	VideoItems *[]VideoItem          `xml:"VideoItems>VideoItem"`
 }
 
 type InformationType int
 
 const (
-	InformationTypeDescription InformationType = 1
-	InformationTypePictures    InformationType = 23
-	InformationTypeLongName    InformationType = 25
+	InformationTypeDescription      InformationType = 1
+	InformationTypeShortDescription InformationType = 17
+	InformationTypePictures         InformationType = 23
+	InformationTypeVideos           InformationType = 24
+	InformationTypeLongName         InformationType = 25
 )
 
 type ImageItem struct {
@@ -105,6 +130,10 @@ type ImageItem struct {
 
 type ImageFormat struct {
 	CopyrightNotice string     `xml:"CopyrightNotice,attr,omitempty"`
+	SourceID        string     `xml:"SourceID,attr,omitempty"`
+	Title           string     `xml:"Title,attr,omitempty"`
+	ApplicableStart string     `xml:"ApplicableStart,attr,omitempty"`
+	ApplicableEnd   string     `xml:"ApplicableEnd,attr,omitempty"`
 	URL             common.URL `xml:"URL"`
 }
 
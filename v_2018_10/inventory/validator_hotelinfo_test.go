@@ -0,0 +1,90 @@
+package inventory
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+
+	"github.com/HGV/alpinebits/v_2018_10/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelInfoValidator_Validate(t *testing.T) {
+	file := "test/data/Inventory-OTA_HotelDescriptiveContentNotifRQ-hotelinfo.xml"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		assert.NoError(t, err, "Failed to read file %s", file)
+	}
+
+	var rq HotelDescriptiveContentNotifRQ
+	if err := xml.Unmarshal(data, &rq); err != nil {
+		assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+	}
+
+	content := rq.HotelDescriptiveContent
+	assert.Equal(t, "3 stars", content.HotelInfo.CategoryCode.CodeDetail)
+	assert.Len(t, content.HotelInfo.Descriptions.Videos(), 1)
+	assert.Len(t, *content.Policies, 6)
+	assert.Equal(t, "Green Hotels", (*content.AffiliationInfo)[0].Provider)
+	assert.Equal(t, "https://www.example.com", content.ContactInfo.URLs[0].Value)
+
+	v := NewHotelInfoValidator()
+	assert.NoError(t, v.Validate(rq))
+
+	tests := []struct {
+		name   string
+		modify func(*HotelDescriptiveContent)
+		err    error
+	}{
+		{
+			name: "guest rooms",
+			modify: func(c *HotelDescriptiveContent) {
+				c.GuestRooms = []GuestRoom{{Code: "DZ"}}
+			},
+			err: common.ErrUnexpectedFacilityInfo,
+		},
+		{
+			name: "latitude",
+			modify: func(c *HotelDescriptiveContent) {
+				lat := 91.0
+				c.HotelInfo.Position.Latitude = &lat
+			},
+			err: common.ErrInvalidLatitude,
+		},
+		{
+			name: "missing provider",
+			modify: func(c *HotelDescriptiveContent) {
+				c.AffiliationInfo = &[]Award{{Rating: "4"}}
+			},
+			err: common.ErrMissingProvider,
+		},
+		{
+			name: "url",
+			modify: func(c *HotelDescriptiveContent) {
+				c.ContactInfo.URLs = []ContactURL{{ID: ContactURLTypeWebsite}}
+			},
+			err: common.ErrInvalidURL,
+		},
+		{
+			name: "duplicate stay context",
+			modify: func(c *HotelDescriptiveContent) {
+				c.Policies = &[]Policy{{StayRequirements: &[]StayRequirement{
+					{StayContext: StayContextCheckin},
+					{StayContext: StayContextCheckin},
+				}}}
+			},
+			err: common.ErrDuplicateStayContext,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rq HotelDescriptiveContentNotifRQ
+			if err := xml.Unmarshal(data, &rq); err != nil {
+				assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+			}
+			tt.modify(&rq.HotelDescriptiveContent)
+			assert.ErrorIs(t, v.Validate(rq), tt.err)
+		})
+	}
+}
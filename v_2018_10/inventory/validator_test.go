@@ -3,8 +3,11 @@ package inventory
 import (
 	"encoding/xml"
 	"os"
+	"regexp"
 	"testing"
 
+	"github.com/HGV/alpinebits/internal/schema"
+	"github.com/HGV/alpinebits/v_2018_10/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,3 +50,31 @@ func TestHotelDescriptiveContentNotifValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestHotelDescriptiveContentNotifValidator_MissingItems(t *testing.T) {
+	data, err := os.ReadFile("test/data/Inventory-OTA_HotelDescriptiveContentNotifRQ.xml")
+	assert.NoError(t, err)
+
+	xsd, err := os.ReadFile("../alpinebits.xsd")
+	assert.NoError(t, err)
+	s, err := schema.Parse(xsd)
+	assert.NoError(t, err)
+
+	for _, expr := range []string{
+		`(?s)<MultimediaDescriptions>.*</MultimediaDescriptions>`,
+		`(?s)<TextItems>.*?</TextItems>`,
+		`(?s)<ImageItems>.*</ImageItems>`,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			doc := regexp.MustCompile(expr).ReplaceAllString(string(data), "")
+			assert.NoError(t, s.Validate(doc))
+
+			var rq HotelDescriptiveContentNotifRQ
+			assert.NoError(t, xml.Unmarshal([]byte(doc), &rq))
+
+			validator := NewHotelDescriptiveContentNotifValidator()
+			assert.Equal(t, common.ErrChildOccupancyNotSupported, validator.Validate(rq))
+			assert.ErrorIs(t, validator.ValidateAll(rq), common.ErrChildOccupancyNotSupported)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 type HotelRatePlanNotifRQ struct {
 	XMLName   xml.Name  `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelRatePlanNotifRQ"`
+	Version   string    `xml:"Version,attr"`
 	UniqueID  *UniqueID `xml:"UniqueID,omitempty"`
 	RatePlans RatePlans `xml:"RatePlans"`
 }
@@ -27,6 +28,15 @@ func (h HotelRatePlanNotifRQ) HotelCode() string {
 	return h.RatePlans.HotelCode
 }
 
+var _ version.UniqueIDProvider = (*HotelRatePlanNotifRQ)(nil)
+
+func (h HotelRatePlanNotifRQ) UniqueIDValue() string {
+	if h.UniqueID == nil {
+		return ""
+	}
+	return h.UniqueID.ID
+}
+
 type UniqueIDType int
 
 const UniqueIDTypeReference UniqueIDType = 16
@@ -45,7 +55,7 @@ type UniqueID struct {
 
 type RatePlans struct {
 	HotelCode string     `xml:"HotelCode,attr"`
-	HotelName string     `xml:"HotelName,attr"`
+	HotelName string     `xml:"HotelName,attr,omitempty"`
 	RatePlans []RatePlan `xml:"RatePlan"`
 }
 
@@ -62,9 +72,9 @@ type RatePlanType int
 const RatePlanTypePromotional RatePlanType = 12
 
 type RatePlan struct {
-	RatePlanNotifType RatePlanNotifType   `xml:"RatePlanNotifType,attr"`
+	RatePlanNotifType RatePlanNotifType   `xml:"RatePlanNotifType,attr,omitempty"`
 	RatePlanType      RatePlanType        `xml:"RatePlanType,attr,omitempty"`
-	CurrencyCode      string              `xml:"CurrencyCode,attr"`
+	CurrencyCode      string              `xml:"CurrencyCode,attr,omitempty"`
 	RatePlanCode      string              `xml:"RatePlanCode,attr"`
 	RatePlanID        string              `xml:"RatePlanID,attr,omitempty"`
 	RatePlanQualifier *bool               `xml:"RatePlanQualifier,attr,omitempty"`
@@ -75,6 +85,35 @@ type RatePlan struct {
 	Descriptions      RatePlanDescription `xml:"Description"`
 }
 
+func (r RatePlan) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := struct {
+		RatePlanNotifType RatePlanNotifType   `xml:"RatePlanNotifType,attr,omitempty"`
+		RatePlanType      RatePlanType        `xml:"RatePlanType,attr,omitempty"`
+		CurrencyCode      string              `xml:"CurrencyCode,attr,omitempty"`
+		RatePlanCode      string              `xml:"RatePlanCode,attr"`
+		RatePlanID        string              `xml:"RatePlanID,attr,omitempty"`
+		RatePlanQualifier *bool               `xml:"RatePlanQualifier,attr,omitempty"`
+		BookingRules      *[]BookingRule      `xml:"BookingRules>BookingRule"`
+		Rates             *[]Rate             `xml:"Rates>Rate"`
+		Supplements       *[]Supplement       `xml:"Supplements>Supplement"`
+		Offers            *[]Offer            `xml:"Offers>Offer"`
+		Descriptions      RatePlanDescription `xml:"Description"`
+	}{
+		RatePlanNotifType: r.RatePlanNotifType,
+		RatePlanType:      r.RatePlanType,
+		CurrencyCode:      r.CurrencyCode,
+		RatePlanCode:      r.RatePlanCode,
+		RatePlanID:        r.RatePlanID,
+		RatePlanQualifier: r.RatePlanQualifier,
+		BookingRules:      nonEmpty(r.BookingRules),
+		Rates:             nonEmpty(r.Rates),
+		Supplements:       nonEmpty(r.Supplements),
+		Offers:            nonEmpty(r.Offers),
+		Descriptions:      r.Descriptions,
+	}
+	return e.EncodeElement(v, start)
+}
+
 func (r RatePlan) IsMaster() bool {
 	return (r.RatePlanQualifier == nil && r.RatePlanID == "") ||
 		(r.RatePlanQualifier != nil && *r.RatePlanQualifier && r.RatePlanID != "")
@@ -89,14 +128,37 @@ const (
 type BookingRule struct {
 	Start               timex.Date         `xml:"Start,attr"`
 	End                 timex.Date         `xml:"End,attr"`
-	Code                string             `xml:"Code,attr"`
-	CodeContext         CodeContext        `xml:"CodeContext,attr"`
+	Code                string             `xml:"Code,attr,omitempty"`
+	CodeContext         CodeContext        `xml:"CodeContext,attr,omitempty"`
 	LengthsOfStay       []LengthOfStay     `xml:"LengthsOfStay>LengthOfStay"`
 	ArrivalDaysOfWeek   *DaysOfWeek        `xml:"DOW_Restrictions>ArrivalDaysOfWeek"`
 	DepartureDaysOfWeek *DaysOfWeek        `xml:"DOW_Restrictions>DepartureDaysOfWeek"`
 	RestrictionStatus   *RestrictionStatus `xml:"RestrictionStatus,omitempty"`
 }
 
+func (b BookingRule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := struct {
+		Start               timex.Date         `xml:"Start,attr"`
+		End                 timex.Date         `xml:"End,attr"`
+		Code                string             `xml:"Code,attr,omitempty"`
+		CodeContext         CodeContext        `xml:"CodeContext,attr,omitempty"`
+		LengthsOfStay       *[]LengthOfStay    `xml:"LengthsOfStay>LengthOfStay"`
+		ArrivalDaysOfWeek   *DaysOfWeek        `xml:"DOW_Restrictions>ArrivalDaysOfWeek"`
+		DepartureDaysOfWeek *DaysOfWeek        `xml:"DOW_Restrictions>DepartureDaysOfWeek"`
+		RestrictionStatus   *RestrictionStatus `xml:"RestrictionStatus,omitempty"`
+	}{
+		Start:               b.Start,
+		End:                 b.End,
+		Code:                b.Code,
+		CodeContext:         b.CodeContext,
+		LengthsOfStay:       nonEmpty(b.LengthsOfStay),
+		ArrivalDaysOfWeek:   b.ArrivalDaysOfWeek,
+		DepartureDaysOfWeek: b.DepartureDaysOfWeek,
+		RestrictionStatus:   b.RestrictionStatus,
+	}
+	return e.EncodeElement(v, start)
+}
+
 var _ version.DateRangeProvider = (*BookingRule)(nil)
 
 func (b BookingRule) DateRange() timex.DateRange {
@@ -166,6 +228,29 @@ type Rate struct {
 	MealsIncluded          *MealsIncluded          `xml:"MealsIncluded,omitempty"`
 }
 
+func (r Rate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := struct {
+		RateTimeUnit           *TimeUnit                `xml:"RateTimeUnit,attr,omitempty"`
+		UnitMultiplier         int                      `xml:"UnitMultiplier,attr,omitempty"`
+		InvTypeCode            string                   `xml:"InvTypeCode,attr,omitempty"`
+		Start                  *timex.Date              `xml:"Start,attr,omitempty"`
+		End                    *timex.Date              `xml:"End,attr,omitempty"`
+		BaseByGuestAmts        *[]BaseByGuestAmt        `xml:"BaseByGuestAmts>BaseByGuestAmt"`
+		AdditionalGuestAmounts *[]AdditionalGuestAmount `xml:"AdditionalGuestAmounts>AdditionalGuestAmount"`
+		MealsIncluded          *MealsIncluded           `xml:"MealsIncluded,omitempty"`
+	}{
+		RateTimeUnit:           r.RateTimeUnit,
+		UnitMultiplier:         r.UnitMultiplier,
+		InvTypeCode:            r.InvTypeCode,
+		Start:                  r.Start,
+		End:                    r.End,
+		BaseByGuestAmts:        nonEmpty(r.BaseByGuestAmts),
+		AdditionalGuestAmounts: nonEmpty(r.AdditionalGuestAmounts),
+		MealsIncluded:          r.MealsIncluded,
+	}
+	return e.EncodeElement(v, start)
+}
+
 var _ version.DateRangeProvider = (*Rate)(nil)
 
 func (r Rate) DateRange() timex.DateRange {
@@ -328,6 +413,25 @@ type OfferRule struct {
 	Occupancies              []Occupancy    `xml:"Occupancy,omitempty"`
 }
 
+func (o OfferRule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := struct {
+		MinAdvancedBookingOffset *duration.Days  `xml:"MinAdvancedBookingOffset,attr,omitempty"`
+		MaxAdvancedBookingOffset *duration.Days  `xml:"MaxAdvancedBookingOffset,attr,omitempty"`
+		LengthsOfStay            *[]LengthOfStay `xml:"LengthsOfStay>LengthOfStay"`
+		ArrivalDaysOfWeek        *DaysOfWeek     `xml:"DOW_Restrictions>ArrivalDaysOfWeek"`
+		DepartureDaysOfWeek      *DaysOfWeek     `xml:"DOW_Restrictions>DepartureDaysOfWeek"`
+		Occupancies              []Occupancy     `xml:"Occupancy,omitempty"`
+	}{
+		MinAdvancedBookingOffset: o.MinAdvancedBookingOffset,
+		MaxAdvancedBookingOffset: o.MaxAdvancedBookingOffset,
+		LengthsOfStay:            nonEmpty(o.LengthsOfStay),
+		ArrivalDaysOfWeek:        o.ArrivalDaysOfWeek,
+		DepartureDaysOfWeek:      o.DepartureDaysOfWeek,
+		Occupancies:              o.Occupancies,
+	}
+	return e.EncodeElement(v, start)
+}
+
 type Occupancy struct {
 	AgeQualifyingCode AgeQualifyingCode `xml:"AgeQualifyingCode,attr"`
 	MinAge            *int              `xml:"MinAge,attr,omitempty"`
@@ -431,7 +535,7 @@ func (rd *RatePlanDescription) decodeDescription(d *xml.Decoder, start xml.Start
 	if err := d.DecodeElement(&t, &start); err != nil {
 		return err
 	}
-	rd.Intros = t.Texts
+	rd.Descriptions = t.Texts
 	return nil
 }
 
@@ -492,6 +596,90 @@ func (rd *RatePlanDescription) decodeGallery(d *xml.Decoder) error {
 	return nil
 }
 
+func (rd RatePlanDescription) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	texts := []struct {
+		name  string
+		texts []common.Description
+	}{
+		{"title", rd.Titles},
+		{"intro", rd.Intros},
+		{"description", rd.Descriptions},
+	}
+	for _, t := range texts {
+		if len(t.texts) == 0 {
+			continue
+		}
+		v := struct {
+			Texts []common.Description `xml:"Text"`
+		}{t.texts}
+		if err := e.EncodeElement(v, descriptionStart(start, t.name)); err != nil {
+			return err
+		}
+	}
+
+	if len(rd.Themes) > 0 {
+		v := struct {
+			ListItems []ListItem `xml:"ListItem"`
+		}{rd.Themes}
+		if err := e.EncodeElement(v, descriptionStart(start, "codelist")); err != nil {
+			return err
+		}
+	}
+
+	if len(rd.Gallery) > 0 {
+		if err := rd.encodeGallery(e, descriptionStart(start, "gallery")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (rd RatePlanDescription) encodeGallery(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, item := range rd.Gallery {
+		if err := e.EncodeElement(item.Image, xml.StartElement{Name: xml.Name{Local: "Image"}}); err != nil {
+			return err
+		}
+		for _, desc := range item.Descriptions {
+			if err := e.EncodeElement(desc, xml.StartElement{Name: xml.Name{Local: "Text"}}); err != nil {
+				return err
+			}
+		}
+		if item.CopyrightNotice != "" {
+			copyright := struct {
+				TextFormat common.TextFormat `xml:"TextFormat,attr"`
+				Value      string            `xml:",chardata"`
+			}{common.TextFormatPlainText, item.CopyrightNotice}
+			if err := e.EncodeElement(copyright, xml.StartElement{Name: xml.Name{Local: "Text"}}); err != nil {
+				return err
+			}
+		}
+		if item.Attribution.Value != "" {
+			if err := e.EncodeElement(item.Attribution, xml.StartElement{Name: xml.Name{Local: "URL"}}); err != nil {
+				return err
+			}
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func descriptionStart(start xml.StartElement, name string) xml.StartElement {
+	return xml.StartElement{
+		Name: start.Name,
+		Attr: []xml.Attr{{Name: xml.Name{Local: "Name"}, Value: name}},
+	}
+}
+
+func nonEmpty[T any](s []T) *[]T {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s
+}
+
 type ListItem struct {
 	Value string `xml:",innerxml"`
 }
@@ -5,8 +5,10 @@ import (
 
 	"github.com/HGV/alpinebits/v_2018_10"
 	"github.com/HGV/alpinebits/v_2018_10/freerooms"
+	"github.com/HGV/alpinebits/v_2018_10/guestrequests"
 	"github.com/HGV/alpinebits/v_2018_10/inventory"
 	"github.com/HGV/alpinebits/v_2018_10/rateplans"
+	"github.com/HGV/alpinebits/version"
 )
 
 func NewFreeRoomOptions(capabilities []string) []freerooms.HotelAvailNotifValidatorFunc {
@@ -70,3 +72,109 @@ func NewRatePlanOptions(capabilities []string) []rateplans.HotelRatePlanNotifVal
 
 	return options
 }
+
+// RequestValidator holds validator options that cannot be derived from
+// capabilities, such as the room and rate plan codes known for a hotel.
+type RequestValidator struct {
+	all           bool
+	freeRooms     []freerooms.HotelAvailNotifValidatorFunc
+	guestRequests []guestrequests.ResRetrieveValidatorFunc
+	inventory     []inventory.HotelDescriptiveContentNotifValidatorFunc
+	ratePlans     []rateplans.HotelRatePlanNotifValidatorFunc
+}
+
+type RequestValidatorFunc func(*RequestValidator)
+
+// WithAllErrors reports all errors of a request as common.ValidationErrors
+// instead of the first one.
+func WithAllErrors() RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.all = true
+	}
+}
+
+func WithFreeRoomOptions(opts ...freerooms.HotelAvailNotifValidatorFunc) RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.freeRooms = append(v.freeRooms, opts...)
+	}
+}
+
+func WithGuestRequestOptions(opts ...guestrequests.ResRetrieveValidatorFunc) RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.guestRequests = append(v.guestRequests, opts...)
+	}
+}
+
+func WithInventoryOptions(opts ...inventory.HotelDescriptiveContentNotifValidatorFunc) RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.inventory = append(v.inventory, opts...)
+	}
+}
+
+func WithRatePlanOptions(opts ...rateplans.HotelRatePlanNotifValidatorFunc) RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.ratePlans = append(v.ratePlans, opts...)
+	}
+}
+
+// NewValidateFunc returns a function running the validator matching the
+// decoded request data, configured from the given capabilities and opts.
+// Requests without a validator are accepted as is.
+func NewValidateFunc(opts ...RequestValidatorFunc) func(action version.Action, data any, capabilities []string) error {
+	var v RequestValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v.validate
+}
+
+// ValidateRequest validates the request data configured from capabilities
+// only.
+func ValidateRequest(action version.Action, data any, capabilities []string) error {
+	return RequestValidator{}.validate(action, data, capabilities)
+}
+
+// ValidateRequestAll is like ValidateRequest but reports all errors of the
+// request as common.ValidationErrors instead of the first one.
+func ValidateRequestAll(action version.Action, data any, capabilities []string) error {
+	return RequestValidator{all: true}.validate(action, data, capabilities)
+}
+
+func (v RequestValidator) validate(action version.Action, data any, capabilities []string) error {
+	switch rq := data.(type) {
+	case *freerooms.HotelAvailNotifRQ:
+		opts := append(NewFreeRoomOptions(capabilities), v.freeRooms...)
+		return validate(v.all, freerooms.NewHotelAvailNotifValidator(opts...), *rq)
+	case *guestrequests.ReadRQ:
+		return validate(v.all, guestrequests.ReadValidator{}, *rq)
+	case *guestrequests.HotelResNotifRQ:
+		return validate(v.all, guestrequests.NewHotelResNotifValidator(v.guestRequests...), *rq)
+	case *inventory.HotelDescriptiveContentNotifRQ:
+		if action == v_2018_10.ActionHotelDescriptiveContentNotifInfo {
+			return validate(v.all, inventory.NewHotelInfoValidator(), *rq)
+		}
+		opts := append(NewInventoryOptions(capabilities), v.inventory...)
+		return validate(v.all, inventory.NewHotelDescriptiveContentNotifValidator(opts...), *rq)
+	case *inventory.HotelDescriptiveInfoRQ:
+		return validate(v.all, inventory.NewHotelDescriptiveInfoValidator(), *rq)
+	case *rateplans.HotelRatePlanNotifRQ:
+		opts := append(NewRatePlanOptions(capabilities), v.ratePlans...)
+		validator := rateplans.NewHotelRatePlanNotifValidator(opts...)
+		return validate(v.all, &validator, *rq)
+	case *rateplans.HotelRatePlanRQ:
+		return validate(v.all, rateplans.NewHotelRatePlanValidator(), *rq)
+	}
+	return nil
+}
+
+type validator[T any] interface {
+	Validate(T) error
+	ValidateAll(T) error
+}
+
+func validate[T any](all bool, v validator[T], rq T) error {
+	if all {
+		return v.ValidateAll(rq)
+	}
+	return v.Validate(rq)
+}
@@ -0,0 +1,86 @@
+package activities
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+
+	"github.com/HGV/alpinebits/v_2020_10/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelPostEventNotifValidator_Validate(t *testing.T) {
+	file := "test/data/Activities-OTA_HotelPostEventNotifRQ.xml"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		assert.NoError(t, err, "Failed to read file %s", file)
+	}
+
+	var rq HotelPostEventNotifRQ
+	if err := xml.Unmarshal(data, &rq); err != nil {
+		assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+	}
+
+	assert.Len(t, rq.EventReports, 1)
+	info := rq.EventReports[0].GeneralEventInfo
+	assert.Equal(t, "e-42", rq.EventReports[0].EventSite.EventID.ID)
+	assert.Equal(t, "Mustermann", info.EventContacts[0].PersonName.Surname)
+	assert.Len(t, info.Dates, 2)
+	assert.Len(t, info.Comments[1].Images, 2)
+
+	v := NewHotelPostEventNotifValidator()
+	assert.NoError(t, v.Validate(rq))
+
+	tests := []struct {
+		name   string
+		modify func(*EventReport)
+		err    error
+	}{
+		{
+			name: "missing event id",
+			modify: func(r *EventReport) {
+				r.EventSite.EventID.ID = ""
+			},
+			err: common.ErrMissingEventID,
+		},
+		{
+			name: "attendees",
+			modify: func(r *EventReport) {
+				r.GeneralEventInfo.AttendeeInfo.PreRegisteredQuantity = 101
+			},
+			err: common.ErrPreRegisteredQuantityGreaterThanTotalQuantity,
+		},
+		{
+			name: "start after end",
+			modify: func(r *EventReport) {
+				r.GeneralEventInfo.Dates[1].End = "2020-09-10"
+			},
+			err: common.ErrStartAfterEnd,
+		},
+		{
+			name: "invalid start",
+			modify: func(r *EventReport) {
+				r.GeneralEventInfo.Dates[0].Start = "tomorrow"
+			},
+			err: common.ErrInvalidStart,
+		},
+		{
+			name: "duplicate language",
+			modify: func(r *EventReport) {
+				r.GeneralEventInfo.Comments[0].Texts[1].Language = "de"
+			},
+			err: common.ErrDuplicateLanguage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rq HotelPostEventNotifRQ
+			if err := xml.Unmarshal(data, &rq); err != nil {
+				assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+			}
+			tt.modify(&rq.EventReports[0])
+			assert.ErrorIs(t, v.Validate(rq), tt.err)
+		})
+	}
+}
@@ -6,244 +6,333 @@ import (
 	"github.com/HGV/x/timex"
 )
 
+// OTA error codes (ERR code list) set in the Code attribute of the errors.
+const (
+	CodeInvalidDate          = 15
+	CodeInvalidRateCode      = 249
+	CodeInvalidValue         = 320
+	CodeRequiredFieldMissing = 321
+	CodeInvalidRoomType      = 402
+	CodeUnableToProcess      = 450
+)
+
+// IDs of the errors created by functions, which can't be compared to a
+// sentinel. Use them with errors.Is.
+const (
+	IDInvCodeNotFound                         ErrorID = "inv_code_not_found"
+	IDInvTypeCodeNotFound                     ErrorID = "inv_type_code_not_found"
+	IDInvalidInvCounts                        ErrorID = "invalid_inv_counts"
+	IDInvalidCount                            ErrorID = "invalid_count"
+	IDDateRangeOverlaps                       ErrorID = "date_range_overlaps"
+	IDInvalidRoomClassificationCode           ErrorID = "invalid_room_classification_code"
+	IDInvalidRoomType                         ErrorID = "invalid_room_type"
+	IDInvalidRoomAmenityType                  ErrorID = "invalid_room_amenity_type"
+	IDInvalidPictureCategoryCode              ErrorID = "invalid_picture_category_code"
+	IDInvalidVideoCategoryCode                ErrorID = "invalid_video_category_code"
+	IDDuplicateCommentName                    ErrorID = "duplicate_comment_name"
+	IDInvalidUniqueID                         ErrorID = "invalid_unique_id"
+	IDRatePlanNotFound                        ErrorID = "rate_plan_not_found"
+	IDDuplicateMealType                       ErrorID = "duplicate_meal_type"
+	IDMinStayArrivalGratherThanMaxStayArrival ErrorID = "min_stay_arrival_greater_than_max_stay_arrival"
+	IDMinStayGratherThanMaxStay               ErrorID = "min_stay_greater_than_max_stay"
+	IDDuplicateBaseByGuestAmt                 ErrorID = "duplicate_base_by_guest_amt"
+	IDMissingBaseByGuestAmtWithStdOccupancy   ErrorID = "missing_base_by_guest_amt_with_std_occupancy"
+	IDMinAgeOutOfRange                        ErrorID = "min_age_out_of_range"
+	IDMaxAgeOutOfRange                        ErrorID = "max_age_out_of_range"
+	IDFamilyOfferMaxAgeTooLow                 ErrorID = "family_offer_max_age_too_low"
+	IDAgeRangeOverlaps                        ErrorID = "age_range_overlaps"
+	IDInvalidInvType                          ErrorID = "invalid_inv_type"
+)
+
+// IDs of the messages shared by the errors of missing and unexpected
+// attributes and elements.
+const (
+	idMissingAttribute    ErrorID = "missing_attribute"
+	idMissingElement      ErrorID = "missing_element"
+	idUnexpectedAttribute ErrorID = "unexpected_attribute"
+	idUnexpectedElement   ErrorID = "unexpected_element"
+)
+
 var (
-	ErrMissingHotelCode                    = newMissingAttributeError("HotelCode")
-	ErrDeltasNotSupported                  = newError("deltas not supported")
-	ErrMissingInvTypeCode                  = newMissingAttributeError("InvTypeCode")
-	ErrMissingInvCode                      = newMissingAttributeError("InvCode")
-	ErrOutOfOrderNotSupported              = newError("out of order not supported")
-	ErrOutOfMarketNotSupported             = newError("out of market not supported")
-	ErrClosingSeasonsNotSupported          = newError("closing seasons not supported")
-	ErrUnexpectedInvCounts                 = newUnexpectedElementError("InvCounts")
-	ErrAvailabilitiesOverlapClosingSeasons = newError("availabilities overlap closing seasons")
-	ErrMissingCode                         = newMissingAttributeError("Code")
-	ErrChildOccupancyNotSupported          = newError("child occupancy not supported")
-	ErrMaxChildOccGreaterThanMaxOcc        = newError("child occupancy must be ≤ max occupancy")
-	ErrStdOccLowerThanMinOcc               = newError("standard occupancy must be ≥ min occupancy")
-	ErrMaxOccLowerThanStdOcc               = newError("max occupancy must be ≥ standard occupancy")
-	ErrMissingMultimediaDescriptions       = newMissingElementError("MultimediaDescriptions")
-	ErrMissingLongName                     = newMissingElementError("MultimediaDescription with attribute InfoCode = 25 (Long name)")
-	ErrDuplicateLanguage                   = newError("duplicate language found for element Description")
-	ErrRoomsNotSupported                   = newError("rooms not supported")
-	ErrMissingRoomID                       = newMissingAttributeError("RoomID")
-	ErrMissingID                           = newMissingAttributeError("UniqueID.ID")
-	ErrMissingRoomStay                     = newMissingElementError("RoomStay")
-	ErrDuplicateAlternativeRoomStay        = newError("at most one alternative room stay is allowed")
-	ErrUnexpectedAlternativeRoomStay       = newError("alternative room stay is not allowed")
-	ErrMissingRoomType                     = newMissingElementError("RoomType")
-	ErrUnexpectedRoomType                  = newUnexpectedElementError("RoomType")
-	ErrMissingRoomTypeCode                 = newMissingAttributeError("RoomTypeCode")
-	ErrMissingRatePlan                     = newMissingElementError("RatePlan")
-	ErrUnexpectedRatePlan                  = newUnexpectedElementError("RatePlan")
-	ErrMissingRatePlanID                   = newMissingAttributeError("RatePlanID")
-	ErrMissingRatePlanQualifier            = newMissingAttributeError("RatePlanQualifier")
-	ErrMissingRatePlanCode                 = newMissingAttributeError("RatePlanCode")
-	ErrInvalidPercent                      = newError("percent must be ≤ 100")
-	ErrMissingMealsIncluded                = newMissingElementError("MealsIncluded")
-	ErrMissingGuestCount                   = newMissingElementError("GuestCount")
-	ErrUnexpectedGuestCounts               = newUnexpectedElementError("GuestCounts")
-	ErrDuplicateAdultGuestCount            = newError("duplicate element GuestCount for adults")
-	ErrMissingStart                        = newMissingAttributeError("Start")
-	ErrMissingEnd                          = newMissingAttributeError("End")
-	ErrMissingTotal                        = newMissingElementError("Total")
-	ErrUnexpectedTotal                     = newUnexpectedElementError("Total")
-	ErrStartAfterEnd                       = newError("start must be ≤ end")
-	ErrMissingDuration                     = newMissingAttributeError("Duration")
-	ErrUnexpectedStartDateWindow           = newUnexpectedElementError("StartDateWindow")
-	ErrUnexpectedDuration                  = newUnexpectedAttributeError("Duration")
-	ErrMissingTimeSpan                     = newMissingElementError("TimeSpan")
-	ErrMissingStartDateWindow              = newMissingElementError("StartDateWindow")
-	ErrEarliestDateAfterLatestDate         = newError("earliest date must be ≤ latest date")
-	ErrDurationOutOfRange                  = newError("duration exceeds the allowed date range")
-	ErrInvalidNamePrefix                   = newError("invalid value for attribute NamePrefix")
-	ErrMissingGivenName                    = newMissingAttributeError("GivenName")
-	ErrMissingSurname                      = newMissingAttributeError("Surname")
-	ErrInvalidNameTitle                    = newError("invalid value for attribute NameTitle")
-	ErrInvalidAddressLine                  = newError("invalid value for attribute AddressLine")
-	ErrInvalidCityName                     = newError("invalid value for attribute CityName")
-	ErrInvalidPostalCode                   = newError("invalid value for attribute PostalCode")
-	ErrInvalidCountryNameCode              = newError("invalid value for attribute CountryName.Code")
-	ErrInvalidListItem                     = newError("invalid value for element ListItem")
-	ErrInvalidCommentText                  = newError("invalid value for element Comment.Text")
-	ErrInvalidPenaltyDescriptionText       = newError("invalid value for attribute element PenaltyDescription.Text")
-	ErrInvalidResIDValue                   = newError("invalid value for attribute ResIDValue")
-	ErrInvalidResIDSource                  = newError("invalid value for attribute ResIDSource")
-	ErrInvalidResIDSourceContext           = newError("invalid value for attribute ResIDSourceContext")
-	ErrInvalidCompanyNameCode              = newError("invalid value for attribute CompanyName.Code")
-	ErrInvalidCompanyNameValue             = newError("invalid value for element CompanyName")
-	ErrInvalidEmail                        = newError("invalid value for element Email")
-	ErrMissingCurrencyCode                 = newMissingAttributeError("CurrencyCode")
-	ErrRatePlanJoinNotSupported            = newError("rate plan join not supported")
-	ErrMissingOfferRule                    = newMissingElementError("OfferRule")
-	ErrOfferRuleBookingOffsetNotSupported  = newError("offer rule booking offset not supported")
-	ErrOfferRuleDOWLOSNotSupported         = newError("offer rule days of week and lengths of stay not supported")
-	ErrStayThroughNotAllowedInOfferRule    = newError("invalid value for attribute MinMaxMessageType inside element OfferRule")
-	ErrMissingAdultOccupancy               = newMissingElementError("Occupancy with attribute AgeQualifyingCode = 10")
-	ErrInvalidMinOccupancy                 = newError("min occupancy must be ≤ 99")
-	ErrInvalidMaxOccupancy                 = newError("max occupancy must be ≤ 99")
-	ErrDuplicateChildOccupancy             = newError("duplicate element Occupancy with attribute AgeQualifyingCode = 8")
-	ErrDuplicateFreeNightOffer             = newError("duplicate free night offer")
-	ErrDuplicateFamilyOffer                = newError("duplicate family offer")
-	ErrFreeNightOfferNotSupported          = newError("free night offer not supported")
-	ErrMissingNightsRequired               = newMissingAttributeError("NightsRequired")
-	ErrMissingNightsDiscounted             = newMissingAttributeError("NightsDiscounted")
-	ErrInvalidDiscountPattern              = newError("invalid value for attribute DiscountPattern")
-	ErrFamilyOfferNotSupported             = newError("free night offer not supported")
-	ErrInvalidGuestAgeQualifyngCode        = newError("invalid value for attribute Guest.AgeQualifyingCode")
-	ErrRoomTypeBookingRulesNotSupported    = newError("room type booking rules not supported")
-	ErrArrivalDOWNotSupported              = newError("arrival days of week not supported")
-	ErrDepartureDOWNotSupported            = newError("departure days of week not supported")
-	ErrMissingStaticRate                   = newMissingElementError("static Rate")
-	ErrInvalidRateTimeUnit                 = newError("invalid value for attribute RateTimeUnit")
-	ErrMissingBaseByGuestAmt               = newMissingElementError("BaseByGuestAmt")
-	ErrMissingNumberOfGuests               = newMissingAttributeError("NumberOfGuests")
-	ErrMissingAgeQualifyingCode            = newMissingAttributeError("AgeQualifyingCode")
-	ErrMissingAmountAfterTax               = newMissingAttributeError("AmountAfterTax")
-	ErrMissingAmount                       = newMissingAttributeError("Amount")
-	ErrDuplicateAdditionalGuestAmountAdult = newError("duplicate element AdditionalGuestAmount with attribute AgeQualifyingCode = 10")
-	ErrChildrenNotAllowed                  = newError("children not allowed")
-	ErrMissingMinAge                       = newMissingAttributeError("MinAge")
-	ErrMinAgeGreaterThanOrEqualsThanMaxAge = newError("attribute MinAge must be < attribute MaxAge")
-	ErrSupplementsNotSupported             = newError("supplements not supported")
-	ErrMissingAddToBasicRateIndicator      = newMissingAttributeError("AddToBasicRateIndicator")
-	ErrMissingMandatoryIndicator           = newMissingAttributeError("MandatoryIndicator")
-	ErrMissingChargeTypeCode               = newMissingAttributeError("ChargeTypeCode")
-	ErrInvalidDOWString                    = newError("invalid value for attribute InvCode with attribute InvType = ALPINEBITSDOW")
-	ErrUnexpectedOffers                    = newUnexpectedElementError("Offers")
-	ErrUnexpectedDescription               = newUnexpectedElementError("Description")
-	ErrUnexpectedBookingRules              = newUnexpectedElementError("BookingRules")
-	ErrUnexpectedRates                     = newUnexpectedElementError("Rates")
-	ErrUnexpectedSupplements               = newUnexpectedElementError("Supplements")
-	ErrUnexpectedGuest                     = newUnexpectedElementError("Guest")
-	ErrUnexpectedNightsRequired            = newUnexpectedAttributeError("NightsRequired")
-	ErrUnexpectedNightsDiscounted          = newUnexpectedAttributeError("NightsDiscounted")
-	ErrUnexpectedDiscountPattern           = newUnexpectedAttributeError("DiscountPattern")
-	ErrUnexpectedInvTypeCode               = newUnexpectedAttributeError("InvTypeCode")
-	ErrUnexpectedStart                     = newUnexpectedAttributeError("Start")
-	ErrUnexpectedEnd                       = newUnexpectedAttributeError("End")
-	ErrUnexpectedNumberOfGuests            = newUnexpectedAttributeError("NumberOfGuests")
-	ErrUnexpectedAgeQualifyingCode         = newUnexpectedAttributeError("AgeQualifyingCode")
-	ErrUnexpectedAmountAfterTax            = newUnexpectedAttributeError("AmountAfterTax")
-	ErrUnexpectedBaseByGuestAmt            = newError("static rates can contain only one element BaseByGuestAmt")
-	ErrUnexpectedAdditionalGuestAmounts    = newUnexpectedElementError("AdditionalGuestAmounts")
-	ErrUnexpectedRateTimeUnit              = newUnexpectedAttributeError("RateTimeUnit")
-	ErrUnexpectedUnitMultiplier            = newUnexpectedAttributeError("UnitMultiplier")
-	ErrUnexpectedMealsIncluded             = newUnexpectedElementError("MealsIncluded")
-	ErrUnexpectedType                      = newUnexpectedAttributeError("Type")
-	ErrUnexpectedAmount                    = newUnexpectedAttributeError("Amount")
-	ErrUnexpectedAddToBasicRateIndicator   = newUnexpectedAttributeError("AddToBasicRateIndicator")
-	ErrUnexpectedMandatoryIndicator        = newUnexpectedAttributeError("MandatoryIndicator")
-	ErrUnexpectedChargeTypeCode            = newUnexpectedAttributeError("ChargeTypeCode")
-	ErrChargeTypeMismatch                  = newError("derived rate plan charge type must match master rate plan charge type")
+	ErrMissingHotelCode                              = newMissingAttributeError("missing_hotel_code", "HotelCode")
+	ErrDeltasNotSupported                            = newError("deltas_not_supported", CodeUnableToProcess, "deltas not supported")
+	ErrTooManyRequests                               = newError("too_many_requests", CodeUnableToProcess, "too many requests")
+	ErrMissingInvTypeCode                            = newMissingAttributeError("missing_inv_type_code", "InvTypeCode")
+	ErrMissingInvCode                                = newMissingAttributeError("missing_inv_code", "InvCode")
+	ErrOutOfOrderNotSupported                        = newError("out_of_order_not_supported", CodeUnableToProcess, "out of order not supported")
+	ErrOutOfMarketNotSupported                       = newError("out_of_market_not_supported", CodeUnableToProcess, "out of market not supported")
+	ErrClosingSeasonsNotSupported                    = newError("closing_seasons_not_supported", CodeUnableToProcess, "closing seasons not supported")
+	ErrUnexpectedInvCounts                           = newUnexpectedElementError("unexpected_inv_counts", "InvCounts")
+	ErrAvailabilitiesOverlapClosingSeasons           = newError("availabilities_overlap_closing_seasons", CodeInvalidValue, "availabilities overlap closing seasons")
+	ErrMissingCode                                   = newMissingAttributeError("missing_code", "Code")
+	ErrChildOccupancyNotSupported                    = newError("child_occupancy_not_supported", CodeUnableToProcess, "child occupancy not supported")
+	ErrMaxChildOccGreaterThanMaxOcc                  = newError("max_child_occ_greater_than_max_occ", CodeInvalidValue, "child occupancy must be ≤ max occupancy")
+	ErrStdOccLowerThanMinOcc                         = newError("std_occ_lower_than_min_occ", CodeInvalidValue, "standard occupancy must be ≥ min occupancy")
+	ErrMaxOccLowerThanStdOcc                         = newError("max_occ_lower_than_std_occ", CodeInvalidValue, "max occupancy must be ≥ standard occupancy")
+	ErrMissingMultimediaDescriptions                 = newMissingElementError("missing_multimedia_descriptions", "MultimediaDescriptions")
+	ErrMissingCustomer                               = newMissingElementError("missing_customer", "Customer")
+	ErrMissingResGlobalInfo                          = newMissingElementError("missing_res_global_info", "ResGlobalInfo")
+	ErrMissingStatusApplicationControl               = newMissingElementError("missing_status_application_control", "StatusApplicationControl")
+	ErrMissingLongName                               = newMissingElementError("missing_long_name", "MultimediaDescription with attribute InfoCode = 25 (Long name)")
+	ErrDuplicateLanguage                             = newError("duplicate_language", CodeInvalidValue, "duplicate language found for element Description")
+	ErrRoomsNotSupported                             = newError("rooms_not_supported", CodeUnableToProcess, "rooms not supported")
+	ErrMissingRoomID                                 = newMissingAttributeError("missing_room_id", "RoomID")
+	ErrMissingID                                     = newMissingAttributeError("missing_id", "UniqueID.ID")
+	ErrMissingRoomStay                               = newMissingElementError("missing_room_stay", "RoomStay")
+	ErrDuplicateAlternativeRoomStay                  = newError("duplicate_alternative_room_stay", CodeInvalidValue, "at most one alternative room stay is allowed")
+	ErrUnexpectedAlternativeRoomStay                 = newError("unexpected_alternative_room_stay", CodeInvalidValue, "alternative room stay is not allowed")
+	ErrMissingRoomType                               = newMissingElementError("missing_room_type", "RoomType")
+	ErrUnexpectedRoomType                            = newUnexpectedElementError("unexpected_room_type", "RoomType")
+	ErrMissingRoomTypeCode                           = newMissingAttributeError("missing_room_type_code", "RoomTypeCode")
+	ErrMissingRatePlan                               = newMissingElementError("missing_rate_plan", "RatePlan")
+	ErrUnexpectedRatePlan                            = newUnexpectedElementError("unexpected_rate_plan", "RatePlan")
+	ErrMissingRatePlanID                             = newMissingAttributeError("missing_rate_plan_id", "RatePlanID")
+	ErrMissingRatePlanQualifier                      = newMissingAttributeError("missing_rate_plan_qualifier", "RatePlanQualifier")
+	ErrMissingRatePlanCode                           = newMissingAttributeError("missing_rate_plan_code", "RatePlanCode")
+	ErrInvalidPercent                                = newError("invalid_percent", CodeInvalidValue, "percent must be ≤ 100")
+	ErrMissingMealsIncluded                          = newMissingElementError("missing_meals_included", "MealsIncluded")
+	ErrMissingGuestCount                             = newMissingElementError("missing_guest_count", "GuestCount")
+	ErrUnexpectedGuestCounts                         = newUnexpectedElementError("unexpected_guest_counts", "GuestCounts")
+	ErrDuplicateAdultGuestCount                      = newError("duplicate_adult_guest_count", CodeInvalidValue, "duplicate element GuestCount for adults")
+	ErrMissingStart                                  = newMissingAttributeError("missing_start", "Start")
+	ErrMissingEnd                                    = newMissingAttributeError("missing_end", "End")
+	ErrMissingTotal                                  = newMissingElementError("missing_total", "Total")
+	ErrUnexpectedTotal                               = newUnexpectedElementError("unexpected_total", "Total")
+	ErrStartAfterEnd                                 = newError("start_after_end", CodeInvalidDate, "start must be ≤ end")
+	ErrMissingDuration                               = newMissingAttributeError("missing_duration", "Duration")
+	ErrUnexpectedStartDateWindow                     = newUnexpectedElementError("unexpected_start_date_window", "StartDateWindow")
+	ErrUnexpectedDuration                            = newUnexpectedAttributeError("unexpected_duration", "Duration")
+	ErrMissingTimeSpan                               = newMissingElementError("missing_time_span", "TimeSpan")
+	ErrMissingStartDateWindow                        = newMissingElementError("missing_start_date_window", "StartDateWindow")
+	ErrEarliestDateAfterLatestDate                   = newError("earliest_date_after_latest_date", CodeInvalidDate, "earliest date must be ≤ latest date")
+	ErrDurationOutOfRange                            = newError("duration_out_of_range", CodeInvalidDate, "duration exceeds the allowed date range")
+	ErrInvalidNamePrefix                             = newError("invalid_name_prefix", CodeInvalidValue, "invalid value for attribute NamePrefix")
+	ErrMissingGivenName                              = newMissingAttributeError("missing_given_name", "GivenName")
+	ErrMissingSurname                                = newMissingAttributeError("missing_surname", "Surname")
+	ErrInvalidNameTitle                              = newError("invalid_name_title", CodeInvalidValue, "invalid value for attribute NameTitle")
+	ErrInvalidAddressLine                            = newError("invalid_address_line", CodeInvalidValue, "invalid value for attribute AddressLine")
+	ErrInvalidCityName                               = newError("invalid_city_name", CodeInvalidValue, "invalid value for attribute CityName")
+	ErrInvalidPostalCode                             = newError("invalid_postal_code", CodeInvalidValue, "invalid value for attribute PostalCode")
+	ErrInvalidCountryNameCode                        = newError("invalid_country_name_code", CodeInvalidValue, "invalid value for attribute CountryName.Code")
+	ErrInvalidListItem                               = newError("invalid_list_item", CodeInvalidValue, "invalid value for element ListItem")
+	ErrInvalidCommentText                            = newError("invalid_comment_text", CodeInvalidValue, "invalid value for element Comment.Text")
+	ErrInvalidPenaltyDescriptionText                 = newError("invalid_penalty_description_text", CodeInvalidValue, "invalid value for attribute element PenaltyDescription.Text")
+	ErrInvalidResIDValue                             = newError("invalid_res_id_value", CodeInvalidValue, "invalid value for attribute ResIDValue")
+	ErrInvalidResIDSource                            = newError("invalid_res_id_source", CodeInvalidValue, "invalid value for attribute ResIDSource")
+	ErrInvalidResIDSourceContext                     = newError("invalid_res_id_source_context", CodeInvalidValue, "invalid value for attribute ResIDSourceContext")
+	ErrInvalidCompanyNameCode                        = newError("invalid_company_name_code", CodeInvalidValue, "invalid value for attribute CompanyName.Code")
+	ErrInvalidCompanyNameValue                       = newError("invalid_company_name_value", CodeInvalidValue, "invalid value for element CompanyName")
+	ErrInvalidEmail                                  = newError("invalid_email", CodeInvalidValue, "invalid value for element Email")
+	ErrMissingCurrencyCode                           = newMissingAttributeError("missing_currency_code", "CurrencyCode")
+	ErrRatePlanJoinNotSupported                      = newError("rate_plan_join_not_supported", CodeUnableToProcess, "rate plan join not supported")
+	ErrMissingOfferRule                              = newMissingElementError("missing_offer_rule", "OfferRule")
+	ErrOfferRuleBookingOffsetNotSupported            = newError("offer_rule_booking_offset_not_supported", CodeUnableToProcess, "offer rule booking offset not supported")
+	ErrOfferRuleDOWLOSNotSupported                   = newError("offer_rule_dow_los_not_supported", CodeUnableToProcess, "offer rule days of week and lengths of stay not supported")
+	ErrStayThroughNotAllowedInOfferRule              = newError("stay_through_not_allowed_in_offer_rule", CodeInvalidValue, "invalid value for attribute MinMaxMessageType inside element OfferRule")
+	ErrMissingAdultOccupancy                         = newMissingElementError("missing_adult_occupancy", "Occupancy with attribute AgeQualifyingCode = 10")
+	ErrInvalidMinOccupancy                           = newError("invalid_min_occupancy", CodeInvalidValue, "min occupancy must be ≤ 99")
+	ErrInvalidMaxOccupancy                           = newError("invalid_max_occupancy", CodeInvalidValue, "max occupancy must be ≤ 99")
+	ErrDuplicateChildOccupancy                       = newError("duplicate_child_occupancy", CodeInvalidValue, "duplicate element Occupancy with attribute AgeQualifyingCode = 8")
+	ErrDuplicateFreeNightOffer                       = newError("duplicate_free_night_offer", CodeInvalidValue, "duplicate free night offer")
+	ErrDuplicateFamilyOffer                          = newError("duplicate_family_offer", CodeInvalidValue, "duplicate family offer")
+	ErrFreeNightOfferNotSupported                    = newError("free_night_offer_not_supported", CodeUnableToProcess, "free night offer not supported")
+	ErrMissingNightsRequired                         = newMissingAttributeError("missing_nights_required", "NightsRequired")
+	ErrMissingNightsDiscounted                       = newMissingAttributeError("missing_nights_discounted", "NightsDiscounted")
+	ErrInvalidDiscountPattern                        = newError("invalid_discount_pattern", CodeInvalidValue, "invalid value for attribute DiscountPattern")
+	ErrFamilyOfferNotSupported                       = newError("family_offer_not_supported", CodeUnableToProcess, "free night offer not supported")
+	ErrInvalidGuestAgeQualifyngCode                  = newError("invalid_guest_age_qualifying_code", CodeInvalidValue, "invalid value for attribute Guest.AgeQualifyingCode")
+	ErrRoomTypeBookingRulesNotSupported              = newError("room_type_booking_rules_not_supported", CodeUnableToProcess, "room type booking rules not supported")
+	ErrArrivalDOWNotSupported                        = newError("arrival_dow_not_supported", CodeUnableToProcess, "arrival days of week not supported")
+	ErrDepartureDOWNotSupported                      = newError("departure_dow_not_supported", CodeUnableToProcess, "departure days of week not supported")
+	ErrMissingStaticRate                             = newMissingElementError("missing_static_rate", "static Rate")
+	ErrInvalidRateTimeUnit                           = newError("invalid_rate_time_unit", CodeInvalidValue, "invalid value for attribute RateTimeUnit")
+	ErrMissingBaseByGuestAmt                         = newMissingElementError("missing_base_by_guest_amt", "BaseByGuestAmt")
+	ErrMissingNumberOfGuests                         = newMissingAttributeError("missing_number_of_guests", "NumberOfGuests")
+	ErrMissingAgeQualifyingCode                      = newMissingAttributeError("missing_age_qualifying_code", "AgeQualifyingCode")
+	ErrMissingAmountAfterTax                         = newMissingAttributeError("missing_amount_after_tax", "AmountAfterTax")
+	ErrMissingAmount                                 = newMissingAttributeError("missing_amount", "Amount")
+	ErrDuplicateAdditionalGuestAmountAdult           = newError("duplicate_additional_guest_amount_adult", CodeInvalidValue, "duplicate element AdditionalGuestAmount with attribute AgeQualifyingCode = 10")
+	ErrChildrenNotAllowed                            = newError("children_not_allowed", CodeInvalidValue, "children not allowed")
+	ErrMissingMinAge                                 = newMissingAttributeError("missing_min_age", "MinAge")
+	ErrMinAgeGreaterThanOrEqualsThanMaxAge           = newError("min_age_greater_than_or_equals_than_max_age", CodeInvalidValue, "attribute MinAge must be < attribute MaxAge")
+	ErrSupplementsNotSupported                       = newError("supplements_not_supported", CodeUnableToProcess, "supplements not supported")
+	ErrMissingAddToBasicRateIndicator                = newMissingAttributeError("missing_add_to_basic_rate_indicator", "AddToBasicRateIndicator")
+	ErrMissingMandatoryIndicator                     = newMissingAttributeError("missing_mandatory_indicator", "MandatoryIndicator")
+	ErrMissingChargeTypeCode                         = newMissingAttributeError("missing_charge_type_code", "ChargeTypeCode")
+	ErrInvalidDOWString                              = newError("invalid_dow_string", CodeInvalidValue, "invalid value for attribute InvCode with attribute InvType = ALPINEBITSDOW")
+	ErrUnexpectedOffers                              = newUnexpectedElementError("unexpected_offers", "Offers")
+	ErrUnexpectedDescription                         = newUnexpectedElementError("unexpected_description", "Description")
+	ErrUnexpectedBookingRules                        = newUnexpectedElementError("unexpected_booking_rules", "BookingRules")
+	ErrUnexpectedRates                               = newUnexpectedElementError("unexpected_rates", "Rates")
+	ErrUnexpectedSupplements                         = newUnexpectedElementError("unexpected_supplements", "Supplements")
+	ErrUnexpectedGuest                               = newUnexpectedElementError("unexpected_guest", "Guest")
+	ErrUnexpectedNightsRequired                      = newUnexpectedAttributeError("unexpected_nights_required", "NightsRequired")
+	ErrUnexpectedNightsDiscounted                    = newUnexpectedAttributeError("unexpected_nights_discounted", "NightsDiscounted")
+	ErrUnexpectedDiscountPattern                     = newUnexpectedAttributeError("unexpected_discount_pattern", "DiscountPattern")
+	ErrUnexpectedInvTypeCode                         = newUnexpectedAttributeError("unexpected_inv_type_code", "InvTypeCode")
+	ErrUnexpectedStart                               = newUnexpectedAttributeError("unexpected_start", "Start")
+	ErrUnexpectedEnd                                 = newUnexpectedAttributeError("unexpected_end", "End")
+	ErrUnexpectedNumberOfGuests                      = newUnexpectedAttributeError("unexpected_number_of_guests", "NumberOfGuests")
+	ErrUnexpectedAgeQualifyingCode                   = newUnexpectedAttributeError("unexpected_age_qualifying_code", "AgeQualifyingCode")
+	ErrUnexpectedAmountAfterTax                      = newUnexpectedAttributeError("unexpected_amount_after_tax", "AmountAfterTax")
+	ErrUnexpectedBaseByGuestAmt                      = newError("unexpected_base_by_guest_amt", CodeInvalidValue, "static rates can contain only one element BaseByGuestAmt")
+	ErrUnexpectedAdditionalGuestAmounts              = newUnexpectedElementError("unexpected_additional_guest_amounts", "AdditionalGuestAmounts")
+	ErrUnexpectedRateTimeUnit                        = newUnexpectedAttributeError("unexpected_rate_time_unit", "RateTimeUnit")
+	ErrUnexpectedUnitMultiplier                      = newUnexpectedAttributeError("unexpected_unit_multiplier", "UnitMultiplier")
+	ErrUnexpectedMealsIncluded                       = newUnexpectedElementError("unexpected_meals_included", "MealsIncluded")
+	ErrUnexpectedType                                = newUnexpectedAttributeError("unexpected_type", "Type")
+	ErrUnexpectedAmount                              = newUnexpectedAttributeError("unexpected_amount", "Amount")
+	ErrUnexpectedAddToBasicRateIndicator             = newUnexpectedAttributeError("unexpected_add_to_basic_rate_indicator", "AddToBasicRateIndicator")
+	ErrUnexpectedMandatoryIndicator                  = newUnexpectedAttributeError("unexpected_mandatory_indicator", "MandatoryIndicator")
+	ErrUnexpectedChargeTypeCode                      = newUnexpectedAttributeError("unexpected_charge_type_code", "ChargeTypeCode")
+	ErrChargeTypeMismatch                            = newError("charge_type_mismatch", CodeInvalidValue, "derived rate plan charge type must match master rate plan charge type")
+	ErrUnexpectedFacilityInfo                        = newUnexpectedElementError("unexpected_facility_info", "FacilityInfo")
+	ErrMissingCodeDetail                             = newMissingAttributeError("missing_code_detail", "CodeDetail")
+	ErrInvalidLatitude                               = newError("invalid_latitude", CodeInvalidValue, "latitude must be ≥ -90 and ≤ 90")
+	ErrInvalidLongitude                              = newError("invalid_longitude", CodeInvalidValue, "longitude must be ≥ -180 and ≤ 180")
+	ErrMissingProvider                               = newMissingAttributeError("missing_provider", "Provider")
+	ErrMissingPhoneTechType                          = newMissingAttributeError("missing_phone_tech_type", "PhoneTechType")
+	ErrInvalidPhoneNumber                            = newError("invalid_phone_number", CodeInvalidValue, "invalid value for attribute PhoneNumber")
+	ErrMissingEmailType                              = newMissingAttributeError("missing_email_type", "EmailType")
+	ErrInvalidURL                                    = newError("invalid_url", CodeInvalidValue, "invalid value for element URL")
+	ErrDuplicateStayContext                          = newError("duplicate_stay_context", CodeInvalidValue, "duplicate element StayRequirement with the same attribute StayContext")
+	ErrMissingEventReport                            = newMissingElementError("missing_event_report", "EventReport")
+	ErrMissingEventID                                = newMissingAttributeError("missing_event_id", "Event_ID.ID")
+	ErrMissingEventIDContext                         = newMissingAttributeError("missing_event_id_context", "Event_ID.ID_Context")
+	ErrInvalidEventIDType                            = newError("invalid_event_id_type", CodeInvalidValue, "invalid value for attribute Event_ID.Type")
+	ErrPreRegisteredQuantityGreaterThanTotalQuantity = newError("pre_registered_quantity_greater_than_total_quantity", CodeInvalidValue, "pre-registered quantity must be ≤ total quantity")
+	ErrInvalidStart                                  = newError("invalid_start", CodeInvalidDate, "invalid value for attribute Start")
+	ErrInvalidEnd                                    = newError("invalid_end", CodeInvalidDate, "invalid value for attribute End")
+	ErrInvalidLatestDate                             = newError("invalid_latest_date", CodeInvalidDate, "invalid value for attribute LatestDate")
 )
 
 func ErrInvCodeNotFound(invCode string) *Error {
-	return newErrorf("inv code not found %s", invCode)
+	return newErrorf(IDInvCodeNotFound, CodeInvalidRoomType, "inv code not found %s", invCode)
 }
 
 func ErrInvTypeCodeNotFound(invTypeCode string) *Error {
-	return newErrorf("inv type code not found %s", invTypeCode)
+	return newErrorf(IDInvTypeCodeNotFound, CodeInvalidRoomType, "inv type code not found %s", invTypeCode)
 }
 
 func ErrInvalidInvCounts(n int) *Error {
-	return newErrorf("invalid value for element InvCounts, expected one element InvCount, got %d", n)
+	return newErrorf(IDInvalidInvCounts, CodeInvalidValue, "invalid value for element InvCounts, expected one element InvCount, got %d", n)
 }
 
 func ErrInvalidCount(n int) *Error {
-	return newErrorf("inv count must be 1, got %d", n)
+	return newErrorf(IDInvalidCount, CodeInvalidValue, "inv count must be 1, got %d", n)
 }
 
 func ErrDateRangeOverlaps(range1, range2 timex.DateRange) *Error {
-	return newErrorf("date range [%s - %s] overlaps with [%s - %s]", range1.Start, range1.End, range2.Start, range2.End)
+	return newErrorf(IDDateRangeOverlaps, CodeInvalidDate, "date range [%s - %s] overlaps with [%s - %s]", range1.Start, range1.End, range2.Start, range2.End)
 }
 
 func ErrInvalidRoomClassificationCode(roomClassificationCode int) *Error {
-	return newErrorf("invalid value for attribute RoomClassificationCode %d", roomClassificationCode)
+	return newErrorf(IDInvalidRoomClassificationCode, CodeInvalidValue, "invalid value for attribute RoomClassificationCode %d", roomClassificationCode)
 }
 
 func ErrInvalidRoomType(roomType int) *Error {
-	return newErrorf("invalid value for attribute RoomType %d", roomType)
+	return newErrorf(IDInvalidRoomType, CodeInvalidValue, "invalid value for attribute RoomType %d", roomType)
 }
 
 func ErrInvalidRoomAmenityType(code int) *Error {
-	return newErrorf("invalid value for attribute RoomAmenityCode %d", code)
+	return newErrorf(IDInvalidRoomAmenityType, CodeInvalidValue, "invalid value for attribute RoomAmenityCode %d", code)
 }
 
 func ErrInvalidPictureCategoryCode(code int) *Error {
-	return newErrorf("invalid value for attribute Category %d", code)
+	return newErrorf(IDInvalidPictureCategoryCode, CodeInvalidValue, "invalid value for attribute Category %d", code)
+}
+
+func ErrInvalidVideoCategoryCode(code int) *Error {
+	return newErrorf(IDInvalidVideoCategoryCode, CodeInvalidValue, "invalid value for attribute Category %d", code)
+}
+
+func ErrDuplicateCommentName(name string) *Error {
+	return newErrorf(IDDuplicateCommentName, CodeInvalidValue, "duplicate element Comment with attribute Name %s", name)
 }
 
 func ErrInvalidUniqueID(status string, uidType int) *Error {
-	return newErrorf("invalid value for attributes ResStatus %s and Type %d", status, uidType)
+	return newErrorf(IDInvalidUniqueID, CodeInvalidValue, "invalid value for attributes ResStatus %s and Type %d", status, uidType)
 }
 
 func ErrRatePlanNotFound(code string) *Error {
-	return newErrorf("rate plan not found %s", code)
+	return newErrorf(IDRatePlanNotFound, CodeInvalidRateCode, "rate plan not found %s", code)
 }
 
 func ErrDuplicateMealType(existingRatePlanCode string, mealType int) *Error {
-	return newErrorf("rate plan %s with meal type %d already exists", existingRatePlanCode, mealType)
+	return newErrorf(IDDuplicateMealType, CodeInvalidValue, "rate plan %s with meal type %d already exists", existingRatePlanCode, mealType)
 }
 
 func ErrMinStayArrivalGratherThanMaxStayArrival(min, max int) *Error {
-	return newErrorf("min stay arrival must be ≤ max stay arrival, got %d and %d", min, max)
+	return newErrorf(IDMinStayArrivalGratherThanMaxStayArrival, CodeInvalidValue, "min stay arrival must be ≤ max stay arrival, got %d and %d", min, max)
 }
 
 func ErrMinStayGratherThanMaxStay(min, max int) *Error {
-	return newErrorf("min stay must be ≤ max stay, got %d and %d", min, max)
+	return newErrorf(IDMinStayGratherThanMaxStay, CodeInvalidValue, "min stay must be ≤ max stay, got %d and %d", min, max)
 }
 
 func ErrDuplicateBaseByGuestAmt(numberOfGuests int) *Error {
-	return newErrorf("duplicate element BaseByGuestAmt with attribute NumberOfGuests %d", numberOfGuests)
+	return newErrorf(IDDuplicateBaseByGuestAmt, CodeInvalidValue, "duplicate element BaseByGuestAmt with attribute NumberOfGuests %d", numberOfGuests)
 }
 
 func ErrMissingBaseByGuestAmtWithStdOccupancy(std int) *Error {
-	return newErrorf("missing element BaseByGuestAmt with attribute NumberOfGuests equal to the standard occupancy %d", std)
+	return newErrorf(IDMissingBaseByGuestAmtWithStdOccupancy, CodeInvalidValue, "missing element BaseByGuestAmt with attribute NumberOfGuests equal to the standard occupancy %d", std)
 }
 
 func ErrMinAgeOutOfRange(childMinAge, ratePlanChildMinAge int) *Error {
-	return newErrorf("child min age must be ≥ rate plan child min age, got %d and %d", childMinAge, ratePlanChildMinAge)
+	return newErrorf(IDMinAgeOutOfRange, CodeInvalidValue, "child min age must be ≥ rate plan child min age, got %d and %d", childMinAge, ratePlanChildMinAge)
 }
 
 func ErrMaxAgeOutOfRange(childMaxAge, ratePlanAdultMinAge int) *Error {
-	return newErrorf("child max age must be < rate plan adult min age, got %d and %d", childMaxAge, ratePlanAdultMinAge)
+	return newErrorf(IDMaxAgeOutOfRange, CodeInvalidValue, "child max age must be < rate plan adult min age, got %d and %d", childMaxAge, ratePlanAdultMinAge)
 }
 
 func ErrFamilyOfferMaxAgeTooLow(offerMaxAge, childMinAge int) *Error {
-	return newErrorf("family offer max age must be > child min age, got %d and %d", offerMaxAge, childMinAge)
+	return newErrorf(IDFamilyOfferMaxAgeTooLow, CodeInvalidValue, "family offer max age must be > child min age, got %d and %d", offerMaxAge, childMinAge)
 }
 
 func ErrAgeRangeOverlaps(min1, max1, min2, max2 int) *Error {
-	return newErrorf("age range [%d - %d] overlaps with [%d - %d]", min1, max1, min2, max2)
+	return newErrorf(IDAgeRangeOverlaps, CodeInvalidValue, "age range [%d - %d] overlaps with [%d - %d]", min1, max1, min2, max2)
 }
 
 func ErrInvalidInvType(invType string) *Error {
-	return newErrorf("invalid value for attribute InvType %s", invType)
+	return newErrorf(IDInvalidInvType, CodeInvalidValue, "invalid value for attribute InvType %s", invType)
+}
+
+func newMissingAttributeError(id ErrorID, attribute string) *Error {
+	return newMessageErrorf(id, idMissingAttribute, CodeRequiredFieldMissing, "missing required attribute %s", attribute)
 }
 
-func newMissingAttributeError(attribute string) *Error {
-	return newErrorf("missing required attribute %s", attribute)
+func newMissingElementError(id ErrorID, element string) *Error {
+	return newMessageErrorf(id, idMissingElement, CodeRequiredFieldMissing, "missing required element %s", element)
 }
 
-func newMissingElementError(element string) *Error {
-	return newErrorf("missing required element %s", element)
+func newUnexpectedAttributeError(id ErrorID, attribute string) *Error {
+	return newMessageErrorf(id, idUnexpectedAttribute, CodeInvalidValue, "unexpected attribute found %s", attribute)
 }
 
-func newUnexpectedAttributeError(attribute string) *Error {
-	return newErrorf("unexpected attribute found %s", attribute)
+func newUnexpectedElementError(id ErrorID, element string) *Error {
+	return newMessageErrorf(id, idUnexpectedElement, CodeInvalidValue, "unexpected element found %s", element)
 }
 
-func newUnexpectedElementError(element string) *Error {
-	return newErrorf("unexpected element found %s", element)
+func newErrorf(id ErrorID, code int, format string, a ...any) *Error {
+	return newMessageErrorf(id, id, code, format, a...)
 }
 
-func newErrorf(message string, a ...any) *Error {
-	return newError(fmt.Sprintf(message, a...))
+// newMessageErrorf returns an error whose message is translated with the
+// translations of messageID, which errors sharing a message have in common.
+func newMessageErrorf(id, messageID ErrorID, code int, format string, a ...any) *Error {
+	err := newError(id, code, fmt.Sprintf(format, a...))
+	err.messageID = messageID
+	err.args = a
+	return err
 }
 
-func newError(message string) *Error {
+func newError(id ErrorID, code int, message string) *Error {
 	return &Error{
-		Type:  ErrorWarningTypeApplicationError,
-		Value: message,
+		Type:      ErrorWarningTypeApplicationError,
+		Code:      code,
+		ID:        id,
+		Value:     message,
+		messageID: id,
 	}
 }
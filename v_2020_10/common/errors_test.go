@@ -0,0 +1,34 @@
+package common
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorIs(t *testing.T) {
+	wrapped := fmt.Errorf("storing inventory: %w", ErrInvCodeNotFound("101"))
+	assert.ErrorIs(t, wrapped, IDInvCodeNotFound)
+	assert.ErrorIs(t, wrapped, ErrInvCodeNotFound("102"))
+	assert.NotErrorIs(t, wrapped, IDInvTypeCodeNotFound)
+
+	copied := *ErrMissingHotelCode
+	assert.ErrorIs(t, copied, ErrMissingHotelCode)
+	assert.ErrorIs(t, &copied, ErrorID("missing_hotel_code"))
+	assert.NotErrorIs(t, ErrMissingInvCode, ErrMissingHotelCode)
+
+	assert.False(t, errors.Is(&Error{Value: "custom"}, &Error{Value: "custom"}))
+}
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, CodeRequiredFieldMissing, ErrMissingHotelCode.Code)
+	assert.Equal(t, CodeUnableToProcess, ErrDeltasNotSupported.Code)
+	assert.Equal(t, CodeInvalidRoomType, ErrInvCodeNotFound("101").Code)
+
+	b, err := xml.Marshal(ErrMissingHotelCode)
+	assert.NoError(t, err)
+	assert.Equal(t, `<Error Type="13" Code="321">missing required attribute HotelCode</Error>`, string(b))
+}
@@ -0,0 +1,386 @@
+package common
+
+// messages holds the German and Italian translations of the error messages,
+// keyed by the ID of the message.
+var messages = map[ErrorID]map[string]string{
+	idMissingAttribute: {
+		"de": "Pflichtattribut %s fehlt",
+		"it": "attributo obbligatorio %s mancante",
+	},
+	idMissingElement: {
+		"de": "Pflichtelement %s fehlt",
+		"it": "elemento obbligatorio %s mancante",
+	},
+	idUnexpectedAttribute: {
+		"de": "unerwartetes Attribut %s gefunden",
+		"it": "attributo inatteso %s trovato",
+	},
+	idUnexpectedElement: {
+		"de": "unerwartetes Element %s gefunden",
+		"it": "elemento inatteso %s trovato",
+	},
+	"deltas_not_supported": {
+		"de": "Deltas werden nicht unterstützt",
+		"it": "i delta non sono supportati",
+	},
+	"too_many_requests": {
+		"de": "zu viele Anfragen",
+		"it": "troppe richieste",
+	},
+	"out_of_order_not_supported": {
+		"de": "Out of Order wird nicht unterstützt",
+		"it": "out of order non è supportato",
+	},
+	"out_of_market_not_supported": {
+		"de": "Out of Market wird nicht unterstützt",
+		"it": "out of market non è supportato",
+	},
+	"closing_seasons_not_supported": {
+		"de": "Schließzeiten werden nicht unterstützt",
+		"it": "i periodi di chiusura non sono supportati",
+	},
+	"availabilities_overlap_closing_seasons": {
+		"de": "Verfügbarkeiten überschneiden sich mit Schließzeiten",
+		"it": "le disponibilità si sovrappongono ai periodi di chiusura",
+	},
+	"child_occupancy_not_supported": {
+		"de": "Kinderbelegung wird nicht unterstützt",
+		"it": "l'occupazione bambini non è supportata",
+	},
+	"max_child_occ_greater_than_max_occ": {
+		"de": "Kinderbelegung muss ≤ Maximalbelegung sein",
+		"it": "l'occupazione bambini deve essere ≤ dell'occupazione massima",
+	},
+	"std_occ_lower_than_min_occ": {
+		"de": "Standardbelegung muss ≥ Mindestbelegung sein",
+		"it": "l'occupazione standard deve essere ≥ dell'occupazione minima",
+	},
+	"max_occ_lower_than_std_occ": {
+		"de": "Maximalbelegung muss ≥ Standardbelegung sein",
+		"it": "l'occupazione massima deve essere ≥ dell'occupazione standard",
+	},
+	"duplicate_language": {
+		"de": "doppelte Sprache im Element Description gefunden",
+		"it": "lingua duplicata trovata nell'elemento Description",
+	},
+	"rooms_not_supported": {
+		"de": "Zimmer werden nicht unterstützt",
+		"it": "le camere non sono supportate",
+	},
+	"duplicate_alternative_room_stay": {
+		"de": "höchstens ein alternativer RoomStay ist erlaubt",
+		"it": "è ammesso al massimo un RoomStay alternativo",
+	},
+	"unexpected_alternative_room_stay": {
+		"de": "alternativer RoomStay ist nicht erlaubt",
+		"it": "il RoomStay alternativo non è ammesso",
+	},
+	"invalid_percent": {
+		"de": "Prozentsatz muss ≤ 100 sein",
+		"it": "la percentuale deve essere ≤ 100",
+	},
+	"duplicate_adult_guest_count": {
+		"de": "doppeltes Element GuestCount für Erwachsene",
+		"it": "elemento GuestCount duplicato per adulti",
+	},
+	"start_after_end": {
+		"de": "Beginn muss ≤ Ende sein",
+		"it": "l'inizio deve essere ≤ della fine",
+	},
+	"earliest_date_after_latest_date": {
+		"de": "frühestes Datum muss ≤ spätestes Datum sein",
+		"it": "la data più vicina deve essere ≤ della data più lontana",
+	},
+	"duration_out_of_range": {
+		"de": "Dauer überschreitet den erlaubten Zeitraum",
+		"it": "la durata supera il periodo consentito",
+	},
+	"invalid_name_prefix": {
+		"de": "ungültiger Wert für Attribut NamePrefix",
+		"it": "valore non valido per l'attributo NamePrefix",
+	},
+	"invalid_name_title": {
+		"de": "ungültiger Wert für Attribut NameTitle",
+		"it": "valore non valido per l'attributo NameTitle",
+	},
+	"invalid_address_line": {
+		"de": "ungültiger Wert für Attribut AddressLine",
+		"it": "valore non valido per l'attributo AddressLine",
+	},
+	"invalid_city_name": {
+		"de": "ungültiger Wert für Attribut CityName",
+		"it": "valore non valido per l'attributo CityName",
+	},
+	"invalid_postal_code": {
+		"de": "ungültiger Wert für Attribut PostalCode",
+		"it": "valore non valido per l'attributo PostalCode",
+	},
+	"invalid_country_name_code": {
+		"de": "ungültiger Wert für Attribut CountryName.Code",
+		"it": "valore non valido per l'attributo CountryName.Code",
+	},
+	"invalid_list_item": {
+		"de": "ungültiger Wert für Element ListItem",
+		"it": "valore non valido per l'elemento ListItem",
+	},
+	"invalid_comment_text": {
+		"de": "ungültiger Wert für Element Comment.Text",
+		"it": "valore non valido per l'elemento Comment.Text",
+	},
+	"invalid_penalty_description_text": {
+		"de": "ungültiger Wert für Element PenaltyDescription.Text",
+		"it": "valore non valido per l'elemento PenaltyDescription.Text",
+	},
+	"invalid_res_id_value": {
+		"de": "ungültiger Wert für Attribut ResIDValue",
+		"it": "valore non valido per l'attributo ResIDValue",
+	},
+	"invalid_res_id_source": {
+		"de": "ungültiger Wert für Attribut ResIDSource",
+		"it": "valore non valido per l'attributo ResIDSource",
+	},
+	"invalid_res_id_source_context": {
+		"de": "ungültiger Wert für Attribut ResIDSourceContext",
+		"it": "valore non valido per l'attributo ResIDSourceContext",
+	},
+	"invalid_company_name_code": {
+		"de": "ungültiger Wert für Attribut CompanyName.Code",
+		"it": "valore non valido per l'attributo CompanyName.Code",
+	},
+	"invalid_company_name_value": {
+		"de": "ungültiger Wert für Element CompanyName",
+		"it": "valore non valido per l'elemento CompanyName",
+	},
+	"invalid_email": {
+		"de": "ungültiger Wert für Element Email",
+		"it": "valore non valido per l'elemento Email",
+	},
+	"rate_plan_join_not_supported": {
+		"de": "Rate Plan Join wird nicht unterstützt",
+		"it": "il rate plan join non è supportato",
+	},
+	"offer_rule_booking_offset_not_supported": {
+		"de": "Buchungsvorlauf in OfferRule wird nicht unterstützt",
+		"it": "l'anticipo di prenotazione nell'OfferRule non è supportato",
+	},
+	"offer_rule_dow_los_not_supported": {
+		"de": "Wochentage und Aufenthaltsdauer in OfferRule werden nicht unterstützt",
+		"it": "i giorni della settimana e le durate del soggiorno nell'OfferRule non sono supportati",
+	},
+	"stay_through_not_allowed_in_offer_rule": {
+		"de": "ungültiger Wert für Attribut MinMaxMessageType im Element OfferRule",
+		"it": "valore non valido per l'attributo MinMaxMessageType nell'elemento OfferRule",
+	},
+	"invalid_min_occupancy": {
+		"de": "Mindestbelegung muss ≤ 99 sein",
+		"it": "l'occupazione minima deve essere ≤ 99",
+	},
+	"invalid_max_occupancy": {
+		"de": "Maximalbelegung muss ≤ 99 sein",
+		"it": "l'occupazione massima deve essere ≤ 99",
+	},
+	"duplicate_child_occupancy": {
+		"de": "doppeltes Element Occupancy mit Attribut AgeQualifyingCode = 8",
+		"it": "elemento Occupancy duplicato con attributo AgeQualifyingCode = 8",
+	},
+	"duplicate_free_night_offer": {
+		"de": "doppeltes Freinächte-Angebot",
+		"it": "offerta notti gratuite duplicata",
+	},
+	"duplicate_family_offer": {
+		"de": "doppeltes Familienangebot",
+		"it": "offerta famiglia duplicata",
+	},
+	"free_night_offer_not_supported": {
+		"de": "Freinächte-Angebote werden nicht unterstützt",
+		"it": "le offerte notti gratuite non sono supportate",
+	},
+	"invalid_discount_pattern": {
+		"de": "ungültiger Wert für Attribut DiscountPattern",
+		"it": "valore non valido per l'attributo DiscountPattern",
+	},
+	"family_offer_not_supported": {
+		"de": "Freinächte-Angebote werden nicht unterstützt",
+		"it": "le offerte notti gratuite non sono supportate",
+	},
+	"invalid_guest_age_qualifying_code": {
+		"de": "ungültiger Wert für Attribut Guest.AgeQualifyingCode",
+		"it": "valore non valido per l'attributo Guest.AgeQualifyingCode",
+	},
+	"room_type_booking_rules_not_supported": {
+		"de": "Buchungsregeln für Zimmertypen werden nicht unterstützt",
+		"it": "le regole di prenotazione per tipologia di camera non sono supportate",
+	},
+	"arrival_dow_not_supported": {
+		"de": "Anreisewochentage werden nicht unterstützt",
+		"it": "i giorni di arrivo non sono supportati",
+	},
+	"departure_dow_not_supported": {
+		"de": "Abreisewochentage werden nicht unterstützt",
+		"it": "i giorni di partenza non sono supportati",
+	},
+	"invalid_rate_time_unit": {
+		"de": "ungültiger Wert für Attribut RateTimeUnit",
+		"it": "valore non valido per l'attributo RateTimeUnit",
+	},
+	"duplicate_additional_guest_amount_adult": {
+		"de": "doppeltes Element AdditionalGuestAmount mit Attribut AgeQualifyingCode = 10",
+		"it": "elemento AdditionalGuestAmount duplicato con attributo AgeQualifyingCode = 10",
+	},
+	"children_not_allowed": {
+		"de": "Kinder sind nicht erlaubt",
+		"it": "i bambini non sono ammessi",
+	},
+	"min_age_greater_than_or_equals_than_max_age": {
+		"de": "Attribut MinAge muss < Attribut MaxAge sein",
+		"it": "l'attributo MinAge deve essere < dell'attributo MaxAge",
+	},
+	"supplements_not_supported": {
+		"de": "Zuschläge werden nicht unterstützt",
+		"it": "i supplementi non sono supportati",
+	},
+	"invalid_dow_string": {
+		"de": "ungültiger Wert für Attribut InvCode mit Attribut InvType = ALPINEBITSDOW",
+		"it": "valore non valido per l'attributo InvCode con attributo InvType = ALPINEBITSDOW",
+	},
+	"unexpected_base_by_guest_amt": {
+		"de": "statische Preise dürfen nur ein Element BaseByGuestAmt enthalten",
+		"it": "le tariffe statiche possono contenere un solo elemento BaseByGuestAmt",
+	},
+	"charge_type_mismatch": {
+		"de": "ChargeType des abgeleiteten Rate Plans muss dem des Master-Rate-Plans entsprechen",
+		"it": "il ChargeType del rate plan derivato deve corrispondere a quello del rate plan master",
+	},
+	"invalid_latitude": {
+		"de": "Breitengrad muss ≥ -90 und ≤ 90 sein",
+		"it": "la latitudine deve essere ≥ -90 e ≤ 90",
+	},
+	"invalid_longitude": {
+		"de": "Längengrad muss ≥ -180 und ≤ 180 sein",
+		"it": "la longitudine deve essere ≥ -180 e ≤ 180",
+	},
+	"invalid_phone_number": {
+		"de": "ungültiger Wert für Attribut PhoneNumber",
+		"it": "valore non valido per l'attributo PhoneNumber",
+	},
+	"invalid_url": {
+		"de": "ungültiger Wert für Element URL",
+		"it": "valore non valido per l'elemento URL",
+	},
+	"duplicate_stay_context": {
+		"de": "doppeltes Element StayRequirement mit demselben Attribut StayContext",
+		"it": "elemento StayRequirement duplicato con lo stesso attributo StayContext",
+	},
+	"invalid_event_id_type": {
+		"de": "ungültiger Wert für Attribut Event_ID.Type",
+		"it": "valore non valido per l'attributo Event_ID.Type",
+	},
+	"pre_registered_quantity_greater_than_total_quantity": {
+		"de": "vorangemeldete Anzahl muss ≤ Gesamtanzahl sein",
+		"it": "la quantità preregistrata deve essere ≤ della quantità totale",
+	},
+	"invalid_start": {
+		"de": "ungültiger Wert für Attribut Start",
+		"it": "valore non valido per l'attributo Start",
+	},
+	"invalid_end": {
+		"de": "ungültiger Wert für Attribut End",
+		"it": "valore non valido per l'attributo End",
+	},
+	"invalid_latest_date": {
+		"de": "ungültiger Wert für Attribut LatestDate",
+		"it": "valore non valido per l'attributo LatestDate",
+	},
+	IDInvCodeNotFound: {
+		"de": "InvCode %s nicht gefunden",
+		"it": "InvCode %s non trovato",
+	},
+	IDInvTypeCodeNotFound: {
+		"de": "InvTypeCode %s nicht gefunden",
+		"it": "InvTypeCode %s non trovato",
+	},
+	IDInvalidInvCounts: {
+		"de": "ungültiger Wert für Element InvCounts, erwartet ein Element InvCount, erhalten %d",
+		"it": "valore non valido per l'elemento InvCounts, atteso un elemento InvCount, ricevuti %d",
+	},
+	IDInvalidCount: {
+		"de": "Anzahl muss 1 sein, erhalten %d",
+		"it": "il conteggio deve essere 1, ricevuto %d",
+	},
+	IDDateRangeOverlaps: {
+		"de": "Zeitraum [%s - %s] überschneidet sich mit [%s - %s]",
+		"it": "il periodo [%s - %s] si sovrappone a [%s - %s]",
+	},
+	IDInvalidRoomClassificationCode: {
+		"de": "ungültiger Wert für Attribut RoomClassificationCode %d",
+		"it": "valore non valido per l'attributo RoomClassificationCode %d",
+	},
+	IDInvalidRoomType: {
+		"de": "ungültiger Wert für Attribut RoomType %d",
+		"it": "valore non valido per l'attributo RoomType %d",
+	},
+	IDInvalidRoomAmenityType: {
+		"de": "ungültiger Wert für Attribut RoomAmenityCode %d",
+		"it": "valore non valido per l'attributo RoomAmenityCode %d",
+	},
+	IDInvalidPictureCategoryCode: {
+		"de": "ungültiger Wert für Attribut Category %d",
+		"it": "valore non valido per l'attributo Category %d",
+	},
+	IDInvalidVideoCategoryCode: {
+		"de": "ungültiger Wert für Attribut Category %d",
+		"it": "valore non valido per l'attributo Category %d",
+	},
+	IDDuplicateCommentName: {
+		"de": "doppeltes Element Comment mit Attribut Name %s",
+		"it": "elemento Comment duplicato con attributo Name %s",
+	},
+	IDInvalidUniqueID: {
+		"de": "ungültige Werte für Attribute ResStatus %s und Type %d",
+		"it": "valori non validi per gli attributi ResStatus %s e Type %d",
+	},
+	IDRatePlanNotFound: {
+		"de": "Rate Plan %s nicht gefunden",
+		"it": "rate plan %s non trovato",
+	},
+	IDDuplicateMealType: {
+		"de": "Rate Plan %s mit Verpflegungsart %d existiert bereits",
+		"it": "il rate plan %s con trattamento %d esiste già",
+	},
+	IDMinStayArrivalGratherThanMaxStayArrival: {
+		"de": "Mindestaufenthalt bei Anreise muss ≤ Höchstaufenthalt bei Anreise sein, erhalten %d und %d",
+		"it": "il soggiorno minimo all'arrivo deve essere ≤ del soggiorno massimo all'arrivo, ricevuti %d e %d",
+	},
+	IDMinStayGratherThanMaxStay: {
+		"de": "Mindestaufenthalt muss ≤ Höchstaufenthalt sein, erhalten %d und %d",
+		"it": "il soggiorno minimo deve essere ≤ del soggiorno massimo, ricevuti %d e %d",
+	},
+	IDDuplicateBaseByGuestAmt: {
+		"de": "doppeltes Element BaseByGuestAmt mit Attribut NumberOfGuests %d",
+		"it": "elemento BaseByGuestAmt duplicato con attributo NumberOfGuests %d",
+	},
+	IDMissingBaseByGuestAmtWithStdOccupancy: {
+		"de": "Element BaseByGuestAmt mit Attribut NumberOfGuests gleich der Standardbelegung %d fehlt",
+		"it": "elemento BaseByGuestAmt con attributo NumberOfGuests pari all'occupazione standard %d mancante",
+	},
+	IDMinAgeOutOfRange: {
+		"de": "Mindestalter des Kindes muss ≥ Mindestalter für Kinder des Rate Plans sein, erhalten %d und %d",
+		"it": "l'età minima del bambino deve essere ≥ dell'età minima bambini del rate plan, ricevuti %d e %d",
+	},
+	IDMaxAgeOutOfRange: {
+		"de": "Höchstalter des Kindes muss < Mindestalter für Erwachsene des Rate Plans sein, erhalten %d und %d",
+		"it": "l'età massima del bambino deve essere < dell'età minima adulti del rate plan, ricevuti %d e %d",
+	},
+	IDFamilyOfferMaxAgeTooLow: {
+		"de": "Höchstalter des Familienangebots muss > Mindestalter für Kinder sein, erhalten %d und %d",
+		"it": "l'età massima dell'offerta famiglia deve essere > dell'età minima bambini, ricevuti %d e %d",
+	},
+	IDAgeRangeOverlaps: {
+		"de": "Altersbereich [%d - %d] überschneidet sich mit [%d - %d]",
+		"it": "la fascia d'età [%d - %d] si sovrappone a [%d - %d]",
+	},
+	IDInvalidInvType: {
+		"de": "ungültiger Wert für Attribut InvType %s",
+		"it": "valore non valido per l'attributo InvType %s",
+	},
+}
@@ -1,5 +1,7 @@
 package common
 
+import "strings"
+
 type ErrorWarningType int
 
 const (
@@ -18,23 +20,69 @@ const (
 type Success struct{}
 
 type Warning struct {
-	Type   ErrorWarningType `xml:"Type,attr"`
-	Code   int              `xml:"Code,attr,omitempty"`
-	Status Status           `xml:"Status,attr,omitempty"`
-	Value  string           `xml:",innerxml"`
+	Type     ErrorWarningType `xml:"Type,attr"`
+	Code     int              `xml:"Code,attr,omitempty"`
+	RecordID string           `xml:"RecordID,attr,omitempty"`
+	Status   Status           `xml:"Status,attr,omitempty"`
+	Value    string           `xml:",innerxml"`
 }
 
 type Error struct {
 	Type   ErrorWarningType `xml:"Type,attr"`
 	Code   int              `xml:"Code,attr,omitempty"`
 	Status Status           `xml:"Status,attr,omitempty"`
+	ID     ErrorID          `xml:"-"`
 	Value  string           `xml:",innerxml"`
+
+	// messageID selects the translations of the message, see Localize, and
+	// args are the values formatted into it.
+	messageID ErrorID
+	args      []any
 }
 
 func (err Error) Error() string {
 	return err.Value
 }
 
+// Is reports whether target is the ID of err or an error with the same ID, so
+// that errors.Is matches copies of the sentinels and errors created by
+// functions such as ErrInvCodeNotFound.
+func (err Error) Is(target error) bool {
+	if err.ID == "" {
+		return false
+	}
+	switch t := target.(type) {
+	case ErrorID:
+		return err.ID == t
+	case *Error:
+		return err.ID == t.ID
+	case Error:
+		return err.ID == t.ID
+	}
+	return false
+}
+
+// ErrorID is the symbolic identifier of an error, stable across releases and
+// independent of its message.
+type ErrorID string
+
+func (id ErrorID) Error() string {
+	return string(id)
+}
+
+// NewWarning returns an advisory warning. Handlers can return it as an error
+// to answer with a successful response carrying the warning.
+func NewWarning(message string) *Warning {
+	return &Warning{
+		Type:  ErrorWarningTypeAdvisory,
+		Value: message,
+	}
+}
+
+func (w Warning) Error() string {
+	return w.Value
+}
+
 type Response struct {
 	Success  *Success   `xml:"Success"`
 	Warnings *[]Warning `xml:"Warnings>Warning"`
@@ -45,16 +93,24 @@ func (r *Response) SetSuccess() {
 	r.Success = &Success{}
 }
 
+// textEscaper escapes the messages of errors and warnings, which end up as
+// character data of the elements but may contain values of the request.
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// AppendWarning appends w with its message escaped.
 func (r *Response) AppendWarning(w Warning) {
 	if r.Warnings == nil {
 		r.Warnings = &[]Warning{}
 	}
+	w.Value = textEscaper.Replace(w.Value)
 	*r.Warnings = append(*r.Warnings, w)
 }
 
+// AppendError appends e with its message escaped.
 func (r *Response) AppendError(e Error) {
 	if r.Errors == nil {
 		r.Errors = &[]Error{}
 	}
+	e.Value = textEscaper.Replace(e.Value)
 	*r.Errors = append(*r.Errors, e)
 }
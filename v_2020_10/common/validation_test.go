@@ -1,6 +1,8 @@
 package common
 
 import (
+	"encoding/xml"
+	"errors"
 	"testing"
 	"time"
 
@@ -93,3 +95,77 @@ func TestValidateOverlaps(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAll(t *testing.T) {
+	fn := func(p Path) {
+		p = p.Elem("Inventories")
+		p.Attr("HotelCode").Add(ErrMissingHotelCode)
+		for i, code := range []string{"DZ", "", "<EZ>"} {
+			p := p.Index("Inventory", i).Elem("StatusApplicationControl")
+			switch code {
+			case "":
+				p.Attr("InvTypeCode").Add(ErrMissingInvTypeCode)
+			case "<EZ>":
+				p.Attr("InvTypeCode").AddValue(ErrInvTypeCodeNotFound(code), code)
+			}
+		}
+	}
+
+	assert.Equal(t, ErrMissingHotelCode, Validate(fn))
+
+	err := ValidateAll(fn)
+	assert.ErrorIs(t, err, ErrMissingHotelCode)
+	assert.ErrorIs(t, err, ErrMissingInvTypeCode)
+	assert.Equal(t, ValidationErrors{
+		{Path: "Inventories/@HotelCode", Err: ErrMissingHotelCode},
+		{Path: "Inventories/Inventory[2]/StatusApplicationControl/@InvTypeCode", Err: ErrMissingInvTypeCode},
+		{Path: "Inventories/Inventory[3]/StatusApplicationControl/@InvTypeCode", Value: "<EZ>", Err: ErrInvTypeCodeNotFound("<EZ>")},
+	}, err)
+	assert.Equal(t, `Inventories/Inventory[3]/StatusApplicationControl/@InvTypeCode: `+
+		ErrInvTypeCodeNotFound("<EZ>").Error()+` (value "<EZ>")`, err.(ValidationErrors)[2].Error())
+
+	assert.NoError(t, Validate(func(p Path) { p.Add(nil) }))
+	assert.NoError(t, ValidateAll(func(p Path) { p.Add(nil) }))
+}
+
+func TestValidateStopsAtFirstError(t *testing.T) {
+	var walked bool
+	err := Validate(func(p Path) {
+		p.Add(ErrMissingHotelCode)
+		walked = true
+	})
+	assert.Equal(t, ErrMissingHotelCode, err)
+	assert.False(t, walked)
+
+	assert.PanicsWithValue(t, "other", func() {
+		_ = Validate(func(p Path) { panic("other") })
+	})
+}
+
+func TestResponse_AppendValidationErrors(t *testing.T) {
+	var resp Response
+	resp.AppendValidationErrors(ValidationErrors{
+		{Path: "Inventories/@HotelCode", Err: ErrMissingHotelCode},
+		{Path: "Inventories/Inventory[1]", Value: "<x>", Err: errors.New("invalid")},
+	})
+
+	assert.Equal(t, &[]Error{
+		{Type: ErrorWarningTypeApplicationError, Code: CodeRequiredFieldMissing, ID: "missing_hotel_code", Value: "Inventories/@HotelCode: " + ErrMissingHotelCode.Error()},
+		{Type: ErrorWarningTypeApplicationError, Code: CodeInvalidValue, Value: `Inventories/Inventory[1]: invalid (value "&lt;x&gt;")`},
+	}, resp.Errors)
+}
+
+func TestResponse_AppendError(t *testing.T) {
+	var resp Response
+	resp.AppendError(*ErrInvTypeCodeNotFound("A&B<x"))
+	resp.AppendWarning(*NewWarning("room <DZ> & more"))
+
+	b, err := xml.Marshal(resp)
+	assert.NoError(t, err)
+
+	var rs Response
+	assert.NoError(t, xml.Unmarshal(b, &rs))
+	assert.Equal(t, "inv type code not found A&amp;B&lt;x", (*rs.Errors)[0].Value)
+	assert.Equal(t, "room &lt;DZ&gt; &amp; more", (*rs.Warnings)[0].Value)
+	assert.Equal(t, "inv type code not found A&B<x", ErrInvTypeCodeNotFound("A&B<x").Error())
+}
@@ -38,6 +38,15 @@ func (h HotelInvCountNotifRQ) HotelCode() string {
 	return h.Inventories.HotelCode
 }
 
+var _ version.UniqueIDProvider = (*HotelInvCountNotifRQ)(nil)
+
+func (h HotelInvCountNotifRQ) UniqueIDValue() string {
+	if h.UniqueID == nil {
+		return ""
+	}
+	return h.UniqueID.ID
+}
+
 type Inventories struct {
 	HotelCode   string      `xml:"HotelCode,attr"`
 	HotelName   string      `xml:"HotelName,attr"`
@@ -65,11 +74,11 @@ func (i Inventory) DateRange() timex.DateRange {
 }
 
 func (i Inventory) isAvailability() bool {
-	return !i.StatusApplicationControl.AllInvCode
+	return i.StatusApplicationControl != nil && !i.StatusApplicationControl.AllInvCode
 }
 
 func (i Inventory) isClosingSeason() bool {
-	return i.StatusApplicationControl.AllInvCode
+	return i.StatusApplicationControl != nil && i.StatusApplicationControl.AllInvCode
 }
 
 type StatusApplicationControl struct {
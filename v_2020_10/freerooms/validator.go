@@ -1,7 +1,6 @@
 package freerooms
 
 import (
-	"errors"
 	"slices"
 	"strings"
 
@@ -21,6 +20,7 @@ type HotelInvCountNotifValidator struct {
 }
 
 var _ common.Validatable[HotelInvCountNotifRQ] = (*HotelInvCountNotifValidator)(nil)
+var _ common.ValidatableAll[HotelInvCountNotifRQ] = (*HotelInvCountNotifValidator)(nil)
 
 type HotelInvCountNotifValidatorFunc func(*HotelInvCountNotifValidator)
 
@@ -81,173 +81,146 @@ func WithClosingSeasons() HotelInvCountNotifValidatorFunc {
 }
 
 func (v HotelInvCountNotifValidator) Validate(r HotelInvCountNotifRQ) error {
-	if err := common.ValidateHotelCode(r.Inventories.HotelCode); err != nil {
-		return err
-	}
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
 
-	if err := v.validateUniqueID(r.UniqueID); err != nil {
-		return err
-	}
+func (v HotelInvCountNotifValidator) ValidateAll(r HotelInvCountNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
 
-	if r.Inventories.IsReset() {
-		return nil
-	}
+func (v HotelInvCountNotifValidator) validate(p common.Path, r HotelInvCountNotifRQ) {
+	p.Elem("Inventories").Attr("HotelCode").Add(common.ValidateHotelCode(r.Inventories.HotelCode))
 
-	if err := v.validateInventories(r.Inventories.Inventories); err != nil {
-		return err
+	v.validateUniqueID(p.Elem("UniqueID"), r.UniqueID)
+
+	if r.Inventories.IsReset() {
+		return
 	}
 
-	return nil
+	v.validateInventories(p.Elem("Inventories"), r.Inventories.Inventories)
 }
 
-func (v HotelInvCountNotifValidator) validateUniqueID(uid *UniqueID) error {
+func (v HotelInvCountNotifValidator) validateUniqueID(p common.Path, uid *UniqueID) {
 	if uid == nil && !v.supportsDeltas {
-		return common.ErrDeltasNotSupported
+		p.Add(common.ErrDeltasNotSupported)
 	}
-	return nil
 }
 
-func (v HotelInvCountNotifValidator) validateInventories(invs []Inventory) error {
-	avails := slicesx.Filter(invs, Inventory.isAvailability)
-	if err := v.validateAvailabilities(avails); err != nil {
-		return err
+func (v HotelInvCountNotifValidator) validateInventories(p common.Path, invs []Inventory) {
+	for i, inv := range invs {
+		if inv.StatusApplicationControl == nil {
+			p.Index("Inventory", i).Elem("StatusApplicationControl").Add(common.ErrMissingStatusApplicationControl)
+		}
 	}
 
+	avails := slicesx.Filter(invs, Inventory.isAvailability)
+	v.validateAvailabilities(p, invs, avails)
+
 	closingSeasons := slicesx.Filter(invs, Inventory.isClosingSeason)
-	if err := v.validateClosingSeasons(closingSeasons); err != nil {
-		return err
-	}
+	v.validateClosingSeasons(p, invs, closingSeasons)
 
 	if v.supportsClosingSeasons {
-		if err := v.validateClosingSeasonsOverlapBookableAvailabilities(avails, closingSeasons); err != nil {
-			return err
-		}
+		v.validateClosingSeasonsOverlapBookableAvailabilities(p, avails, closingSeasons)
 	}
-
-	return nil
 }
 
-func (v HotelInvCountNotifValidator) validateAvailabilities(avails []Inventory) error {
-	for _, avail := range avails {
-		if err := v.validateAvailability(avail); err != nil {
-			return err
+func (v HotelInvCountNotifValidator) validateAvailabilities(p common.Path, invs, avails []Inventory) {
+	for i, inv := range invs {
+		if inv.isAvailability() {
+			v.validateAvailability(p.Index("Inventory", i), inv)
 		}
 	}
 
-	if err := v.validateAvailabilitiesOverlap(avails); err != nil {
-		return err
-	}
-
-	return nil
+	v.validateAvailabilitiesOverlap(p, avails)
 }
 
-func (v HotelInvCountNotifValidator) validateAvailability(avail Inventory) error {
-	if err := v.validateStatusApplicationControl(avail.StatusApplicationControl); err != nil {
-		return err
-	}
-
-	if err := v.validateInvCounts(avail.InvCounts); err != nil {
-		return err
-	}
-
-	return nil
+func (v HotelInvCountNotifValidator) validateAvailability(p common.Path, avail Inventory) {
+	v.validateStatusApplicationControl(p.Elem("StatusApplicationControl"), avail.StatusApplicationControl)
+	v.validateInvCounts(p.Elem("InvCounts"), avail.InvCounts)
 }
 
-func (v HotelInvCountNotifValidator) validateStatusApplicationControl(s *StatusApplicationControl) error {
-	if s == nil {
-		return errors.New("")
-	}
-
+func (v HotelInvCountNotifValidator) validateStatusApplicationControl(p common.Path, s *StatusApplicationControl) {
 	if strings.TrimSpace(s.InvTypeCode) == "" {
-		return common.ErrMissingInvTypeCode
+		p.Attr("InvTypeCode").Add(common.ErrMissingInvTypeCode)
+		return
 	}
 
 	if v.supportsRooms {
 		if strings.TrimSpace(s.InvCode) == "" {
-			return common.ErrMissingInvCode
+			p.Attr("InvCode").Add(common.ErrMissingInvCode)
+			return
 		}
 		if v.roomMapping != nil {
 			if _, ok := (*v.roomMapping)[s.InvTypeCode][s.InvCode]; !ok {
-				return common.ErrInvCodeNotFound(s.InvCode)
+				p.Attr("InvCode").AddValue(common.ErrInvCodeNotFound(s.InvCode), s.InvCode)
 			}
 		}
 	} else if v.supportsCategories {
 		if v.categoriesMapping != nil {
 			if _, ok := (*v.categoriesMapping)[s.InvTypeCode]; !ok {
-				return common.ErrInvTypeCodeNotFound(s.InvTypeCode)
+				p.Attr("InvTypeCode").AddValue(common.ErrInvTypeCodeNotFound(s.InvTypeCode), s.InvTypeCode)
 			}
 		}
 	}
-
-	return nil
 }
 
-func (v HotelInvCountNotifValidator) validateInvCounts(invCounts *[]InvCount) error {
+func (v HotelInvCountNotifValidator) validateInvCounts(p common.Path, invCounts *[]InvCount) {
 	if invCounts == nil {
-		return nil
+		return
 	}
 
 	if n := len(*invCounts); v.supportsRooms && n > 1 {
-		return common.ErrInvalidInvCounts(n)
+		p.AddValue(common.ErrInvalidInvCounts(n), n)
 	}
 
-	for _, invCount := range *invCounts {
+	for i, invCount := range *invCounts {
+		p := p.Index("InvCount", i)
 		if v.supportsRooms && invCount.Count > 1 {
-			return common.ErrInvalidCount(invCount.Count)
+			p.Attr("Count").AddValue(common.ErrInvalidCount(invCount.Count), invCount.Count)
 		}
 
 		switch ct := invCount.CountType; ct {
 		case CountTypeBookable:
 		case CountTypeOutOfOrder:
 			if !v.supportsOutOfOrder {
-				return common.ErrOutOfOrderNotSupported
+				p.Attr("CountType").AddValue(common.ErrOutOfOrderNotSupported, ct)
 			}
 		case CountTypeFree:
 			if !v.supportsOutOfMarket {
-				return common.ErrOutOfMarketNotSupported
+				p.Attr("CountType").AddValue(common.ErrOutOfMarketNotSupported, ct)
 			}
 		}
 	}
-
-	return nil
 }
 
-func (v HotelInvCountNotifValidator) validateAvailabilitiesOverlap(invs []Inventory) error {
+func (v HotelInvCountNotifValidator) validateAvailabilitiesOverlap(p common.Path, invs []Inventory) {
 	availsBy := v.groupAvailabilitiesByCapability(invs)
 	for _, avails := range availsBy {
-		if err := common.ValidateOverlaps(avails); err != nil {
-			return err
-		}
+		p.Add(common.ValidateOverlaps(avails))
 	}
-	return nil
 }
 
-func (v HotelInvCountNotifValidator) validateClosingSeasons(closingSeasons []Inventory) error {
+func (v HotelInvCountNotifValidator) validateClosingSeasons(p common.Path, invs, closingSeasons []Inventory) {
 	if !v.supportsClosingSeasons && len(closingSeasons) > 0 {
-		return common.ErrClosingSeasonsNotSupported
+		p.Add(common.ErrClosingSeasonsNotSupported)
 	}
 
-	for _, closingSeason := range closingSeasons {
-		if err := v.validateClosingSeason(closingSeason); err != nil {
-			return err
+	for i, inv := range invs {
+		if inv.isClosingSeason() {
+			v.validateClosingSeason(p.Index("Inventory", i), inv)
 		}
 	}
 
-	if err := common.ValidateOverlaps(closingSeasons); err != nil {
-		return err
-	}
-
-	return nil
+	p.Add(common.ValidateOverlaps(closingSeasons))
 }
 
-func (v HotelInvCountNotifValidator) validateClosingSeason(closingSeason Inventory) error {
+func (v HotelInvCountNotifValidator) validateClosingSeason(p common.Path, closingSeason Inventory) {
 	if closingSeason.InvCounts != nil {
-		return common.ErrUnexpectedInvCounts
+		p.Elem("InvCounts").Add(common.ErrUnexpectedInvCounts)
 	}
-	return nil
 }
 
-func (v HotelInvCountNotifValidator) validateClosingSeasonsOverlapBookableAvailabilities(avails []Inventory, closingSeasons []Inventory) error {
+func (v HotelInvCountNotifValidator) validateClosingSeasonsOverlapBookableAvailabilities(p common.Path, avails []Inventory, closingSeasons []Inventory) {
 	bookableAvails := slicesx.Filter(avails, func(a Inventory) bool {
 		return a.InvCounts != nil && slices.ContainsFunc(*a.InvCounts, func(i InvCount) bool {
 			return i.CountType == CountTypeBookable
@@ -257,11 +230,9 @@ func (v HotelInvCountNotifValidator) validateClosingSeasonsOverlapBookableAvaila
 	bookableAvailsBy := v.groupAvailabilitiesByCapability(bookableAvails)
 	for _, avails := range bookableAvailsBy {
 		if err := common.ValidateOverlaps(append(avails, closingSeasons...)); err != nil {
-			return common.ErrAvailabilitiesOverlapClosingSeasons
+			p.Add(common.ErrAvailabilitiesOverlapClosingSeasons)
 		}
 	}
-
-	return nil
 }
 
 func (v HotelInvCountNotifValidator) groupAvailabilitiesByCapability(avails []Inventory) map[string][]Inventory {
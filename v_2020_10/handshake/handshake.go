@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 
 	"github.com/HGV/alpinebits/v_2020_10/common"
+	"github.com/HGV/alpinebits/version"
 )
 
 type PingRQ struct {
@@ -12,6 +13,12 @@ type PingRQ struct {
 	EchoData EchoData `xml:"EchoData"`
 }
 
+var _ version.EchoDataProvider = (*PingRQ)(nil)
+
+func (p PingRQ) EchoDataValue() string {
+	return p.EchoData.Value
+}
+
 type EchoData struct {
 	Value string `xml:",innerxml"`
 }
@@ -4,7 +4,6 @@ import (
 	"strings"
 
 	"github.com/HGV/alpinebits/v_2020_10/common"
-	"github.com/HGV/x/slicesx"
 )
 
 type HotelDescriptiveContentNotifValidator struct {
@@ -13,6 +12,7 @@ type HotelDescriptiveContentNotifValidator struct {
 }
 
 var _ common.Validatable[HotelDescriptiveContentNotifRQ] = (*HotelDescriptiveContentNotifValidator)(nil)
+var _ common.ValidatableAll[HotelDescriptiveContentNotifRQ] = (*HotelDescriptiveContentNotifValidator)(nil)
 
 type HotelDescriptiveContentNotifValidatorFunc func(*HotelDescriptiveContentNotifValidator)
 
@@ -37,87 +37,77 @@ func WithOccupancyChildren() HotelDescriptiveContentNotifValidatorFunc {
 }
 
 func (v HotelDescriptiveContentNotifValidator) Validate(r HotelDescriptiveContentNotifRQ) error {
-	if err := common.ValidateHotelCode(r.HotelDescriptiveContent.HotelCode); err != nil {
-		return err
-	}
-
-	if err := v.validateGuestRooms(r.HotelDescriptiveContent.GuestRooms); err != nil {
-		return err
-	}
-
-	return nil
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
 }
 
-func (v HotelDescriptiveContentNotifValidator) validateGuestRooms(guestRooms []GuestRoom) error {
-	guestRoomsByCode := slicesx.GroupByFunc(guestRooms, func(g GuestRoom) string {
-		return g.Code
-	})
-	for _, guestRooms := range guestRoomsByCode {
-		if err := v.validateGuestRoom(guestRooms); err != nil {
-			return err
-		}
-	}
-	return nil
+func (v HotelDescriptiveContentNotifValidator) ValidateAll(r HotelDescriptiveContentNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
 }
 
-func (v HotelDescriptiveContentNotifValidator) validateGuestRoom(guestRooms []GuestRoom) error {
-	headGuestRoom := guestRooms[0]
-	if strings.TrimSpace(headGuestRoom.Code) == "" {
-		return common.ErrMissingCode
-	}
+func (v HotelDescriptiveContentNotifValidator) validate(p common.Path, r HotelDescriptiveContentNotifRQ) {
+	p = p.Elem("HotelDescriptiveContents").Elem("HotelDescriptiveContent")
+	p.Attr("HotelCode").Add(common.ValidateHotelCode(r.HotelDescriptiveContent.HotelCode))
 
-	if err := v.validateOccupancies(headGuestRoom); err != nil {
-		return err
-	}
-
-	if err := v.validateTypeRoom(headGuestRoom.TypeRoom); err != nil {
-		return err
-	}
+	v.validateGuestRooms(p.Elem("FacilityInfo").Elem("GuestRooms"), r.HotelDescriptiveContent.GuestRooms)
+}
 
-	if err := v.validateAmenities(headGuestRoom.Amenities); err != nil {
-		return err
+func (v HotelDescriptiveContentNotifValidator) validateGuestRooms(p common.Path, guestRooms []GuestRoom) {
+	var codes []string
+	indexesByCode := make(map[string][]int)
+	for i, guestRoom := range guestRooms {
+		if _, ok := indexesByCode[guestRoom.Code]; !ok {
+			codes = append(codes, guestRoom.Code)
+		}
+		indexesByCode[guestRoom.Code] = append(indexesByCode[guestRoom.Code], i)
 	}
-
-	if err := v.validateMultimediaDescriptions(headGuestRoom.MultimediaDescriptions); err != nil {
-		return err
+	for _, code := range codes {
+		v.validateGuestRoom(p, guestRooms, indexesByCode[code])
 	}
+}
 
-	tailGuestRooms := guestRooms[1:]
-	if err := v.validateRooms(tailGuestRooms); err != nil {
-		return err
+// validateGuestRoom validates the guest rooms at indexes, which share the same
+// code. The first one describes the room category, the others its rooms.
+func (v HotelDescriptiveContentNotifValidator) validateGuestRoom(p common.Path, guestRooms []GuestRoom, indexes []int) {
+	headGuestRoom := guestRooms[indexes[0]]
+	head := p.Index("GuestRoom", indexes[0])
+	if strings.TrimSpace(headGuestRoom.Code) == "" {
+		head.Attr("Code").Add(common.ErrMissingCode)
 	}
 
-	return nil
+	v.validateOccupancies(head, headGuestRoom)
+	v.validateTypeRoom(head.Elem("TypeRoom"), headGuestRoom.TypeRoom)
+	v.validateAmenities(head.Elem("Amenities"), headGuestRoom.Amenities)
+	v.validateMultimediaDescriptions(head.Elem("MultimediaDescriptions"), headGuestRoom.MultimediaDescriptions)
+	v.validateRooms(p, guestRooms, indexes[1:])
 }
 
-func (v HotelDescriptiveContentNotifValidator) validateOccupancies(guestRoom GuestRoom) error {
+func (v HotelDescriptiveContentNotifValidator) validateOccupancies(p common.Path, guestRoom GuestRoom) {
 	min := guestRoom.MinOccupancy
 	std := guestRoom.TypeRoom.StandardOccupancy
 	max := guestRoom.MaxOccupancy
 	mco := guestRoom.MaxChildOccupancy
 
 	if !v.supportsOccupancyChildren && mco > 0 {
-		return common.ErrChildOccupancyNotSupported
+		p.Attr("MaxChildOccupancy").AddValue(common.ErrChildOccupancyNotSupported, mco)
 	}
 
 	if mco > max {
-		return common.ErrMaxChildOccGreaterThanMaxOcc
+		p.Attr("MaxChildOccupancy").AddValue(common.ErrMaxChildOccGreaterThanMaxOcc, mco)
 	}
 
 	if std < min {
-		return common.ErrStdOccLowerThanMinOcc
+		p.Elem("TypeRoom").Attr("StandardOccupancy").AddValue(common.ErrStdOccLowerThanMinOcc, std)
 	}
 
 	if max < std {
-		return common.ErrMaxOccLowerThanStdOcc
+		p.Attr("MaxOccupancy").AddValue(common.ErrMaxOccLowerThanStdOcc, max)
 	}
-
-	return nil
 }
 
-func (v *HotelDescriptiveContentNotifValidator) validateTypeRoom(typeRoom TypeRoom) error {
+func (v *HotelDescriptiveContentNotifValidator) validateTypeRoom(p common.Path, typeRoom TypeRoom) {
 	if typeRoom.RoomClassificationCode < 1 || typeRoom.RoomClassificationCode > 83 {
-		return common.ErrInvalidRoomClassificationCode(typeRoom.RoomClassificationCode)
+		p.Attr("RoomClassificationCode").AddValue(common.ErrInvalidRoomClassificationCode(typeRoom.RoomClassificationCode), typeRoom.RoomClassificationCode)
+		return
 	}
 
 	if typeRoom.RoomType > 0 {
@@ -134,82 +124,81 @@ func (v *HotelDescriptiveContentNotifValidator) validateTypeRoom(typeRoom TypeRo
 		}
 		rcc, ok := allowed[typeRoom.RoomType]
 		if !ok {
-			return common.ErrInvalidRoomType(typeRoom.RoomType)
+			p.Attr("RoomType").AddValue(common.ErrInvalidRoomType(typeRoom.RoomType), typeRoom.RoomType)
+			return
 		}
 		if typeRoom.RoomClassificationCode != rcc {
-			return common.ErrInvalidRoomClassificationCode(typeRoom.RoomClassificationCode)
+			p.Attr("RoomClassificationCode").AddValue(common.ErrInvalidRoomClassificationCode(typeRoom.RoomClassificationCode), typeRoom.RoomClassificationCode)
 		}
 	}
-
-	return nil
 }
 
-func (v *HotelDescriptiveContentNotifValidator) validateAmenities(amenities *[]Amenity) error {
+func (v *HotelDescriptiveContentNotifValidator) validateAmenities(p common.Path, amenities *[]Amenity) {
 	if amenities == nil {
-		return nil
+		return
 	}
 
-	for _, amenity := range *amenities {
+	for i, amenity := range *amenities {
 		if code := amenity.RoomAmenityCode; code < 1 || code > 293 {
-			return common.ErrInvalidRoomAmenityType(code)
+			p.Index("Amenity", i).Attr("RoomAmenityCode").AddValue(common.ErrInvalidRoomAmenityType(code), code)
 		}
 	}
-
-	return nil
 }
 
-func (v *HotelDescriptiveContentNotifValidator) validateMultimediaDescriptions(mds *MultimediaDescriptions) error {
+func (v *HotelDescriptiveContentNotifValidator) validateMultimediaDescriptions(p common.Path, mds *MultimediaDescriptions) {
 	if mds == nil {
-		return common.ErrMissingMultimediaDescriptions
+		p.Add(common.ErrMissingMultimediaDescriptions)
+		return
 	}
 
 	longNames := mds.LongNames()
 	if len(longNames) == 0 {
-		return common.ErrMissingLongName
+		p.Add(common.ErrMissingLongName)
 	}
 
-	for _, md := range *mds {
+	for i, md := range *mds {
+		p := p.Index("MultimediaDescription", i)
 		switch md.InfoCode {
 		case InformationTypeLongName:
-			if err := common.ValidateLanguageUniqueness(*md.TextItems); err != nil {
-				return err
+			if md.TextItems == nil {
+				continue
 			}
+			p.Elem("TextItems").Add(common.ValidateLanguageUniqueness(*md.TextItems))
 		case InformationTypeDescription:
-			if err := common.ValidateLanguageUniqueness(*md.TextItems); err != nil {
-				return err
+			if md.TextItems == nil {
+				continue
 			}
+			p.Elem("TextItems").Add(common.ValidateLanguageUniqueness(*md.TextItems))
 		case InformationTypePictures:
-			if err := v.validateImages(*md.ImageItems); err != nil {
-				return err
+			if md.ImageItems == nil {
+				continue
 			}
+			v.validateImages(p.Elem("ImageItems"), *md.ImageItems)
 		}
 	}
-
-	return nil
 }
 
-func (v *HotelDescriptiveContentNotifValidator) validateImages(images []ImageItem) error {
-	for _, image := range images {
+func (v *HotelDescriptiveContentNotifValidator) validateImages(p common.Path, images []ImageItem) {
+	for i, image := range images {
+		p := p.Index("ImageItem", i)
 		if category := image.Category; category < 1 || category > 23 {
-			return common.ErrInvalidPictureCategoryCode(category)
-		}
-		if err := common.ValidateLanguageUniqueness(image.Descriptions); err != nil {
-			return err
+			p.Attr("Category").AddValue(common.ErrInvalidPictureCategoryCode(category), category)
 		}
+		p.Add(common.ValidateLanguageUniqueness(image.Descriptions))
 	}
-	return nil
 }
 
-func (v *HotelDescriptiveContentNotifValidator) validateRooms(rooms []GuestRoom) error {
-	if !v.supportsRooms && len(rooms) > 0 {
-		return common.ErrRoomsNotSupported
+// validateRooms validates the guest rooms at indexes, which describe the rooms
+// of a room category.
+func (v *HotelDescriptiveContentNotifValidator) validateRooms(p common.Path, guestRooms []GuestRoom, indexes []int) {
+	if !v.supportsRooms && len(indexes) > 0 {
+		p.Index("GuestRoom", indexes[0]).Add(common.ErrRoomsNotSupported)
+		return
 	}
 
-	for _, room := range rooms {
-		if strings.TrimSpace(room.TypeRoom.RoomID) == "" {
-			return common.ErrMissingRoomID
+	for _, i := range indexes {
+		if strings.TrimSpace(guestRooms[i].TypeRoom.RoomID) == "" {
+			p.Index("GuestRoom", i).Elem("TypeRoom").Attr("RoomID").Add(common.ErrMissingRoomID)
 		}
 	}
-
-	return nil
 }
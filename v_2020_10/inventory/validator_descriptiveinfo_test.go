@@ -0,0 +1,77 @@
+package inventory
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+
+	"github.com/HGV/alpinebits/internal/schema"
+	"github.com/HGV/alpinebits/v_2020_10/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelDescriptiveInfoValidator_Validate(t *testing.T) {
+	file := "test/data/Inventory-OTA_HotelDescriptiveInfoRQ.xml"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		assert.NoError(t, err, "Failed to read file %s", file)
+	}
+
+	var rq HotelDescriptiveInfoRQ
+	if err := xml.Unmarshal(data, &rq); err != nil {
+		assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+	}
+
+	assert.Equal(t, "123", rq.HotelCode())
+	assert.Equal(t, "Frangart Inn", rq.HotelDescriptiveInfo.HotelName)
+
+	v := NewHotelDescriptiveInfoValidator()
+	assert.NoError(t, v.Validate(rq))
+
+	invalid := rq
+	invalid.HotelDescriptiveInfo = HotelDescriptiveInfo{HotelName: "Frangart Inn"}
+	assert.ErrorIs(t, v.Validate(invalid), common.ErrMissingHotelCode)
+}
+
+func TestHotelDescriptiveInfoRS_Unmarshal(t *testing.T) {
+	file := "test/data/Inventory-OTA_HotelDescriptiveInfoRS.xml"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		assert.NoError(t, err, "Failed to read file %s", file)
+	}
+
+	var rs HotelDescriptiveInfoRS
+	if err := xml.Unmarshal(data, &rs); err != nil {
+		assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+	}
+
+	assert.NotNil(t, rs.Success)
+	content := rs.HotelDescriptiveContent
+	assert.Equal(t, "123", content.HotelCode)
+	assert.Equal(t, "3 stars", content.HotelInfo.CategoryCode.CodeDetail)
+	assert.Len(t, content.GuestRooms, 1)
+	assert.Equal(t, "DZ", content.GuestRooms[0].Code)
+	assert.Len(t, *content.Policies, 6)
+
+	xsd, err := os.ReadFile("../alpinebits.xsd")
+	if err != nil {
+		assert.NoError(t, err, "Failed to read schema")
+	}
+	s, err := schema.Parse(xsd)
+	assert.NoError(t, err)
+
+	b, err := xml.Marshal(rs)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate(string(b)))
+
+	content.GuestRooms = nil
+	b, err = xml.Marshal(rs)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate(string(b)))
+
+	errRS := HotelDescriptiveInfoRS{Version: rs.Version}
+	errRS.AppendError(common.Error{Type: common.ErrorWarningTypeApplicationError, Value: "unknown hotel"})
+	b, err = xml.Marshal(errRS)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate(string(b)))
+}
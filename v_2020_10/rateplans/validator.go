@@ -49,6 +49,7 @@ type HotelRatePlanNotifValidator struct {
 }
 
 var _ common.Validatable[HotelRatePlanNotifRQ] = (*HotelRatePlanNotifValidator)(nil)
+var _ common.ValidatableAll[HotelRatePlanNotifRQ] = (*HotelRatePlanNotifValidator)(nil)
 
 type HotelRatePlanNotifValidatorFunc func(*HotelRatePlanNotifValidator)
 
@@ -155,202 +156,161 @@ func WithOfferRuleDOWLOS() HotelRatePlanNotifValidatorFunc {
 }
 
 func (v *HotelRatePlanNotifValidator) Validate(r HotelRatePlanNotifRQ) error {
-	if err := common.ValidateHotelCode(r.RatePlans.HotelCode); err != nil {
-		return err
-	}
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v *HotelRatePlanNotifValidator) ValidateAll(r HotelRatePlanNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v *HotelRatePlanNotifValidator) validate(p common.Path, r HotelRatePlanNotifRQ) {
+	p = p.Elem("RatePlans")
+	p.Attr("HotelCode").Add(common.ValidateHotelCode(r.RatePlans.HotelCode))
 
 	if r.IsReset() {
-		if err := v.validateRatePlansReset(r.RatePlans.RatePlans); err != nil {
-			return err
-		}
+		v.validateRatePlansReset(p, r.RatePlans.RatePlans)
 	} else {
-		if err := v.validateRatePlans(r.RatePlans.RatePlans); err != nil {
-			return err
-		}
+		v.validateRatePlans(p, r.RatePlans.RatePlans)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlansReset(ratePlans []RatePlan) error {
-	for _, ratePlan := range ratePlans {
-		if err := v.validateRatePlanCode(ratePlan.RatePlanCode); err != nil {
-			return err
-		}
+func (v *HotelRatePlanNotifValidator) validateRatePlansReset(p common.Path, ratePlans []RatePlan) {
+	for i, ratePlan := range ratePlans {
+		v.validateRatePlanCode(p.Index("RatePlan", i), ratePlan.RatePlanCode)
 	}
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlans(ratePlans []RatePlan) error {
-	for _, ratePlan := range ratePlans {
-		if err := v.validateRatePlan(ratePlan); err != nil {
-			return err
-		}
+func (v *HotelRatePlanNotifValidator) validateRatePlans(p common.Path, ratePlans []RatePlan) {
+	for i, ratePlan := range ratePlans {
+		v.validateRatePlan(p.Index("RatePlan", i), ratePlan)
 	}
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlan(ratePlan RatePlan) error {
-	if err := v.validateRatePlanCode(ratePlan.RatePlanCode); err != nil {
-		return err
-	}
-
-	if err := v.validateCurrencyCode(ratePlan.CurrencyCode); err != nil {
-		return err
-	}
+func (v *HotelRatePlanNotifValidator) validateRatePlan(p common.Path, ratePlan RatePlan) {
+	v.validateRatePlanCode(p, ratePlan.RatePlanCode)
+	v.validateCurrencyCode(p, ratePlan.CurrencyCode)
 
 	usesJoinFeature := ratePlan.RatePlanQualifier != nil || ratePlan.RatePlanID != ""
 	if !v.supportsRatePlanJoin && usesJoinFeature {
-		return common.ErrRatePlanJoinNotSupported
+		p.Add(common.ErrRatePlanJoinNotSupported)
+		return
 	}
 
 	switch v.ratePlanNotifType = ratePlan.RatePlanNotifType; v.ratePlanNotifType {
 	case RatePlanNotifTypeNew:
-		return v.validateRatePlanNew(ratePlan)
+		v.validateRatePlanNew(p, ratePlan)
 	case RatePlanNotifTypeOverlay:
-		return v.validateRatePlanOverlay(ratePlan)
+		v.validateRatePlanOverlay(p, ratePlan)
 	case RatePlanNotifTypeRemove:
-		return v.validateRatePlanRemove(ratePlan)
-	default:
-		return nil
+		v.validateRatePlanRemove(p, ratePlan)
 	}
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlanMasterCode(rp RatePlan) error {
+func (v *HotelRatePlanNotifValidator) validateRatePlanMasterCode(p common.Path, rp RatePlan) bool {
 	if !v.supportsRatePlanJoin {
-		return nil
+		return true
 	}
 
+	ok := true
 	if err := common.ValidateString(rp.RatePlanID); err != nil {
-		return common.ErrMissingRatePlanID
+		p.Attr("RatePlanID").Add(common.ErrMissingRatePlanID)
+		ok = false
 	}
 
 	if rp.RatePlanQualifier == nil {
-		return common.ErrMissingRatePlanQualifier
+		p.Attr("RatePlanQualifier").Add(common.ErrMissingRatePlanQualifier)
+		ok = false
 	}
 
-	return nil
+	return ok
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlanCode(code string) error {
+func (v *HotelRatePlanNotifValidator) validateRatePlanCode(p common.Path, code string) {
 	if err := common.ValidateString(code); err != nil {
-		return common.ErrMissingRatePlanCode
+		p.Attr("RatePlanCode").Add(common.ErrMissingRatePlanCode)
 	}
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateCurrencyCode(code string) error {
+func (v *HotelRatePlanNotifValidator) validateCurrencyCode(p common.Path, code string) {
 	if err := common.ValidateString(code); err != nil {
-		return common.ErrMissingCurrencyCode
+		p.Attr("CurrencyCode").Add(common.ErrMissingCurrencyCode)
 	}
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlanNew(ratePlan RatePlan) error {
-	if err := v.validateRatePlanMasterCode(ratePlan); err != nil {
-		return err
+func (v *HotelRatePlanNotifValidator) validateRatePlanNew(p common.Path, ratePlan RatePlan) {
+	if !v.validateRatePlanMasterCode(p, ratePlan) {
+		return
 	}
 
 	if ratePlan.IsMaster() {
-		return v.validateRatePlanNewMaster(ratePlan)
+		v.validateRatePlanNewMaster(p, ratePlan)
+	} else {
+		v.validateRatePlanNewDerived(p, ratePlan)
 	}
-	return v.validateRatePlanNewDerived(ratePlan)
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlanNewMaster(ratePlan RatePlan) error {
-	if err := v.validateOffers(ratePlan.Offers); err != nil {
-		return err
-	}
-
-	if err := v.validateDescriptions(ratePlan.Descriptions); err != nil {
-		return err
-	}
-
-	if err := v.validateBookingRules(ratePlan.BookingRules); err != nil {
-		return err
-	}
-
-	if err := v.validateRates(ratePlan.Rates); err != nil {
-		return err
-	}
-
-	if err := v.validateSupplements(ratePlan.Supplements); err != nil {
-		return err
-	}
-
-	return nil
+func (v *HotelRatePlanNotifValidator) validateRatePlanNewMaster(p common.Path, ratePlan RatePlan) {
+	v.validateOffers(p.Elem("Offers"), ratePlan.Offers)
+	v.validateDescriptions(p.Elem("Description"), ratePlan.Descriptions)
+	v.validateBookingRules(p.Elem("BookingRules"), ratePlan.BookingRules)
+	v.validateRates(p.Elem("Rates"), ratePlan.Rates)
+	v.validateSupplements(p.Elem("Supplements"), ratePlan.Supplements)
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlanNewDerived(ratePlan RatePlan) error {
+func (v *HotelRatePlanNotifValidator) validateRatePlanNewDerived(p common.Path, ratePlan RatePlan) {
 	if _, ok := v.ratePlanMapping[ratePlan.RatePlanID]; !ok {
-		return common.ErrRatePlanNotFound(ratePlan.RatePlanID)
-	}
-
-	if err := v.validateDerivedChargeType(ratePlan); err != nil {
-		return err
-	}
-
-	if err := v.validateMealTypeUniqueness(ratePlan); err != nil {
-		return err
-	}
-
-	if err := v.validateBookingRules(ratePlan.BookingRules); err != nil {
-		return err
+		p.Attr("RatePlanID").AddValue(common.ErrRatePlanNotFound(ratePlan.RatePlanID), ratePlan.RatePlanID)
+		return
 	}
 
-	if err := v.validateRates(ratePlan.Rates); err != nil {
-		return err
-	}
-
-	if err := v.validateDateDependingSupplements(ratePlan.Supplements); err != nil {
-		return err
-	}
+	v.validateDerivedChargeType(p, ratePlan)
+	v.validateMealTypeUniqueness(p, ratePlan)
+	v.validateBookingRules(p.Elem("BookingRules"), ratePlan.BookingRules)
+	v.validateRates(p.Elem("Rates"), ratePlan.Rates)
+	v.validateDateDependingSupplements(p.Elem("Supplements"), ratePlan.Supplements)
 
 	if len(ratePlan.Offers) > 0 {
-		return common.ErrUnexpectedOffers
+		p.Elem("Offers").Add(common.ErrUnexpectedOffers)
 	}
 
 	if !ratePlan.Descriptions.isZero() {
-		return common.ErrUnexpectedDescription
+		p.Elem("Description").Add(common.ErrUnexpectedDescription)
 	}
-
-	return nil
 }
 
 // validateDerivedChargeType validates that a derived rate plan's charge type matches the master rate plan's charge type.
-func (v *HotelRatePlanNotifValidator) validateDerivedChargeType(ratePlan RatePlan) error {
+func (v *HotelRatePlanNotifValidator) validateDerivedChargeType(p common.Path, ratePlan RatePlan) {
 	if len(ratePlan.Rates) == 0 || len(ratePlan.Rates[0].BaseByGuestAmts) == 0 {
-		return nil
+		return
 	}
 
 	derivedChargeType := ratePlan.Rates[0].BaseByGuestAmts[0].Type
 	if derivedChargeType == nil {
-		return nil
+		return
 	}
 
 	master, ok := v.ratePlanMapping[ratePlan.RatePlanID]
 	if !ok {
-		return nil
+		return
 	}
 
 	if *derivedChargeType != master.ChargeType {
-		return common.ErrChargeTypeMismatch
+		p.Elem("Rates").Index("Rate", 0).Elem("BaseByGuestAmts").Index("BaseByGuestAmt", 0).Attr("Type").
+			AddValue(common.ErrChargeTypeMismatch, *derivedChargeType)
 	}
-
-	return nil
 }
 
 // validateMealTypeUniqueness validates that no other rate plan code under the same master has the same meal type.
-func (v *HotelRatePlanNotifValidator) validateMealTypeUniqueness(ratePlan RatePlan) error {
+func (v *HotelRatePlanNotifValidator) validateMealTypeUniqueness(p common.Path, ratePlan RatePlan) {
 	if len(ratePlan.Rates) == 0 || ratePlan.Rates[0].MealsIncluded == nil {
-		return nil
+		return
 	}
 
 	mealType := ratePlan.Rates[0].MealsIncluded.MealPlanCodes
 
 	master, ok := v.ratePlanMapping[ratePlan.RatePlanID]
 	if !ok {
-		return nil
+		return
 	}
 
 	for existingCode, existingMealType := range master.DerivedPlans {
@@ -359,116 +319,98 @@ func (v *HotelRatePlanNotifValidator) validateMealTypeUniqueness(ratePlan RatePl
 		}
 
 		if existingMealType == mealType {
-			return common.ErrDuplicateMealType(existingCode, int(mealType))
+			p.Elem("Rates").Index("Rate", 0).Elem("MealsIncluded").Attr("MealPlanCodes").
+				AddValue(common.ErrDuplicateMealType(existingCode, int(mealType)), mealType)
+			return
 		}
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateOffers(offers []Offer) error {
+func (v *HotelRatePlanNotifValidator) validateOffers(p common.Path, offers []Offer) {
 	if len(offers) == 0 {
-		return common.ErrMissingOfferRule
-	}
-
-	if err := v.validateOfferRule(offers[0].OfferRule); err != nil {
-		return err
-	}
-
-	if err := v.validateAdditionalOffers(offers[1:]); err != nil {
-		return err
+		p.Add(common.ErrMissingOfferRule)
+		return
 	}
 
-	return nil
+	v.validateOfferRule(p.Index("Offer", 0).Elem("OfferRules").Elem("OfferRule"), offers[0].OfferRule)
+	v.validateAdditionalOffers(p, offers)
 }
 
-func (v *HotelRatePlanNotifValidator) validateOfferRule(offerRule *OfferRule) error {
+func (v *HotelRatePlanNotifValidator) validateOfferRule(p common.Path, offerRule *OfferRule) {
 	if offerRule == nil {
-		return common.ErrMissingOfferRule
+		p.Add(common.ErrMissingOfferRule)
+		return
 	}
 
 	if !v.supportsOfferRuleBookingOffset &&
 		(offerRule.MinAdvancedBookingOffset != nil || offerRule.MaxAdvancedBookingOffset != nil) {
-		return common.ErrOfferRuleBookingOffsetNotSupported
+		p.Add(common.ErrOfferRuleBookingOffsetNotSupported)
 	}
 
 	if !v.supportsOfferRuleDOWLOS &&
 		(len(offerRule.LengthsOfStay) > 0 ||
 			offerRule.ArrivalDaysOfWeek != nil ||
 			offerRule.DepartureDaysOfWeek != nil) {
-		return common.ErrOfferRuleDOWLOSNotSupported
-	}
-
-	if err := v.validateOfferRuleLengthsOfStay(offerRule); err != nil {
-		return err
-	}
-
-	if err := v.validateOccupancies(offerRule.Occupancies); err != nil {
-		return err
+		p.Add(common.ErrOfferRuleDOWLOSNotSupported)
 	}
 
-	return nil
+	v.validateOfferRuleLengthsOfStay(p.Elem("LengthsOfStay"), offerRule)
+	v.validateOccupancies(p, offerRule.Occupancies)
 }
 
-func (v *HotelRatePlanNotifValidator) validateOfferRuleLengthsOfStay(offerRule *OfferRule) error {
+func (v *HotelRatePlanNotifValidator) validateOfferRuleLengthsOfStay(p common.Path, offerRule *OfferRule) {
 	var minArrival int
 	var maxArrival int
-	for _, los := range offerRule.LengthsOfStay {
+	for i, los := range offerRule.LengthsOfStay {
 		switch los.MinMaxMessageType {
 		case StayTypeMinArrival:
 			minArrival = los.Time
 		case StayTypeMaxArrival:
 			maxArrival = los.Time
 		case StayTypeMinThrough, StayTypeMaxThrough:
-			return common.ErrStayThroughNotAllowedInOfferRule
+			p.Index("LengthOfStay", i).Attr("MinMaxMessageType").
+				AddValue(common.ErrStayThroughNotAllowedInOfferRule, los.MinMaxMessageType)
+			return
 		}
 	}
 
 	if maxArrival > 0 && minArrival > maxArrival {
-		return common.ErrMinStayArrivalGratherThanMaxStayArrival(minArrival, maxArrival)
+		p.Add(common.ErrMinStayArrivalGratherThanMaxStayArrival(minArrival, maxArrival))
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateOccupancies(occupancies []Occupancy) error {
-	adults := slicesx.Filter(occupancies, Occupancy.isAdult)
+func (v *HotelRatePlanNotifValidator) validateOccupancies(p common.Path, occupancies []Occupancy) {
+	adults := common.IndexesFunc(occupancies, Occupancy.isAdult)
 	switch len(adults) {
 	case 0:
-		return common.ErrMissingAdultOccupancy
+		p.Add(common.ErrMissingAdultOccupancy)
+		return
 	case 1:
-		adultOccupancy := adults[0]
-		if err := v.validateOccupancy(adultOccupancy); err != nil {
-			return err
-		}
+		adultOccupancy := occupancies[adults[0]]
+		v.validateOccupancy(p.Index("Occupancy", adults[0]), adultOccupancy)
 		v.populateAdultOccupancy(adultOccupancy)
 	}
 
-	children := slicesx.Filter(occupancies, Occupancy.isChild)
+	children := common.IndexesFunc(occupancies, Occupancy.isChild)
 	switch len(children) {
 	case 0:
 		break
 	case 1:
-		childOccupancy := children[0]
-		if err := v.validateOccupancy(childOccupancy); err != nil {
-			return err
-		}
+		childOccupancy := occupancies[children[0]]
+		v.validateOccupancy(p.Index("Occupancy", children[0]), childOccupancy)
 		v.populateChildOccupancy(childOccupancy)
 	default:
-		return common.ErrDuplicateChildOccupancy
+		p.Index("Occupancy", children[1]).Add(common.ErrDuplicateChildOccupancy)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateOccupancy(o Occupancy) error {
+func (v *HotelRatePlanNotifValidator) validateOccupancy(p common.Path, o Occupancy) {
 	if min := o.MinOccupancy; min != nil && *min > 99 {
-		return common.ErrInvalidMinOccupancy
+		p.Attr("MinOccupancy").AddValue(common.ErrInvalidMinOccupancy, *min)
 	}
 	if max := o.MaxOccupancy; max != nil && *max > 99 {
-		return common.ErrInvalidMaxOccupancy
+		p.Attr("MaxOccupancy").AddValue(common.ErrInvalidMaxOccupancy, *max)
 	}
-	return nil
 }
 
 func (v *HotelRatePlanNotifValidator) populateAdultOccupancy(occupancy Occupancy) {
@@ -485,45 +427,48 @@ func (v *HotelRatePlanNotifValidator) populateChildOccupancy(occupancy Occupancy
 	}
 }
 
-func (v *HotelRatePlanNotifValidator) validateAdditionalOffers(offers []Offer) error {
-	freeNightOffers := slicesx.Filter(offers, Offer.IsFreeNightOffer)
+// validateAdditionalOffers validates the offers following the first one, which
+// holds the offer rule.
+func (v *HotelRatePlanNotifValidator) validateAdditionalOffers(p common.Path, offers []Offer) {
+	additional := offers[1:]
+	offer := func(i int) common.Path {
+		return p.Index("Offer", i+1)
+	}
+
+	freeNightOffers := common.IndexesFunc(additional, Offer.IsFreeNightOffer)
 	switch len(freeNightOffers) {
 	case 0:
 		break
 	case 1:
-		if err := v.validateFreeNightOffer(freeNightOffers[0]); err != nil {
-			return err
-		}
+		v.validateFreeNightOffer(offer(freeNightOffers[0]), additional[freeNightOffers[0]])
 	default:
-		return common.ErrDuplicateFreeNightOffer
+		offer(freeNightOffers[1]).Add(common.ErrDuplicateFreeNightOffer)
 	}
 
-	familyOffers := slicesx.Filter(offers, Offer.IsFamilyOffer)
+	familyOffers := common.IndexesFunc(additional, Offer.IsFamilyOffer)
 	switch len(familyOffers) {
 	case 0:
 		break
 	case 1:
-		if err := v.validateFamilyOffer(familyOffers[0]); err != nil {
-			return err
-		}
+		v.validateFamilyOffer(offer(familyOffers[0]), additional[familyOffers[0]])
 	default:
-		return common.ErrDuplicateFamilyOffer
+		offer(familyOffers[1]).Add(common.ErrDuplicateFamilyOffer)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateFreeNightOffer(offer Offer) error {
+func (v *HotelRatePlanNotifValidator) validateFreeNightOffer(p common.Path, offer Offer) {
 	if !v.supportsFreeNightOffer {
-		return common.ErrFreeNightOfferNotSupported
+		p.Add(common.ErrFreeNightOfferNotSupported)
+		return
 	}
 
+	discount := p.Elem("Discount")
 	if offer.Discount.NightsRequired == 0 {
-		return common.ErrMissingNightsRequired
+		discount.Attr("NightsRequired").Add(common.ErrMissingNightsRequired)
 	}
 
 	if offer.Discount.NightsDiscounted == 0 {
-		return common.ErrMissingNightsDiscounted
+		discount.Attr("NightsDiscounted").Add(common.ErrMissingNightsDiscounted)
 	}
 
 	if pattern := offer.Discount.DiscountPattern; pattern != "" {
@@ -532,115 +477,91 @@ func (v *HotelRatePlanNotifValidator) validateFreeNightOffer(offer Offer) error
 			offer.Discount.NightsDiscounted,
 		)
 		if pattern != expectedPattern {
-			return common.ErrInvalidDiscountPattern
+			discount.Attr("DiscountPattern").AddValue(common.ErrInvalidDiscountPattern, pattern)
 		}
 	}
 
 	if offer.Guest != nil {
-		return common.ErrUnexpectedGuest
+		p.Elem("Guests").Elem("Guest").Add(common.ErrUnexpectedGuest)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateFamilyOffer(offer Offer) error {
+func (v *HotelRatePlanNotifValidator) validateFamilyOffer(p common.Path, offer Offer) {
 	if !v.supportsFamilyOffer {
-		return common.ErrFamilyOfferNotSupported
+		p.Add(common.ErrFamilyOfferNotSupported)
+		return
 	}
 
+	guest := p.Elem("Guests").Elem("Guest")
 	if offer.Guest.AgeQualifyingCode != AgeQualifyingCodeChild {
-		return common.ErrInvalidGuestAgeQualifyngCode
+		guest.Attr("AgeQualifyingCode").AddValue(common.ErrInvalidGuestAgeQualifyngCode, offer.Guest.AgeQualifyingCode)
 	}
 
 	if v.childOccupancy != nil && v.childOccupancy.MinAge != nil && offer.Guest.MaxAge <= *v.childOccupancy.MinAge {
-		return common.ErrFamilyOfferMaxAgeTooLow(offer.Guest.MaxAge, *v.childOccupancy.MinAge)
+		guest.Attr("MaxAge").AddValue(common.ErrFamilyOfferMaxAgeTooLow(offer.Guest.MaxAge, *v.childOccupancy.MinAge), offer.Guest.MaxAge)
 	}
 
+	discount := p.Elem("Discount")
 	if offer.Discount.NightsRequired > 0 {
-		return common.ErrUnexpectedNightsRequired
+		discount.Attr("NightsRequired").Add(common.ErrUnexpectedNightsRequired)
 	}
 
 	if offer.Discount.NightsDiscounted > 0 {
-		return common.ErrUnexpectedNightsDiscounted
+		discount.Attr("NightsDiscounted").Add(common.ErrUnexpectedNightsDiscounted)
 	}
 
 	if offer.Discount.DiscountPattern != "" {
-		return common.ErrUnexpectedDiscountPattern
+		discount.Attr("DiscountPattern").Add(common.ErrUnexpectedDiscountPattern)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateDescriptions(d RatePlanDescription) error {
-	if err := common.ValidateLanguageUniqueness(d.Titles); err != nil {
-		return err
-	}
-
-	if err := common.ValidateLanguageUniqueness(d.Intros); err != nil {
-		return err
-	}
-
-	if err := common.ValidateLanguageUniqueness(d.Descriptions); err != nil {
-		return err
-	}
+func (v *HotelRatePlanNotifValidator) validateDescriptions(p common.Path, d RatePlanDescription) {
+	p.Add(common.ValidateLanguageUniqueness(d.Titles))
+	p.Add(common.ValidateLanguageUniqueness(d.Intros))
+	p.Add(common.ValidateLanguageUniqueness(d.Descriptions))
 
 	for _, item := range d.Gallery {
-		if err := common.ValidateLanguageUniqueness(item.Descriptions); err != nil {
-			return err
-		}
+		p.Add(common.ValidateLanguageUniqueness(item.Descriptions))
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateBookingRules(bookingRules []BookingRule) error {
-	for _, bookgingRule := range bookingRules {
-		if err := v.validateBookingRule(bookgingRule); err != nil {
-			return err
-		}
-	}
-
-	if err := v.validateBookingRuleOverlaps(bookingRules); err != nil {
-		return err
+func (v *HotelRatePlanNotifValidator) validateBookingRules(p common.Path, bookingRules []BookingRule) {
+	for i, bookgingRule := range bookingRules {
+		v.validateBookingRule(p.Index("BookingRule", i), bookgingRule)
 	}
 
-	return nil
+	v.validateBookingRuleOverlaps(p, bookingRules)
 }
 
-func (v *HotelRatePlanNotifValidator) validateBookingRule(bookingRule BookingRule) error {
+func (v *HotelRatePlanNotifValidator) validateBookingRule(p common.Path, bookingRule BookingRule) {
 	if v.supportsRoomTypeBokingRules {
 		if err := common.ValidateString(bookingRule.Code); err != nil {
-			return common.ErrMissingCode
-		}
-		if _, ok := v.roomTypeMapping[bookingRule.Code]; !ok {
-			return common.ErrInvTypeCodeNotFound(bookingRule.Code)
+			p.Attr("Code").Add(common.ErrMissingCode)
+		} else if _, ok := v.roomTypeMapping[bookingRule.Code]; !ok {
+			p.Attr("Code").AddValue(common.ErrInvTypeCodeNotFound(bookingRule.Code), bookingRule.Code)
 		}
 	} else if v.supportsGenericBookingRules {
 		if bookingRule.Code != "" || bookingRule.CodeContext != "" {
-			return common.ErrRoomTypeBookingRulesNotSupported
+			p.Add(common.ErrRoomTypeBookingRulesNotSupported)
 		}
 	}
 
 	if bookingRule.Start.After(bookingRule.End) {
-		return common.ErrStartAfterEnd
+		p.Attr("Start").AddValue(common.ErrStartAfterEnd, bookingRule.Start)
 	}
 
-	if err := v.validateLengthsOfStay(bookingRule.LengthsOfStay); err != nil {
-		return err
-	}
+	v.validateLengthsOfStay(p.Elem("LengthsOfStay"), bookingRule.LengthsOfStay)
 
 	if !v.supportsArrivalDOW && bookingRule.ArrivalDaysOfWeek != nil {
-		return common.ErrArrivalDOWNotSupported
+		p.Elem("DOW_Restrictions").Elem("ArrivalDaysOfWeek").Add(common.ErrArrivalDOWNotSupported)
 	}
 
 	if !v.supportsDepartureDOW && bookingRule.DepartureDaysOfWeek != nil {
-		return common.ErrDepartureDOWNotSupported
+		p.Elem("DOW_Restrictions").Elem("DepartureDaysOfWeek").Add(common.ErrDepartureDOWNotSupported)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateLengthsOfStay(lengthsOfStay []LengthOfStay) error {
+func (v *HotelRatePlanNotifValidator) validateLengthsOfStay(p common.Path, lengthsOfStay []LengthOfStay) {
 	minArrival, minThrough := 1, 1
 	maxArrival, maxThrough := math.MaxInt32, math.MaxInt32
 
@@ -660,163 +581,140 @@ func (v *HotelRatePlanNotifValidator) validateLengthsOfStay(lengthsOfStay []Leng
 	min := int(math.Max(float64(minArrival), float64(minThrough)))
 	max := int(math.Min(float64(maxArrival), float64(maxThrough)))
 	if min > max {
-		return common.ErrMinStayGratherThanMaxStay(min, max)
+		p.Add(common.ErrMinStayGratherThanMaxStay(min, max))
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateBookingRuleOverlaps(bookingRules []BookingRule) error {
+func (v *HotelRatePlanNotifValidator) validateBookingRuleOverlaps(p common.Path, bookingRules []BookingRule) {
 	if v.supportsRoomTypeBokingRules {
 		bookingRulesByRoomType := slicesx.GroupByFunc(bookingRules, func(b BookingRule) string {
 			return b.Code
 		})
 		for _, brs := range bookingRulesByRoomType {
-			if err := common.ValidateOverlaps(brs); err != nil {
-				return err
-			}
+			p.Add(common.ValidateOverlaps(brs))
 		}
 	} else if v.supportsGenericBookingRules {
-		if err := common.ValidateOverlaps(bookingRules); err != nil {
-			return err
-		}
+		p.Add(common.ValidateOverlaps(bookingRules))
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateRates(rates []Rate) error {
+func (v *HotelRatePlanNotifValidator) validateRates(p common.Path, rates []Rate) {
 	if len(rates) == 0 {
-		return common.ErrMissingStaticRate
-	}
-
-	if err := v.validateStaticRate(rates[0]); err != nil {
-		return err
-	}
-
-	if err := v.validateDateDependingRates(rates[1:]); err != nil {
-		return err
+		p.Add(common.ErrMissingStaticRate)
+		return
 	}
 
-	return nil
+	v.validateStaticRate(p.Index("Rate", 0), rates[0])
+	v.validateDateDependingRates(p, rates, 1)
 }
 
-func (v *HotelRatePlanNotifValidator) validateStaticRate(rate Rate) error {
+func (v *HotelRatePlanNotifValidator) validateStaticRate(p common.Path, rate Rate) {
 	if rate.RateTimeUnit != nil && *rate.RateTimeUnit != TimeUnitDay {
-		return common.ErrInvalidRateTimeUnit
+		p.Attr("RateTimeUnit").AddValue(common.ErrInvalidRateTimeUnit, *rate.RateTimeUnit)
 	}
 
+	b := p.Elem("BaseByGuestAmts")
 	switch len(rate.BaseByGuestAmts) {
 	case 0:
-		return common.ErrMissingBaseByGuestAmt
+		b.Add(common.ErrMissingBaseByGuestAmt)
 	case 1:
-		b := rate.BaseByGuestAmts[0]
-		if b.NumberOfGuests != nil {
-			return common.ErrUnexpectedNumberOfGuests
+		b := b.Index("BaseByGuestAmt", 0)
+		amt := rate.BaseByGuestAmts[0]
+		if amt.NumberOfGuests != nil {
+			b.Attr("NumberOfGuests").Add(common.ErrUnexpectedNumberOfGuests)
 		}
-		if b.AgeQualifyingCode != nil {
-			return common.ErrUnexpectedAgeQualifyingCode
+		if amt.AgeQualifyingCode != nil {
+			b.Attr("AgeQualifyingCode").Add(common.ErrUnexpectedAgeQualifyingCode)
 		}
-		if b.AmountAfterTax != nil {
-			return common.ErrUnexpectedAmountAfterTax
+		if amt.AmountAfterTax != nil {
+			b.Attr("AmountAfterTax").Add(common.ErrUnexpectedAmountAfterTax)
 		}
 	default:
-		return common.ErrUnexpectedBaseByGuestAmt
+		b.Index("BaseByGuestAmt", 1).Add(common.ErrUnexpectedBaseByGuestAmt)
 	}
 
 	if rate.MealsIncluded == nil {
-		return common.ErrMissingMealsIncluded
+		p.Elem("MealsIncluded").Add(common.ErrMissingMealsIncluded)
 	}
 
 	if rate.InvTypeCode != "" {
-		return common.ErrUnexpectedInvTypeCode
+		p.Attr("InvTypeCode").Add(common.ErrUnexpectedInvTypeCode)
 	}
 
 	if rate.Start != nil {
-		return common.ErrUnexpectedStart
+		p.Attr("Start").Add(common.ErrUnexpectedStart)
 	}
 
 	if rate.End != nil {
-		return common.ErrUnexpectedEnd
+		p.Attr("End").Add(common.ErrUnexpectedEnd)
 	}
 
 	if len(rate.AdditionalGuestAmounts) > 0 {
-		return common.ErrUnexpectedAdditionalGuestAmounts
+		p.Elem("AdditionalGuestAmounts").Add(common.ErrUnexpectedAdditionalGuestAmounts)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateDateDependingRates(rates []Rate) error {
-	for _, rate := range rates {
-		if err := v.validateDateDependingRate(rate); err != nil {
-			return err
-		}
-	}
-
-	if err := v.validateDateDependingRateOverlaps(rates); err != nil {
-		return err
+// validateDateDependingRates validates rates[first:].
+func (v *HotelRatePlanNotifValidator) validateDateDependingRates(p common.Path, rates []Rate, first int) {
+	for i := first; i < len(rates); i++ {
+		v.validateDateDependingRate(p.Index("Rate", i), rates[i])
 	}
 
-	return nil
+	v.validateDateDependingRateOverlaps(p, rates[first:])
 }
 
-func (v *HotelRatePlanNotifValidator) validateDateDependingRate(rate Rate) error {
+func (v *HotelRatePlanNotifValidator) validateDateDependingRate(p common.Path, rate Rate) {
 	if err := common.ValidateString(rate.InvTypeCode); err != nil {
-		return common.ErrMissingInvTypeCode
+		p.Attr("InvTypeCode").Add(common.ErrMissingInvTypeCode)
+		return
 	}
 
 	roomTypeOccupancySettings, ok := v.roomTypeMapping[rate.InvTypeCode]
 	if !ok {
-		return common.ErrInvTypeCodeNotFound(rate.InvTypeCode)
+		p.Attr("InvTypeCode").AddValue(common.ErrInvTypeCodeNotFound(rate.InvTypeCode), rate.InvTypeCode)
+		return
 	}
 
 	if rate.Start == nil {
-		return common.ErrMissingStart
+		p.Attr("Start").Add(common.ErrMissingStart)
 	}
 
 	if rate.End == nil {
-		return common.ErrMissingEnd
+		p.Attr("End").Add(common.ErrMissingEnd)
 	}
 
-	if rate.Start.After(*rate.End) {
-		return common.ErrStartAfterEnd
+	if rate.Start != nil && rate.End != nil && rate.Start.After(*rate.End) {
+		p.Attr("Start").AddValue(common.ErrStartAfterEnd, *rate.Start)
 	}
 
-	if err := v.validateBaseByGuestAmts(rate.BaseByGuestAmts, roomTypeOccupancySettings); err != nil {
-		return err
-	}
-
-	if err := v.validateAdditionalGuestAmounts(rate.AdditionalGuestAmounts); err != nil {
-		return err
-	}
+	v.validateBaseByGuestAmts(p.Elem("BaseByGuestAmts"), rate.BaseByGuestAmts, roomTypeOccupancySettings)
+	v.validateAdditionalGuestAmounts(p.Elem("AdditionalGuestAmounts"), rate.AdditionalGuestAmounts)
 
 	if rate.RateTimeUnit != nil {
-		return common.ErrUnexpectedRateTimeUnit
+		p.Attr("RateTimeUnit").Add(common.ErrUnexpectedRateTimeUnit)
 	}
 
 	if rate.UnitMultiplier > 0 {
-		return common.ErrUnexpectedUnitMultiplier
+		p.Attr("UnitMultiplier").Add(common.ErrUnexpectedUnitMultiplier)
 	}
 
 	if rate.MealsIncluded != nil {
-		return common.ErrUnexpectedMealsIncluded
+		p.Elem("MealsIncluded").Add(common.ErrUnexpectedMealsIncluded)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateBaseByGuestAmts(baseByGuestAmts []BaseByGuestAmt, roomTypeOccupancySettings RoomTypeOccupancySettings) error {
+func (v *HotelRatePlanNotifValidator) validateBaseByGuestAmts(p common.Path, baseByGuestAmts []BaseByGuestAmt, roomTypeOccupancySettings RoomTypeOccupancySettings) {
 	numberOfGuestSeen := make(map[int]struct{})
 	stdOccupancySeen := false
-	for _, baseByGuestAmt := range baseByGuestAmts {
-		if err := v.validateBaseByGuestAmt(baseByGuestAmt); err != nil {
-			return err
+	for i, baseByGuestAmt := range baseByGuestAmts {
+		p := p.Index("BaseByGuestAmt", i)
+		if !v.validateBaseByGuestAmt(p, baseByGuestAmt) {
+			continue
 		}
 
 		numberOfGuests := *baseByGuestAmt.NumberOfGuests
 		if _, exists := numberOfGuestSeen[numberOfGuests]; exists {
-			return common.ErrDuplicateBaseByGuestAmt(numberOfGuests)
+			p.Attr("NumberOfGuests").AddValue(common.ErrDuplicateBaseByGuestAmt(numberOfGuests), numberOfGuests)
 		}
 		numberOfGuestSeen[numberOfGuests] = struct{}{}
 
@@ -827,79 +725,82 @@ func (v *HotelRatePlanNotifValidator) validateBaseByGuestAmts(baseByGuestAmts []
 
 	isStdOccupancyRequired := v.ratePlanNotifType == RatePlanNotifTypeNew
 	if isStdOccupancyRequired && !stdOccupancySeen {
-		return common.ErrMissingBaseByGuestAmtWithStdOccupancy(roomTypeOccupancySettings.Std)
+		p.Add(common.ErrMissingBaseByGuestAmtWithStdOccupancy(roomTypeOccupancySettings.Std))
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateBaseByGuestAmt(baseByGuestAmt BaseByGuestAmt) error {
+// validateBaseByGuestAmt reports whether NumberOfGuests is present, which the
+// checks across all amounts depend on.
+func (v *HotelRatePlanNotifValidator) validateBaseByGuestAmt(p common.Path, baseByGuestAmt BaseByGuestAmt) bool {
 	if baseByGuestAmt.NumberOfGuests == nil {
-		return common.ErrMissingNumberOfGuests
+		p.Attr("NumberOfGuests").Add(common.ErrMissingNumberOfGuests)
 	}
 
 	if baseByGuestAmt.AgeQualifyingCode == nil {
-		return common.ErrMissingAgeQualifyingCode
+		p.Attr("AgeQualifyingCode").Add(common.ErrMissingAgeQualifyingCode)
 	}
 
 	if baseByGuestAmt.AmountAfterTax == nil {
-		return common.ErrMissingAmountAfterTax
+		p.Attr("AmountAfterTax").Add(common.ErrMissingAmountAfterTax)
 	}
 
 	if baseByGuestAmt.Type != nil {
-		return common.ErrUnexpectedType
+		p.Attr("Type").Add(common.ErrUnexpectedType)
 	}
 
-	return nil
+	return baseByGuestAmt.NumberOfGuests != nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateAdditionalGuestAmounts(additionalGuestAmounts []AdditionalGuestAmount) error {
-	adults := slicesx.Filter(additionalGuestAmounts, AdditionalGuestAmount.IsAdult)
+func (v *HotelRatePlanNotifValidator) validateAdditionalGuestAmounts(p common.Path, additionalGuestAmounts []AdditionalGuestAmount) {
+	adults := common.IndexesFunc(additionalGuestAmounts, AdditionalGuestAmount.IsAdult)
 	switch len(adults) {
 	case 0:
 		break
 	case 1:
-		if adults[0].Amount == nil {
-			return common.ErrMissingAmount
+		if additionalGuestAmounts[adults[0]].Amount == nil {
+			p.Index("AdditionalGuestAmount", adults[0]).Attr("Amount").Add(common.ErrMissingAmount)
 		}
 	default:
-		return common.ErrDuplicateAdditionalGuestAmountAdult
+		p.Index("AdditionalGuestAmount", adults[1]).Add(common.ErrDuplicateAdditionalGuestAmountAdult)
 	}
 
-	children := slicesx.Filter(additionalGuestAmounts, AdditionalGuestAmount.IsChild)
+	children := common.IndexesFunc(additionalGuestAmounts, AdditionalGuestAmount.IsChild)
 	if v.childOccupancy == nil && len(children) > 0 {
-		return common.ErrChildrenNotAllowed
+		p.Index("AdditionalGuestAmount", children[0]).Add(common.ErrChildrenNotAllowed)
+		return
 	}
-	for _, child := range children {
+	for _, i := range children {
+		p := p.Index("AdditionalGuestAmount", i)
+		child := additionalGuestAmounts[i]
 		if child.MinAge == nil && child.MaxAge == nil {
-			return common.ErrMissingMinAge
+			p.Attr("MinAge").Add(common.ErrMissingMinAge)
 		}
 
 		if child.MinAge != nil && child.MaxAge != nil && *child.MinAge >= *child.MaxAge {
-			return common.ErrMinAgeGreaterThanOrEqualsThanMaxAge
+			p.Attr("MinAge").AddValue(common.ErrMinAgeGreaterThanOrEqualsThanMaxAge, *child.MinAge)
 		}
 
 		if v.childOccupancy.MinAge != nil && child.MinAge != nil && *child.MinAge < *v.childOccupancy.MinAge {
-			return common.ErrMinAgeOutOfRange(*child.MinAge, *v.childOccupancy.Min)
+			p.Attr("MinAge").AddValue(common.ErrMinAgeOutOfRange(*child.MinAge, *v.childOccupancy.Min), *child.MinAge)
 		}
 
 		if v.adultOccupancy.MinAge != nil && child.MaxAge != nil && *child.MaxAge > *v.adultOccupancy.MinAge {
-			return common.ErrMaxAgeOutOfRange(*child.MaxAge, *v.adultOccupancy.MinAge)
+			p.Attr("MaxAge").AddValue(common.ErrMaxAgeOutOfRange(*child.MaxAge, *v.adultOccupancy.MinAge), *child.MaxAge)
 		}
 
 		if child.Amount == nil {
-			return common.ErrMissingAmount
+			p.Attr("Amount").Add(common.ErrMissingAmount)
 		}
 	}
 
-	if err := v.validateAgeRangeOverlaps(children); err != nil {
-		return err
+	childAmounts := make([]AdditionalGuestAmount, len(children))
+	for i, j := range children {
+		childAmounts[i] = additionalGuestAmounts[j]
 	}
-
-	return nil
+	v.validateAgeRangeOverlaps(p, childAmounts)
 }
 
-func (v *HotelRatePlanNotifValidator) validateAgeRangeOverlaps(ageRanges []AdditionalGuestAmount) error {
+func (v *HotelRatePlanNotifValidator) validateAgeRangeOverlaps(p common.Path, ageRanges []AdditionalGuestAmount) {
 	minMaxAge := func(a AdditionalGuestAmount) (min int, max int) {
 		min = 0
 		if a.MinAge != nil {
@@ -921,11 +822,10 @@ func (v *HotelRatePlanNotifValidator) validateAgeRangeOverlaps(ageRanges []Addit
 		min1, max1 := minMaxAge(ageRanges[i])
 		min2, max2 := minMaxAge(ageRanges[i+1])
 		if max1 >= min2 {
-			return common.ErrAgeRangeOverlaps(min1, max1, min2, max2)
+			p.Add(common.ErrAgeRangeOverlaps(min1, max1, min2, max2))
+			return
 		}
 	}
-
-	return nil
 }
 
 func sortAdditionalGuestAmountsByAge(amounts []AdditionalGuestAmount) {
@@ -958,155 +858,132 @@ func sortAdditionalGuestAmountsByAge(amounts []AdditionalGuestAmount) {
 	})
 }
 
-func (v *HotelRatePlanNotifValidator) validateDateDependingRateOverlaps(rates []Rate) error {
+func (v *HotelRatePlanNotifValidator) validateDateDependingRateOverlaps(p common.Path, rates []Rate) {
 	ratesByInvTypeCode := slicesx.GroupByFunc(rates, func(r Rate) string {
 		return r.InvTypeCode
 	})
 	for _, rates := range ratesByInvTypeCode {
-		if err := common.ValidateOverlaps(rates); err != nil {
-			return err
-		}
+		p.Add(common.ValidateOverlaps(rates))
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateSupplements(supplements []Supplement) error {
+func (v *HotelRatePlanNotifValidator) validateSupplements(p common.Path, supplements []Supplement) {
 	if !v.supportsSupplements && len(supplements) > 0 {
-		return common.ErrSupplementsNotSupported
+		p.Add(common.ErrSupplementsNotSupported)
+		return
 	}
 
-	staticSupplements := slicesx.Filter(supplements, Supplement.isStaticSupplement)
-	if err := v.validateStaticSupplements(staticSupplements); err != nil {
-		return err
+	for _, i := range common.IndexesFunc(supplements, Supplement.isStaticSupplement) {
+		v.validateStaticSupplement(p.Index("Supplement", i), supplements[i])
+		v.supplementMapping[supplements[i].InvCode] = struct{}{}
 	}
 
-	dateDependingSupplements := slicesx.Filter(supplements, Supplement.isDateDependingSupplement)
-	if err := v.validateDateDependingSupplements(dateDependingSupplements); err != nil {
-		return err
+	dateDepending := common.IndexesFunc(supplements, Supplement.isDateDependingSupplement)
+	for _, i := range dateDepending {
+		v.validateDateDependingSupplement(p.Index("Supplement", i), supplements[i])
 	}
 
-	return nil
-}
-
-func (v *HotelRatePlanNotifValidator) validateStaticSupplements(supplements []Supplement) error {
-	for _, supplement := range supplements {
-		if err := v.validateStaticSupplement(supplement); err != nil {
-			return err
-		}
-		v.supplementMapping[supplement.InvCode] = struct{}{}
+	dateDependingSupplements := make([]Supplement, len(dateDepending))
+	for i, j := range dateDepending {
+		dateDependingSupplements[i] = supplements[j]
 	}
-	return nil
+	v.validateDateDependingSupplementsOverlaps(p, dateDependingSupplements)
 }
 
-func (v *HotelRatePlanNotifValidator) validateStaticSupplement(supplement Supplement) error {
+func (v *HotelRatePlanNotifValidator) validateStaticSupplement(p common.Path, supplement Supplement) {
 	if supplement.AddToBasicRateIndicator == nil {
-		return common.ErrMissingAddToBasicRateIndicator
+		p.Attr("AddToBasicRateIndicator").Add(common.ErrMissingAddToBasicRateIndicator)
 	}
 
 	if supplement.MandatoryIndicator == nil {
-		return common.ErrMissingMandatoryIndicator
+		p.Attr("MandatoryIndicator").Add(common.ErrMissingMandatoryIndicator)
 	}
 
 	if supplement.ChargeTypeCode == nil {
-		return common.ErrMissingChargeTypeCode
+		p.Attr("ChargeTypeCode").Add(common.ErrMissingChargeTypeCode)
 	}
 
-	if p := supplement.PrerequisiteInventory; p != nil {
-		switch p.InvType {
+	if pi := supplement.PrerequisiteInventory; pi != nil {
+		switch pi.InvType {
 		case PrerequisiteInventoryInvTypeAlpineBitsDOW:
-			match, _ := regexp.MatchString("[0-1]{7}", p.InvCode)
+			match, _ := regexp.MatchString("[0-1]{7}", pi.InvCode)
 			if !match {
-				return common.ErrInvalidDOWString
+				p.Elem("PrerequisiteInventory").Attr("InvCode").AddValue(common.ErrInvalidDOWString, pi.InvCode)
 			}
 		default:
-			return common.ErrInvalidInvType(string(p.InvType))
+			p.Elem("PrerequisiteInventory").Attr("InvType").AddValue(common.ErrInvalidInvType(string(pi.InvType)), pi.InvType)
 		}
 	}
 
 	if d := supplement.Descriptions; d != nil {
-		if err := v.validateDescriptions(*d); err != nil {
-			return err
-		}
+		v.validateDescriptions(p.Elem("Description"), *d)
 	}
 
 	if supplement.Amount != nil {
-		return common.ErrUnexpectedAmount
+		p.Attr("Amount").Add(common.ErrUnexpectedAmount)
 	}
 
 	if supplement.Start != nil {
-		return common.ErrUnexpectedStart
+		p.Attr("Start").Add(common.ErrUnexpectedStart)
 	}
 
 	if supplement.End != nil {
-		return common.ErrUnexpectedEnd
+		p.Attr("End").Add(common.ErrUnexpectedEnd)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateDateDependingSupplements(supplements []Supplement) error {
-	for _, supplement := range supplements {
-		if err := v.validateDateDependingSupplement(supplement); err != nil {
-			return err
-		}
-	}
-
-	if err := v.validateDateDependingSupplementsOverlaps(supplements); err != nil {
-		return err
+func (v *HotelRatePlanNotifValidator) validateDateDependingSupplements(p common.Path, supplements []Supplement) {
+	for i, supplement := range supplements {
+		v.validateDateDependingSupplement(p.Index("Supplement", i), supplement)
 	}
 
-	return nil
+	v.validateDateDependingSupplementsOverlaps(p, supplements)
 }
 
-func (v *HotelRatePlanNotifValidator) validateDateDependingSupplement(supplement Supplement) error {
+func (v *HotelRatePlanNotifValidator) validateDateDependingSupplement(p common.Path, supplement Supplement) {
 	if err := common.ValidateString(supplement.InvCode); err != nil {
-		return common.ErrMissingInvCode
-	}
-
-	if _, ok := v.supplementMapping[supplement.InvCode]; !ok {
-		return common.ErrInvCodeNotFound(supplement.InvCode)
+		p.Attr("InvCode").Add(common.ErrMissingInvCode)
+	} else if _, ok := v.supplementMapping[supplement.InvCode]; !ok {
+		p.Attr("InvCode").AddValue(common.ErrInvCodeNotFound(supplement.InvCode), supplement.InvCode)
 	}
 
 	if supplement.Start == nil {
-		return common.ErrMissingStart
+		p.Attr("Start").Add(common.ErrMissingStart)
 	}
 
 	if supplement.End == nil {
-		return common.ErrMissingEnd
+		p.Attr("End").Add(common.ErrMissingEnd)
 	}
 
-	if supplement.Start.After(*supplement.End) {
-		return common.ErrStartAfterEnd
+	if supplement.Start != nil && supplement.End != nil && supplement.Start.After(*supplement.End) {
+		p.Attr("Start").AddValue(common.ErrStartAfterEnd, *supplement.Start)
 	}
 
-	if p := supplement.PrerequisiteInventory; p != nil {
-		switch p.InvType {
+	if pi := supplement.PrerequisiteInventory; pi != nil {
+		switch pi.InvType {
 		case PrerequisiteInventoryInvTypeRoomType:
-			if _, ok := v.roomTypeMapping[p.InvCode]; !ok {
-				return common.ErrInvCodeNotFound(p.InvCode)
+			if _, ok := v.roomTypeMapping[pi.InvCode]; !ok {
+				p.Elem("PrerequisiteInventory").Attr("InvCode").AddValue(common.ErrInvCodeNotFound(pi.InvCode), pi.InvCode)
 			}
 		default:
-			return common.ErrInvalidInvType(string(p.InvType))
+			p.Elem("PrerequisiteInventory").Attr("InvType").AddValue(common.ErrInvalidInvType(string(pi.InvType)), pi.InvType)
 		}
 	}
 
 	if supplement.AddToBasicRateIndicator != nil {
-		return common.ErrUnexpectedAddToBasicRateIndicator
+		p.Attr("AddToBasicRateIndicator").Add(common.ErrUnexpectedAddToBasicRateIndicator)
 	}
 
 	if supplement.MandatoryIndicator != nil {
-		return common.ErrUnexpectedAddToBasicRateIndicator
+		p.Attr("MandatoryIndicator").Add(common.ErrUnexpectedAddToBasicRateIndicator)
 	}
 
 	if supplement.ChargeTypeCode != nil {
-		return common.ErrUnexpectedAddToBasicRateIndicator
+		p.Attr("ChargeTypeCode").Add(common.ErrUnexpectedAddToBasicRateIndicator)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateDateDependingSupplementsOverlaps(supplements []Supplement) error {
+func (v *HotelRatePlanNotifValidator) validateDateDependingSupplementsOverlaps(p common.Path, supplements []Supplement) {
 	type key struct {
 		SupplementCode string
 		InvTypeCode    string
@@ -1124,22 +1001,20 @@ func (v *HotelRatePlanNotifValidator) validateDateDependingSupplementsOverlaps(s
 		return key
 	})
 	for _, supplements := range supplementsByInvCode {
-		if err := common.ValidateOverlaps(supplements); err != nil {
-			return err
-		}
+		p.Add(common.ValidateOverlaps(supplements))
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlanOverlay(ratePlan RatePlan) error {
+func (v *HotelRatePlanNotifValidator) validateRatePlanOverlay(p common.Path, ratePlan RatePlan) {
 	if !v.supportsOverlay {
-		return common.ErrDeltasNotSupported
+		p.Attr("RatePlanNotifType").AddValue(common.ErrDeltasNotSupported, ratePlan.RatePlanNotifType)
+		return
 	}
 
 	if ratePlan.RatePlanID != "" {
 		if _, ok := v.ratePlanMapping[ratePlan.RatePlanID]; !ok {
-			return common.ErrRatePlanNotFound(ratePlan.RatePlanID)
+			p.Attr("RatePlanID").AddValue(common.ErrRatePlanNotFound(ratePlan.RatePlanID), ratePlan.RatePlanID)
+			return
 		}
 	}
 
@@ -1151,52 +1026,41 @@ func (v *HotelRatePlanNotifValidator) validateRatePlanOverlay(ratePlan RatePlan)
 		}
 	}
 	if !mealPlanSeen {
-		return common.ErrRatePlanNotFound(ratePlan.RatePlanCode)
-	}
-
-	if err := v.validateBookingRules(ratePlan.BookingRules); err != nil {
-		return err
-	}
-
-	if err := v.validateDateDependingRates(ratePlan.Rates); err != nil {
-		return err
+		p.Attr("RatePlanCode").AddValue(common.ErrRatePlanNotFound(ratePlan.RatePlanCode), ratePlan.RatePlanCode)
+		return
 	}
 
-	if err := v.validateDateDependingSupplements(ratePlan.Supplements); err != nil {
-		return err
-	}
+	v.validateBookingRules(p.Elem("BookingRules"), ratePlan.BookingRules)
+	v.validateDateDependingRates(p.Elem("Rates"), ratePlan.Rates, 0)
+	v.validateDateDependingSupplements(p.Elem("Supplements"), ratePlan.Supplements)
 
 	if len(ratePlan.Offers) > 0 {
-		return common.ErrUnexpectedOffers
+		p.Elem("Offers").Add(common.ErrUnexpectedOffers)
 	}
 
 	if !ratePlan.Descriptions.isZero() {
-		return common.ErrUnexpectedDescription
+		p.Elem("Description").Add(common.ErrUnexpectedDescription)
 	}
-
-	return nil
 }
 
-func (v *HotelRatePlanNotifValidator) validateRatePlanRemove(ratePlan RatePlan) error {
+func (v *HotelRatePlanNotifValidator) validateRatePlanRemove(p common.Path, ratePlan RatePlan) {
 	if len(ratePlan.Offers) > 0 {
-		return common.ErrUnexpectedOffers
+		p.Elem("Offers").Add(common.ErrUnexpectedOffers)
 	}
 
 	if !ratePlan.Descriptions.isZero() {
-		return common.ErrUnexpectedDescription
+		p.Elem("Description").Add(common.ErrUnexpectedDescription)
 	}
 
 	if len(ratePlan.BookingRules) > 0 {
-		return common.ErrUnexpectedBookingRules
+		p.Elem("BookingRules").Add(common.ErrUnexpectedBookingRules)
 	}
 
 	if len(ratePlan.Rates) > 0 {
-		return common.ErrUnexpectedRates
+		p.Elem("Rates").Add(common.ErrUnexpectedRates)
 	}
 
 	if len(ratePlan.Supplements) > 0 {
-		return common.ErrUnexpectedSupplements
+		p.Elem("Supplements").Add(common.ErrUnexpectedSupplements)
 	}
-
-	return nil
 }
@@ -1,9 +1,20 @@
 package v_2022_10
 
 import (
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
 
+	"github.com/HGV/alpinebits/v_2022_10/activities"
+	"github.com/HGV/alpinebits/v_2022_10/common"
+	"github.com/HGV/alpinebits/v_2022_10/freerooms"
+	"github.com/HGV/alpinebits/v_2022_10/guestrequests"
+	"github.com/HGV/alpinebits/v_2022_10/handshake"
+	"github.com/HGV/alpinebits/v_2022_10/inventory"
+	"github.com/HGV/alpinebits/v_2022_10/rateplans"
 	"github.com/HGV/alpinebits/version"
 )
 
@@ -16,43 +27,180 @@ const (
 	ActionHotelInvCountNotif                    Action = "OTA_HotelInvCountNotif:FreeRooms"
 	ActionReadGuestRequests                     Action = "OTA_Read:GuestRequests"
 	ActionNotifReportGuestRequests              Action = "OTA_NotifReport:GuestRequests"
+	ActionHotelResNotifGuestRequests            Action = "OTA_HotelResNotif:GuestRequests"
 	ActionHotelDescriptiveContentNotifInventory Action = "OTA_HotelDescriptiveContentNotif:Inventory"
 	ActionHotelDescriptiveContentNotifInfo      Action = "OTA_HotelDescriptiveContentNotif:Info"
+	ActionHotelDescriptiveInfoInventory         Action = "OTA_HotelDescriptiveInfo:Inventory"
+	ActionHotelDescriptiveInfoInfo              Action = "OTA_HotelDescriptiveInfo:Info"
 	ActionHotelRatePlanNotifRatePlans           Action = "OTA_HotelRatePlanNotif:RatePlans"
+	ActionHotelRatePlanBaseRates                Action = "OTA_HotelRatePlan:BaseRates"
+	ActionHotelPostEventNotifEventReports       Action = "OTA_HotelPostEventNotif:EventReports"
 )
 
 func (a Action) Unmarshal(b []byte) (any, error) {
+	return a.Decode(bytes.NewReader(b))
+}
+
+var _ version.Decoder = new(Action)
+
+func (a Action) Decode(r io.Reader) (any, error) {
 	var v any
 
 	switch a {
 	case ActionPing:
-		v = new(PingRQ)
+		v = new(handshake.PingRQ)
+	case ActionHotelInvCountNotif:
+		v = new(freerooms.HotelInvCountNotifRQ)
+	case ActionReadGuestRequests:
+		v = new(guestrequests.ReadRQ)
+	case ActionNotifReportGuestRequests:
+		v = new(guestrequests.NotifReportRQ)
+	case ActionHotelResNotifGuestRequests:
+		v = new(guestrequests.HotelResNotifRQ)
+	case ActionHotelDescriptiveContentNotifInventory, ActionHotelDescriptiveContentNotifInfo:
+		v = new(inventory.HotelDescriptiveContentNotifRQ)
+	case ActionHotelDescriptiveInfoInventory, ActionHotelDescriptiveInfoInfo:
+		v = new(inventory.HotelDescriptiveInfoRQ)
+	case ActionHotelRatePlanNotifRatePlans:
+		v = new(rateplans.HotelRatePlanNotifRQ)
+	case ActionHotelRatePlanBaseRates:
+		v = new(rateplans.HotelRatePlanRQ)
+	case ActionHotelPostEventNotifEventReports:
+		v = new(activities.HotelPostEventNotifRQ)
 	default:
 		return nil, fmt.Errorf("unhandled action: %s", a)
 	}
 
-	if err := xml.Unmarshal(b, &v); err != nil {
+	if err := xml.NewDecoder(r).Decode(&v); err != nil {
 		return nil, err
 	}
 	return v, nil
 }
 
+var _ version.ErrorResponder = new(Action)
+
+func (a Action) ErrorResponse(data any, err error) (any, bool) {
+	err = commonError(err)
+
+	var resp common.Response
+	var errs common.ValidationErrors
+	var e *common.Error
+	var w *common.Warning
+	switch {
+	case errors.As(err, &errs):
+		resp.AppendValidationErrors(errs)
+	case errors.As(err, &e):
+		resp.AppendError(*e)
+	case errors.As(err, &w):
+		resp.SetSuccess()
+		resp.AppendWarning(*w)
+	default:
+		return nil, false
+	}
+
+	switch rq := data.(type) {
+	case *freerooms.HotelInvCountNotifRQ:
+		return freerooms.HotelInvCountNotifRS{Response: resp, Version: rq.Version}, true
+	case *guestrequests.NotifReportRQ:
+		return guestrequests.NotifReportRS{Response: resp, Version: rq.Version}, true
+	case *inventory.HotelDescriptiveContentNotifRQ:
+		return inventory.HotelDescriptiveContentNotifRS{Response: resp, Version: rq.Version}, true
+	case *rateplans.HotelRatePlanNotifRQ:
+		return rateplans.HotelRatePlanNotifRS{Response: resp, Version: rq.Version}, true
+	case *activities.HotelPostEventNotifRQ:
+		return activities.HotelPostEventNotifRS{Response: resp, Version: rq.Version}, true
+	}
+
+	// The remaining responses carry their payload next to Success, so only
+	// errors can be rendered without the handler's response.
+	if resp.Errors == nil {
+		return nil, false
+	}
+
+	switch rq := data.(type) {
+	case *guestrequests.ReadRQ:
+		return guestrequests.ResRetrieveRS{Response: resp, Version: rq.Version}, true
+	case *guestrequests.HotelResNotifRQ:
+		return guestrequests.HotelResNotifRS{Response: resp, Version: rq.Version}, true
+	case *inventory.HotelDescriptiveInfoRQ:
+		return inventory.HotelDescriptiveInfoRS{Response: resp, Version: rq.Version}, true
+	case *rateplans.HotelRatePlanRQ:
+		return rateplans.HotelRatePlanRS{Response: resp, Version: rq.Version}, true
+	}
+	return nil, false
+}
+
+var _ version.ErrorLocalizer = new(Action)
+
+// LocalizeError translates the messages of the errors rendered by
+// ErrorResponse into lang, see common.Localize.
+func (a Action) LocalizeError(err error, lang string) error {
+	return common.Localize(commonError(err), lang)
+}
+
+// commonError replaces the version independent errors of the router with
+// their OTA error.
+func commonError(err error) error {
+	if errors.Is(err, version.ErrTooManyRequests) {
+		return common.ErrTooManyRequests
+	}
+	return err
+}
+
+var _ version.MessageTypesProvider = new(Action)
+
+func (a Action) MessageTypes() (reflect.Type, reflect.Type) {
+	switch a {
+	case ActionPing:
+		return version.MessageTypesOf[handshake.PingRQ, handshake.PingRS]()
+	case ActionHotelInvCountNotif:
+		return version.MessageTypesOf[freerooms.HotelInvCountNotifRQ, freerooms.HotelInvCountNotifRS]()
+	case ActionReadGuestRequests:
+		return version.MessageTypesOf[guestrequests.ReadRQ, guestrequests.ResRetrieveRS]()
+	case ActionNotifReportGuestRequests:
+		return version.MessageTypesOf[guestrequests.NotifReportRQ, guestrequests.NotifReportRS]()
+	case ActionHotelResNotifGuestRequests:
+		return version.MessageTypesOf[guestrequests.HotelResNotifRQ, guestrequests.HotelResNotifRS]()
+	case ActionHotelDescriptiveContentNotifInventory, ActionHotelDescriptiveContentNotifInfo:
+		return version.MessageTypesOf[inventory.HotelDescriptiveContentNotifRQ, inventory.HotelDescriptiveContentNotifRS]()
+	case ActionHotelDescriptiveInfoInventory, ActionHotelDescriptiveInfoInfo:
+		return version.MessageTypesOf[inventory.HotelDescriptiveInfoRQ, inventory.HotelDescriptiveInfoRS]()
+	case ActionHotelRatePlanNotifRatePlans:
+		return version.MessageTypesOf[rateplans.HotelRatePlanNotifRQ, rateplans.HotelRatePlanNotifRS]()
+	case ActionHotelRatePlanBaseRates:
+		return version.MessageTypesOf[rateplans.HotelRatePlanRQ, rateplans.HotelRatePlanRS]()
+	case ActionHotelPostEventNotifEventReports:
+		return version.MessageTypesOf[activities.HotelPostEventNotifRQ, activities.HotelPostEventNotifRS]()
+	default:
+		return nil, nil
+	}
+}
+
 func (a Action) HandshakeName() string {
 	switch a {
 	case ActionPing:
 		return "action_OTA_Ping"
 	case ActionHotelInvCountNotif:
 		return "action_OTA_HotelInvCountNotif"
-	case ActionReadGuestRequests:
+	case ActionReadGuestRequests, ActionNotifReportGuestRequests:
+		// acknowledging pulled guest requests is part of the pull
 		return "action_OTA_Read"
-	case ActionNotifReportGuestRequests:
+	case ActionHotelResNotifGuestRequests:
 		return "action_OTA_HotelResNotif_GuestRequests"
 	case ActionHotelDescriptiveContentNotifInventory:
 		return "action_OTA_HotelDescriptiveContentNotif_Inventory"
 	case ActionHotelDescriptiveContentNotifInfo:
 		return "action_OTA_HotelDescriptiveContentNotif_Info"
+	case ActionHotelDescriptiveInfoInventory:
+		return "action_OTA_HotelDescriptiveInfo_Inventory"
+	case ActionHotelDescriptiveInfoInfo:
+		return "action_OTA_HotelDescriptiveInfo_Info"
 	case ActionHotelRatePlanNotifRatePlans:
 		return "action_OTA_HotelRatePlanNotif_RatePlans"
+	case ActionHotelRatePlanBaseRates:
+		return "action_OTA_HotelRatePlan_BaseRates"
+	case ActionHotelPostEventNotifEventReports:
+		return "action_OTA_HotelPostEventNotif_EventReports"
 	default:
 		return ""
 	}
@@ -0,0 +1,167 @@
+package activities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/HGV/alpinebits/v_2022_10/common"
+)
+
+type HotelPostEventNotifValidator struct{}
+
+var _ common.Validatable[HotelPostEventNotifRQ] = (*HotelPostEventNotifValidator)(nil)
+var _ common.ValidatableAll[HotelPostEventNotifRQ] = (*HotelPostEventNotifValidator)(nil)
+
+type HotelPostEventNotifValidatorFunc func(*HotelPostEventNotifValidator)
+
+func NewHotelPostEventNotifValidator(opts ...HotelPostEventNotifValidatorFunc) HotelPostEventNotifValidator {
+	var v HotelPostEventNotifValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+var dateLayouts = []string{
+	time.DateOnly,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+}
+
+func (v HotelPostEventNotifValidator) Validate(r HotelPostEventNotifRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelPostEventNotifValidator) ValidateAll(r HotelPostEventNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelPostEventNotifValidator) validate(p common.Path, r HotelPostEventNotifRQ) {
+	p = p.Elem("EventReports")
+	if len(r.EventReports) == 0 {
+		p.Add(common.ErrMissingEventReport)
+		return
+	}
+
+	for i, eventReport := range r.EventReports {
+		v.validateEventReport(p.Index("EventReport", i), eventReport)
+	}
+}
+
+func (v HotelPostEventNotifValidator) validateEventReport(p common.Path, eventReport EventReport) {
+	v.validateEventID(p.Elem("EventSites").Elem("EventSite").Elem("Event_ID"), eventReport.EventSite.EventID)
+
+	info := eventReport.GeneralEventInfo
+	p = p.Elem("GeneralEventInfo")
+
+	v.validateEventContacts(p.Elem("EventContacts"), info.EventContacts)
+	v.validateAttendeeInfo(p.Elem("AttendeeInfo"), info.AttendeeInfo)
+	v.validateDates(p.Elem("Dates"), info.Dates)
+	v.validateComments(p.Elem("Comments"), info.Comments)
+}
+
+func (v HotelPostEventNotifValidator) validateEventID(p common.Path, eventID EventID) {
+	if strings.TrimSpace(eventID.ID) == "" {
+		p.Attr("ID").Add(common.ErrMissingEventID)
+	}
+
+	if strings.TrimSpace(eventID.IDContext) == "" {
+		p.Attr("ID_Context").Add(common.ErrMissingEventIDContext)
+	}
+
+	if eventID.Type != EventIDTypeEvent {
+		p.Attr("Type").AddValue(common.ErrInvalidEventIDType, eventID.Type)
+	}
+}
+
+func (v HotelPostEventNotifValidator) validateEventContacts(p common.Path, contacts []EventContact) {
+	for i, contact := range contacts {
+		p := p.Index("EventContact", i)
+		if contact.PersonName != nil {
+			if err := common.ValidateString(contact.PersonName.Surname); err != nil {
+				p.Elem("PersonName").Elem("Surname").Add(common.ErrMissingSurname)
+			}
+		}
+		if contact.URL != nil {
+			if err := common.ValidateString(contact.URL.Value); err != nil {
+				p.Elem("URL").Add(common.ErrInvalidURL)
+			}
+		}
+	}
+}
+
+func (v HotelPostEventNotifValidator) validateAttendeeInfo(p common.Path, attendeeInfo *AttendeeInfo) {
+	if attendeeInfo == nil {
+		return
+	}
+
+	if attendeeInfo.PreRegisteredQuantity > attendeeInfo.TotalQuantity {
+		p.Attr("PreRegisteredQuantity").AddValue(common.ErrPreRegisteredQuantityGreaterThanTotalQuantity, attendeeInfo.PreRegisteredQuantity)
+	}
+}
+
+func (v HotelPostEventNotifValidator) validateDates(p common.Path, dates []Date) {
+	for i, date := range dates {
+		v.validateDate(p.Index("Date", i), date)
+	}
+}
+
+func (v HotelPostEventNotifValidator) validateDate(p common.Path, date Date) {
+	start, ok := parseDate(date.Start)
+	if !ok {
+		p.Attr("Start").AddValue(common.ErrInvalidStart, date.Start)
+	}
+
+	if date.End != "" {
+		end, endOK := parseDate(date.End)
+		if !endOK {
+			p.Attr("End").AddValue(common.ErrInvalidEnd, date.End)
+		} else if ok && end.Before(start) {
+			p.Attr("Start").AddValue(common.ErrStartAfterEnd, date.Start)
+		}
+	}
+
+	if date.EndDateWindow != nil {
+		if _, ok := parseDate(date.EndDateWindow.LatestDate); !ok {
+			p.Elem("EndDateWindow").Attr("LatestDate").AddValue(common.ErrInvalidLatestDate, date.EndDateWindow.LatestDate)
+		}
+	}
+
+	if date.LocationCategories != nil {
+		seen := make(map[string]struct{})
+		for i, category := range date.LocationCategories.Categories {
+			if _, exists := seen[category.Language]; exists {
+				p.Elem("LocationCategories").Index("Category", i).Attr("Language").AddValue(common.ErrDuplicateLanguage, category.Language)
+			}
+			seen[category.Language] = struct{}{}
+		}
+	}
+}
+
+func (v HotelPostEventNotifValidator) validateComments(p common.Path, comments []Comment) {
+	seenNames := make(map[CommentName]struct{})
+	for i, comment := range comments {
+		p := p.Index("Comment", i)
+		if _, exists := seenNames[comment.Name]; exists {
+			p.Attr("Name").AddValue(common.ErrDuplicateCommentName(string(comment.Name)), comment.Name)
+		}
+		seenNames[comment.Name] = struct{}{}
+
+		seenLanguages := make(map[string]struct{})
+		for j, text := range comment.Texts {
+			if _, exists := seenLanguages[text.Language]; exists {
+				p.Index("Text", j).Attr("Language").AddValue(common.ErrDuplicateLanguage, text.Language)
+			}
+			seenLanguages[text.Language] = struct{}{}
+		}
+	}
+}
+
+func parseDate(s string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,237 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/HGV/alpinebits/version"
+)
+
+type Validatable[T any] interface {
+	Validate(v T) error
+}
+
+// ValidatableAll is implemented by validators that can report all errors of
+// a request at once instead of the first one.
+type ValidatableAll[T any] interface {
+	ValidateAll(v T) error
+}
+
+func ValidateHotelCode(hotelCode string) error {
+	if strings.TrimSpace(hotelCode) == "" {
+		return ErrMissingHotelCode
+	}
+	return nil
+}
+
+func ValidateOverlaps[T version.DateRangeProvider](ranges []T) error {
+	if len(ranges) <= 1 {
+		return nil
+	}
+
+	slices.SortFunc(ranges, func(a, b T) int {
+		return a.DateRange().Start.Compare(b.DateRange().Start)
+	})
+
+	for i := 0; i < len(ranges)-1; i++ {
+		range1 := ranges[i].DateRange()
+		range2 := ranges[i+1].DateRange()
+		if !ranges[i].DateRange().End.Before(ranges[i+1].DateRange().Start) {
+			return ErrDateRangeOverlaps(range1, range2)
+		}
+	}
+
+	return nil
+}
+
+func ValidateLanguageUniqueness(descs []Description) error {
+	seen := make(map[string]struct{})
+	for _, desc := range descs {
+		lang := strings.TrimSpace(desc.Language)
+		key := lang + "|" + string(desc.TextFormat)
+		if _, exists := seen[key]; exists {
+			return ErrDuplicateLanguage
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+func ValidateString(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return errors.New("string is empty or contains only whitespace")
+	}
+	return nil
+}
+
+func ValidateNonNilString(s *string) error {
+	if s == nil {
+		return nil
+	}
+	return ValidateString(*s)
+}
+
+// IndexesFunc returns the indexes of the elements of s satisfying f, e.g. to
+// locate filtered elements in the request.
+func IndexesFunc[S ~[]E, E any](s S, f func(E) bool) []int {
+	var indexes []int
+	for i, e := range s {
+		if f(e) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// ValidationError is an error located in a request. Path points to the
+// element or attribute, e.g. RatePlans/RatePlan[3]/Rates/Rate[2]/@Start, with
+// indexes starting at 1 like in XPath. Value is the offending value, if any.
+type ValidationError struct {
+	Path  string
+	Value string
+	Err   error
+}
+
+func (e ValidationError) Error() string {
+	msg := e.Err.Error()
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	if e.Value != "" {
+		msg += fmt.Sprintf(" (value %q)", e.Value)
+	}
+	return msg
+}
+
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors are all errors found by ValidateAll.
+type ValidationErrors []ValidationError
+
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (errs ValidationErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i, err := range errs {
+		unwrapped[i] = err
+	}
+	return unwrapped
+}
+
+// AppendValidationErrors appends an Error for each validation error, with the
+// path and value included in the message.
+func (r *Response) AppendValidationErrors(errs ValidationErrors) {
+	for _, err := range errs {
+		e := Error{Type: ErrorWarningTypeApplicationError, Code: CodeInvalidValue}
+		var inner *Error
+		if errors.As(err.Err, &inner) {
+			// the message includes the path, so it can't be translated anymore
+			e = Error{Type: inner.Type, Code: inner.Code, Status: inner.Status, ID: inner.ID}
+		}
+		e.Value = err.Error()
+		r.AppendError(e)
+	}
+}
+
+// Path is the location of the element being validated. Errors added to a path
+// are collected by the Validate or ValidateAll call it descends from.
+type Path struct {
+	errs *collector
+	path string
+}
+
+type collector struct {
+	all  bool
+	errs ValidationErrors
+}
+
+// stop ends the walk of Validate once the first error is added, so that
+// validators don't have to check for errors before descending.
+type stop struct{}
+
+// Validate calls fn with the root path and returns the first error added. fn
+// doesn't continue after the error.
+func Validate(fn func(p Path)) (err error) {
+	c := &collector{}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(stop); !ok {
+				panic(r)
+			}
+			err = c.errs[0].Err
+		}
+	}()
+	fn(Path{errs: c})
+	return nil
+}
+
+// ValidateAll calls fn with the root path and returns all errors added as
+// ValidationErrors.
+func ValidateAll(fn func(p Path)) error {
+	c := &collector{all: true}
+	fn(Path{errs: c})
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+func (p Path) Elem(name string) Path {
+	return p.join(name)
+}
+
+// Index returns the path of the i-th element with the given name, counting
+// from 0.
+func (p Path) Index(name string, i int) Path {
+	return p.join(name + "[" + strconv.Itoa(i+1) + "]")
+}
+
+func (p Path) Attr(name string) Path {
+	return p.join("@" + name)
+}
+
+func (p Path) join(s string) Path {
+	if p.path != "" {
+		s = p.path + "/" + s
+	}
+	return Path{errs: p.errs, path: s}
+}
+
+func (p Path) String() string {
+	return p.path
+}
+
+// Add records err at the path. Nil errors are ignored.
+func (p Path) Add(err error) {
+	p.add(err, "")
+}
+
+// AddValue records err at the path together with the offending value.
+func (p Path) AddValue(err error, value any) {
+	p.add(err, fmt.Sprint(value))
+}
+
+func (p Path) add(err error, value string) {
+	if err == nil {
+		return
+	}
+	p.errs.errs = append(p.errs.errs, ValidationError{
+		Path:  p.path,
+		Value: value,
+		Err:   err,
+	})
+	if !p.errs.all {
+		panic(stop{})
+	}
+}
@@ -0,0 +1,259 @@
+package freerooms
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/HGV/alpinebits/v_2022_10/common"
+	"github.com/HGV/x/slicesx"
+)
+
+type HotelInvCountNotifValidator struct {
+	supportsRooms          bool
+	roomMapping            *map[string]map[string]struct{}
+	supportsCategories     bool
+	categoriesMapping      *map[string]struct{}
+	supportsCompleteSet    bool
+	supportsDeltas         bool
+	supportsOutOfOrder     bool
+	supportsOutOfMarket    bool
+	supportsClosingSeasons bool
+}
+
+var _ common.Validatable[HotelInvCountNotifRQ] = (*HotelInvCountNotifValidator)(nil)
+var _ common.ValidatableAll[HotelInvCountNotifRQ] = (*HotelInvCountNotifValidator)(nil)
+
+type HotelInvCountNotifValidatorFunc func(*HotelInvCountNotifValidator)
+
+func NewHotelInvCountNotifValidator(opts ...HotelInvCountNotifValidatorFunc) HotelInvCountNotifValidator {
+	var v HotelInvCountNotifValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+func WithRooms() HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.supportsRooms = true
+	}
+}
+
+func WithRoomMapping(mapping map[string]map[string]struct{}) HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.roomMapping = &mapping
+	}
+}
+
+func WithCategories() HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.supportsCategories = true
+	}
+}
+
+func WithCategoriesMapping(mapping map[string]struct{}) HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.categoriesMapping = &mapping
+	}
+}
+
+func WithCompleteSet() HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.supportsCompleteSet = true
+	}
+}
+
+func WithDeltas() HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.supportsDeltas = true
+	}
+}
+
+func WithOutOfOrder() HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.supportsOutOfOrder = true
+	}
+}
+
+func WithOutOfMarket() HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.supportsOutOfMarket = true
+	}
+}
+
+func WithClosingSeasons() HotelInvCountNotifValidatorFunc {
+	return func(v *HotelInvCountNotifValidator) {
+		v.supportsClosingSeasons = true
+	}
+}
+
+func (v HotelInvCountNotifValidator) Validate(r HotelInvCountNotifRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelInvCountNotifValidator) ValidateAll(r HotelInvCountNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelInvCountNotifValidator) validate(p common.Path, r HotelInvCountNotifRQ) {
+	p.Elem("Inventories").Attr("HotelCode").Add(common.ValidateHotelCode(r.Inventories.HotelCode))
+
+	v.validateUniqueID(p.Elem("UniqueID"), r.UniqueID)
+
+	if r.Inventories.IsReset() {
+		return
+	}
+
+	v.validateInventories(p.Elem("Inventories"), r.Inventories.Inventories)
+}
+
+func (v HotelInvCountNotifValidator) validateUniqueID(p common.Path, uid *UniqueID) {
+	if uid == nil && !v.supportsDeltas {
+		p.Add(common.ErrDeltasNotSupported)
+	}
+	if uid != nil && uid.Instance == UniqueIDInstanceCompleteSet && !v.supportsCompleteSet {
+		p.Attr("Instance").AddValue(common.ErrCompleteSetNotSupported, uid.Instance)
+	}
+}
+
+func (v HotelInvCountNotifValidator) validateInventories(p common.Path, invs []Inventory) {
+	for i, inv := range invs {
+		if inv.StatusApplicationControl == nil {
+			p.Index("Inventory", i).Elem("StatusApplicationControl").Add(common.ErrMissingStatusApplicationControl)
+		}
+	}
+
+	avails := slicesx.Filter(invs, Inventory.isAvailability)
+	v.validateAvailabilities(p, invs, avails)
+
+	closingSeasons := slicesx.Filter(invs, Inventory.isClosingSeason)
+	v.validateClosingSeasons(p, invs, closingSeasons)
+
+	if v.supportsClosingSeasons {
+		v.validateClosingSeasonsOverlapBookableAvailabilities(p, avails, closingSeasons)
+	}
+}
+
+func (v HotelInvCountNotifValidator) validateAvailabilities(p common.Path, invs, avails []Inventory) {
+	for i, inv := range invs {
+		if inv.isAvailability() {
+			v.validateAvailability(p.Index("Inventory", i), inv)
+		}
+	}
+
+	v.validateAvailabilitiesOverlap(p, avails)
+}
+
+func (v HotelInvCountNotifValidator) validateAvailability(p common.Path, avail Inventory) {
+	v.validateStatusApplicationControl(p.Elem("StatusApplicationControl"), avail.StatusApplicationControl)
+	v.validateInvCounts(p.Elem("InvCounts"), avail.InvCounts)
+}
+
+func (v HotelInvCountNotifValidator) validateStatusApplicationControl(p common.Path, s *StatusApplicationControl) {
+	if strings.TrimSpace(s.InvTypeCode) == "" {
+		p.Attr("InvTypeCode").Add(common.ErrMissingInvTypeCode)
+		return
+	}
+
+	if v.supportsRooms {
+		if strings.TrimSpace(s.InvCode) == "" {
+			p.Attr("InvCode").Add(common.ErrMissingInvCode)
+			return
+		}
+		if v.roomMapping != nil {
+			if _, ok := (*v.roomMapping)[s.InvTypeCode][s.InvCode]; !ok {
+				p.Attr("InvCode").AddValue(common.ErrInvCodeNotFound(s.InvCode), s.InvCode)
+			}
+		}
+	} else if v.supportsCategories {
+		if v.categoriesMapping != nil {
+			if _, ok := (*v.categoriesMapping)[s.InvTypeCode]; !ok {
+				p.Attr("InvTypeCode").AddValue(common.ErrInvTypeCodeNotFound(s.InvTypeCode), s.InvTypeCode)
+			}
+		}
+	}
+}
+
+func (v HotelInvCountNotifValidator) validateInvCounts(p common.Path, invCounts *[]InvCount) {
+	if invCounts == nil {
+		return
+	}
+
+	if n := len(*invCounts); v.supportsRooms && n > 1 {
+		p.AddValue(common.ErrInvalidInvCounts(n), n)
+	}
+
+	for i, invCount := range *invCounts {
+		p := p.Index("InvCount", i)
+		if v.supportsRooms && invCount.Count > 1 {
+			p.Attr("Count").AddValue(common.ErrInvalidCount(invCount.Count), invCount.Count)
+		}
+
+		switch ct := invCount.CountType; ct {
+		case CountTypeBookable:
+		case CountTypeOutOfOrder:
+			if !v.supportsOutOfOrder {
+				p.Attr("CountType").AddValue(common.ErrOutOfOrderNotSupported, ct)
+			}
+		case CountTypeFree:
+			if !v.supportsOutOfMarket {
+				p.Attr("CountType").AddValue(common.ErrOutOfMarketNotSupported, ct)
+			}
+		}
+	}
+}
+
+func (v HotelInvCountNotifValidator) validateAvailabilitiesOverlap(p common.Path, invs []Inventory) {
+	availsBy := v.groupAvailabilitiesByCapability(invs)
+	for _, avails := range availsBy {
+		p.Add(common.ValidateOverlaps(avails))
+	}
+}
+
+func (v HotelInvCountNotifValidator) validateClosingSeasons(p common.Path, invs, closingSeasons []Inventory) {
+	if !v.supportsClosingSeasons && len(closingSeasons) > 0 {
+		p.Add(common.ErrClosingSeasonsNotSupported)
+	}
+
+	for i, inv := range invs {
+		if inv.isClosingSeason() {
+			v.validateClosingSeason(p.Index("Inventory", i), inv)
+		}
+	}
+
+	p.Add(common.ValidateOverlaps(closingSeasons))
+}
+
+func (v HotelInvCountNotifValidator) validateClosingSeason(p common.Path, closingSeason Inventory) {
+	if closingSeason.InvCounts != nil {
+		p.Elem("InvCounts").Add(common.ErrUnexpectedInvCounts)
+	}
+}
+
+func (v HotelInvCountNotifValidator) validateClosingSeasonsOverlapBookableAvailabilities(p common.Path, avails []Inventory, closingSeasons []Inventory) {
+	bookableAvails := slicesx.Filter(avails, func(a Inventory) bool {
+		return a.InvCounts != nil && slices.ContainsFunc(*a.InvCounts, func(i InvCount) bool {
+			return i.CountType == CountTypeBookable
+		})
+	})
+
+	bookableAvailsBy := v.groupAvailabilitiesByCapability(bookableAvails)
+	for _, avails := range bookableAvailsBy {
+		if err := common.ValidateOverlaps(append(avails, closingSeasons...)); err != nil {
+			p.Add(common.ErrAvailabilitiesOverlapClosingSeasons)
+		}
+	}
+}
+
+func (v HotelInvCountNotifValidator) groupAvailabilitiesByCapability(avails []Inventory) map[string][]Inventory {
+	return slicesx.GroupByFunc(avails, func(inv Inventory) string {
+		switch {
+		case v.supportsRooms:
+			return inv.StatusApplicationControl.InvCode
+		case v.supportsCategories:
+			return inv.StatusApplicationControl.InvTypeCode
+		default:
+			return ""
+		}
+	})
+}
@@ -0,0 +1,503 @@
+package guestrequests
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/HGV/alpinebits/v_2022_10/common"
+	"github.com/HGV/alpinebits/v_2022_10/rateplans"
+)
+
+type ReadValidator struct{}
+
+var _ common.Validatable[ReadRQ] = (*ReadValidator)(nil)
+var _ common.ValidatableAll[ReadRQ] = (*ReadValidator)(nil)
+
+func (v ReadValidator) Validate(r ReadRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v ReadValidator) ValidateAll(r ReadRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v ReadValidator) validate(p common.Path, r ReadRQ) {
+	p = p.Elem("ReadRequests").Elem("HotelReadRequest").Attr("HotelCode")
+	p.Add(common.ValidateHotelCode(r.HotelReadRequest.HotelCode))
+}
+
+type ResRetrieveValidator struct {
+	roomTypeCodes map[string]struct{}
+	resStatuses   []ResStatus
+}
+
+var _ common.Validatable[ResRetrieveRS] = (*ResRetrieveValidator)(nil)
+var _ common.ValidatableAll[ResRetrieveRS] = (*ResRetrieveValidator)(nil)
+
+type ResRetrieveValidatorFunc func(*ResRetrieveValidator)
+
+func NewResRetrieveValidator(opts ...ResRetrieveValidatorFunc) ResRetrieveValidator {
+	var v ResRetrieveValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+func WithRoomTypeCodes(mapping map[string]struct{}) ResRetrieveValidatorFunc {
+	return func(v *ResRetrieveValidator) {
+		v.roomTypeCodes = mapping
+	}
+}
+
+func (v ResRetrieveValidator) Validate(r ResRetrieveRS) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v ResRetrieveValidator) ValidateAll(r ResRetrieveRS) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v ResRetrieveValidator) validate(p common.Path, r ResRetrieveRS) {
+	if r.HotelReservations != nil {
+		p := p.Elem("ReservationsList")
+		for i, res := range *r.HotelReservations {
+			v.validateHotelReservation(p.Index("HotelReservation", i), res)
+		}
+	}
+}
+
+func (v ResRetrieveValidator) validateHotelReservation(p common.Path, h HotelReservation) {
+	v.validateUniqueID(p.Elem("UniqueID"), h.UniqueID, h.ResStatus)
+	v.resStatuses = append(v.resStatuses, h.ResStatus)
+
+	v.validateRoomStays(p.Elem("RoomStays"), h.RoomStays)
+	v.validateCustomer(p.Elem("ResGuests").Elem("ResGuest").Elem("Profiles").Elem("ProfileInfo").Elem("Profile").Elem("Customer"), h.Customer)
+	v.validateResGlobalInfo(p.Elem("ResGlobalInfo"), h.ResGlobalInfo)
+}
+
+func (v ResRetrieveValidator) validateUniqueID(p common.Path, uid UniqueID, resStatus ResStatus) {
+	switch resStatus {
+	case ResStatusRequested, ResStatusReserved, ResStatusModify:
+		if uid.Type != UniqueIDTypeReservation {
+			p.Attr("Type").AddValue(common.ErrInvalidUniqueID(string(resStatus), int(uid.Type)), int(uid.Type))
+		}
+	case ResStatusCancelled:
+		if uid.Type != UniqueIDTypeCancellation {
+			p.Attr("Type").AddValue(common.ErrInvalidUniqueID(string(resStatus), int(uid.Type)), int(uid.Type))
+		}
+	}
+
+	if err := common.ValidateString(uid.ID); err != nil {
+		p.Attr("ID").Add(common.ErrMissingID)
+	}
+}
+
+func (v ResRetrieveValidator) validateRoomStays(p common.Path, roomStays *[]RoomStay) {
+	if roomStays == nil || len(*roomStays) == 0 {
+		if !v.isCancellation() {
+			p.Add(common.ErrMissingRoomStay)
+		}
+		return
+	}
+
+	for _, i := range common.IndexesFunc(*roomStays, RoomStay.isPrimaryStay) {
+		v.validateRoomStay(p.Index("RoomStay", i), (*roomStays)[i])
+	}
+
+	alternatives := common.IndexesFunc(*roomStays, RoomStay.isAlternativeStay)
+	if len(alternatives) > 1 {
+		p.Index("RoomStay", alternatives[1]).Add(common.ErrDuplicateAlternativeRoomStay)
+		return
+	}
+	if len(alternatives) == 1 {
+		v.validateAlternativeRoomStay(p.Index("RoomStay", alternatives[0]), (*roomStays)[alternatives[0]])
+	}
+}
+
+func (v ResRetrieveValidator) validateRoomStay(p common.Path, roomStay RoomStay) {
+	v.validateRoomType(p.Elem("RoomTypes").Elem("RoomType"), roomStay.RoomType)
+	v.validateRatePlan(p.Elem("RatePlans").Elem("RatePlan"), roomStay.RatePlan)
+	v.validateGuestCounts(p.Elem("GuestCounts"), roomStay.GuestCounts)
+	v.validateTimeSpan(p.Elem("TimeSpan"), roomStay.TimeSpan)
+	v.validateTotal(p.Elem("Total"), roomStay.Total)
+}
+
+func (v ResRetrieveValidator) validateRoomType(p common.Path, roomType *ResRoomType) {
+	if roomType == nil {
+		if v.isReservation() {
+			p.Add(common.ErrMissingRoomType)
+		}
+		return
+	}
+
+	if strings.TrimSpace(roomType.RoomTypeCode) == "" {
+		p.Attr("RoomTypeCode").Add(common.ErrMissingRoomTypeCode)
+		return
+	}
+
+	if v.roomTypeCodes != nil {
+		if _, ok := v.roomTypeCodes[roomType.RoomTypeCode]; !ok {
+			p.Attr("RoomTypeCode").AddValue(common.ErrInvCodeNotFound(roomType.RoomTypeCode), roomType.RoomTypeCode)
+		}
+	}
+}
+
+func (v ResRetrieveValidator) validateRatePlan(p common.Path, ratePlan *ResRatePlan) {
+	if ratePlan == nil {
+		if v.isReservation() {
+			p.Add(common.ErrMissingRatePlan)
+		}
+		return
+	}
+
+	if strings.TrimSpace(ratePlan.RatePlanCode) == "" {
+		p.Attr("RatePlanCode").Add(common.ErrMissingRatePlanCode)
+	}
+
+	if c := ratePlan.Commission; c != nil {
+		v.validateCommission(p.Elem("Commission"), *c)
+	}
+
+	v.validateMealsIncluded(p.Elem("MealsIncluded"), ratePlan.MealsIncluded)
+}
+
+func (v ResRetrieveValidator) validateCommission(p common.Path, commission Commission) {
+	if commission.Percent != nil {
+		if *commission.Percent > 100 {
+			p.Attr("Percent").AddValue(common.ErrInvalidPercent, *commission.Percent)
+		}
+	}
+}
+
+func (v ResRetrieveValidator) validateMealsIncluded(p common.Path, mealsIncluded *rateplans.MealsIncluded) {
+	if v.isReservation() && mealsIncluded == nil {
+		p.Add(common.ErrMissingMealsIncluded)
+	}
+}
+
+func (v ResRetrieveValidator) validateGuestCounts(p common.Path, guestCounts []GuestCount) {
+	if len(guestCounts) == 0 {
+		p.Add(common.ErrMissingGuestCount)
+		return
+	}
+
+	adultSeen := false
+	for i, guestCount := range guestCounts {
+		if guestCount.Age == nil && adultSeen {
+			p.Index("GuestCount", i).Add(common.ErrDuplicateAdultGuestCount)
+		}
+		adultSeen = adultSeen || guestCount.Age == nil
+	}
+}
+
+func (v ResRetrieveValidator) validateTimeSpan(p common.Path, timeSpan TimeSpan) {
+	if v.isReservation() {
+		v.validateTimeSpanFixedPeriod(p, timeSpan)
+		return
+	}
+
+	hasFixedPeriod := timeSpan.Start != nil && timeSpan.End != nil
+	hasWindowedPeriod := timeSpan.StartDateWindow != nil && timeSpan.Duration != nil
+
+	if !hasFixedPeriod && !hasWindowedPeriod {
+		p.Add(common.ErrMissingTimeSpan)
+		return
+	}
+
+	if hasFixedPeriod {
+		v.validateTimeSpanFixedPeriod(p, timeSpan)
+	}
+
+	if hasWindowedPeriod {
+		v.validateTimeSpanWindowedPeriod(p, timeSpan)
+	}
+}
+
+func (v ResRetrieveValidator) validateTimeSpanFixedPeriod(p common.Path, timeSpan TimeSpan) {
+	if timeSpan.Start == nil {
+		p.Attr("Start").Add(common.ErrMissingStart)
+		return
+	}
+
+	if timeSpan.End == nil {
+		p.Attr("End").Add(common.ErrMissingEnd)
+		return
+	}
+
+	if timeSpan.Start.After(*timeSpan.End) {
+		p.Attr("Start").AddValue(common.ErrStartAfterEnd, *timeSpan.Start)
+	}
+
+	if timeSpan.StartDateWindow != nil {
+		p.Elem("StartDateWindow").Add(common.ErrUnexpectedStartDateWindow)
+	}
+
+	if timeSpan.Duration != nil {
+		p.Attr("Duration").Add(common.ErrUnexpectedDuration)
+	}
+}
+
+func (v ResRetrieveValidator) validateTimeSpanWindowedPeriod(p common.Path, timeSpan TimeSpan) {
+	w := timeSpan.StartDateWindow
+	if w == nil {
+		p.Elem("StartDateWindow").Add(common.ErrMissingStartDateWindow)
+		return
+	}
+
+	if w.EarliestDate.After(w.LatestDate) {
+		p.Elem("StartDateWindow").Attr("EarliestDate").AddValue(common.ErrEarliestDateAfterLatestDate, w.EarliestDate)
+		return
+	}
+
+	nights := timeSpan.Duration
+	if nights == nil {
+		p.Attr("Duration").Add(common.ErrMissingDuration)
+		return
+	}
+
+	if int(*nights) >= w.LatestDate.DaysSince(w.EarliestDate) {
+		p.Attr("Duration").AddValue(common.ErrDurationOutOfRange, *nights)
+	}
+
+	if timeSpan.Start != nil {
+		p.Attr("Start").Add(common.ErrUnexpectedStart)
+	}
+
+	if timeSpan.End != nil {
+		p.Attr("End").Add(common.ErrUnexpectedEnd)
+	}
+}
+
+func (v ResRetrieveValidator) validateTotal(p common.Path, total *Total) {
+	if v.isReservation() && total == nil {
+		p.Add(common.ErrMissingTotal)
+	}
+}
+
+func (v ResRetrieveValidator) validateAlternativeRoomStay(p common.Path, roomStay RoomStay) {
+	if !v.isQuoteRequest() {
+		p.Add(common.ErrUnexpectedAlternativeRoomStay)
+		return
+	}
+
+	v.validateTimeSpan(p.Elem("TimeSpan"), roomStay.TimeSpan)
+
+	if roomStay.RoomType != nil {
+		p.Elem("RoomTypes").Elem("RoomType").Add(common.ErrUnexpectedRoomType)
+	}
+
+	if roomStay.RatePlan != nil {
+		p.Elem("RatePlans").Elem("RatePlan").Add(common.ErrUnexpectedRatePlan)
+	}
+
+	if len(roomStay.GuestCounts) > 0 {
+		p.Elem("GuestCounts").Add(common.ErrUnexpectedGuestCounts)
+	}
+
+	if roomStay.Total != nil {
+		p.Elem("Total").Add(common.ErrUnexpectedTotal)
+	}
+}
+
+func (v ResRetrieveValidator) validateCustomer(p common.Path, customer *Customer) {
+	if customer == nil {
+		if !v.isCancellation() {
+			p.Add(common.ErrMissingCustomer)
+		}
+		return
+	}
+
+	v.validatePersonName(p.Elem("PersonName"), customer.PersonName)
+
+	if customer.Email != nil {
+		p.Elem("Email").AddValue(v.validateEmail(*customer.Email), customer.Email.Value)
+	}
+
+	if customer.Address != nil {
+		v.validateAddress(p.Elem("Address"), *customer.Address)
+	}
+}
+
+func (v ResRetrieveValidator) validatePersonName(p common.Path, personName PersonName) {
+	if personName.NamePrefix != nil && strings.TrimSpace(*personName.NamePrefix) == "" {
+		p.Elem("NamePrefix").Add(common.ErrInvalidNamePrefix)
+	}
+
+	if strings.TrimSpace(personName.GivenName) == "" {
+		p.Elem("GivenName").Add(common.ErrMissingGivenName)
+	}
+
+	if strings.TrimSpace(personName.Surname) == "" {
+		p.Elem("Surname").Add(common.ErrMissingSurname)
+	}
+
+	if personName.NameTitle != nil && strings.TrimSpace(*personName.NameTitle) == "" {
+		p.Elem("NameTitle").Add(common.ErrInvalidNameTitle)
+	}
+}
+
+func (v ResRetrieveValidator) validateEmail(email Email) error {
+	_, err := mail.ParseAddress(email.Value)
+	return err
+}
+
+func (v ResRetrieveValidator) validateAddress(p common.Path, address Address) {
+	if err := common.ValidateNonNilString(address.AddressLine); err != nil {
+		p.Elem("AddressLine").Add(common.ErrInvalidAddressLine)
+	}
+
+	if err := common.ValidateNonNilString(address.CityName); err != nil {
+		p.Elem("CityName").Add(common.ErrInvalidCityName)
+	}
+
+	if err := common.ValidateNonNilString(address.PostalCode); err != nil {
+		p.Elem("PostalCode").Add(common.ErrInvalidPostalCode)
+	}
+
+	if err := v.validateCountryName(address.CountryName); err != nil {
+		p.Elem("CountryName").Attr("Code").Add(common.ErrInvalidCountryNameCode)
+	}
+}
+
+func (v ResRetrieveValidator) validateCountryName(countryName *CountryName) error {
+	if countryName == nil {
+		return nil
+	}
+	return common.ValidateString(countryName.Code)
+}
+
+func (v ResRetrieveValidator) validateResGlobalInfo(p common.Path, globalInfo *ResGlobalInfo) {
+	if globalInfo == nil {
+		if !v.isCancellation() {
+			p.Add(common.ErrMissingResGlobalInfo)
+		}
+		return
+	}
+
+	v.validateComments(p.Elem("Comments"), globalInfo.Comments)
+
+	if v.isReservation() {
+		if err := common.ValidateNonNilString(globalInfo.CancelPenalty); err != nil {
+			p.Elem("CancelPenalties").Elem("CancelPenalty").Elem("PenaltyDescription").Elem("Text").Add(common.ErrInvalidPenaltyDescriptionText)
+		}
+	}
+
+	v.validateHotelReservationID(p.Elem("HotelReservationIDs").Elem("HotelReservationID"), globalInfo.HotelReservationID)
+
+	if globalInfo.Profile != nil {
+		v.validateCompanyInfo(p.Elem("Profiles").Elem("ProfileInfo").Elem("Profile").Elem("CompanyInfo"), globalInfo.Profile.CompanyInfo)
+	}
+
+	if !v.isCancellation() {
+		p.Elem("BasicPropertyInfo").Attr("HotelCode").Add(common.ValidateHotelCode(globalInfo.BasicPropertyInfo.HotelCode))
+	}
+}
+
+func (v ResRetrieveValidator) validateComments(p common.Path, comments *[]Comment) {
+	if comments == nil {
+		return
+	}
+
+	for i, comment := range *comments {
+		p := p.Index("Comment", i)
+		for j, listItem := range comment.ListItems {
+			if err := common.ValidateString(listItem.Value); err != nil {
+				p.Index("ListItem", j).Add(common.ErrInvalidListItem)
+			}
+		}
+		if comment.Text != nil {
+			if err := common.ValidateString(comment.Text.Value); err != nil {
+				p.Elem("Text").Add(common.ErrInvalidCommentText)
+			}
+		}
+	}
+}
+
+func (v ResRetrieveValidator) validateHotelReservationID(p common.Path, id *HotelReservationID) {
+	if id == nil {
+		return
+	}
+
+	if err := common.ValidateNonNilString(id.ResIDValue); err != nil {
+		p.Attr("ResID_Value").Add(common.ErrInvalidResIDValue)
+	}
+
+	if err := common.ValidateNonNilString(id.ResIDSource); err != nil {
+		p.Attr("ResID_Source").Add(common.ErrInvalidResIDSource)
+	}
+
+	if err := common.ValidateNonNilString(id.ResIDSourceContext); err != nil {
+		p.Attr("ResID_SourceContext").Add(common.ErrInvalidResIDSourceContext)
+	}
+}
+
+func (v ResRetrieveValidator) validateCompanyInfo(p common.Path, companyInfo CompanyInfo) {
+	v.validateCompanyName(p.Elem("CompanyName"), companyInfo.CompanyName)
+
+	if companyInfo.AddressInfo != nil {
+		v.validateAddress(p.Elem("AddressInfo"), *companyInfo.AddressInfo)
+	}
+
+	if companyInfo.Email != nil {
+		if err := v.validateEmail(*companyInfo.Email); err != nil {
+			p.Elem("Email").AddValue(common.ErrInvalidEmail, companyInfo.Email.Value)
+		}
+	}
+}
+
+func (v ResRetrieveValidator) validateCompanyName(p common.Path, companyName CompanyName) {
+	if err := common.ValidateString(companyName.Code); err != nil {
+		p.Attr("Code").Add(common.ErrInvalidCompanyNameCode)
+	}
+
+	if err := common.ValidateString(companyName.Value); err != nil {
+		p.Add(common.ErrInvalidCompanyNameValue)
+	}
+}
+
+// Returns true if the current guest request being validated is a quote request.
+func (v ResRetrieveValidator) isQuoteRequest() bool {
+	status := v.resStatuses[len(v.resStatuses)-1]
+	return status == ResStatusRequested
+}
+
+// Returns true if the current guest request being validated is a reservation.
+func (v ResRetrieveValidator) isReservation() bool {
+	status := v.resStatuses[len(v.resStatuses)-1]
+	return status.IsReservation()
+}
+
+// Returns true if the current guest request being validated is a cancellation.
+func (v ResRetrieveValidator) isCancellation() bool {
+	status := v.resStatuses[len(v.resStatuses)-1]
+	return status == ResStatusCancelled
+}
+
+type HotelResNotifValidator struct {
+	resRetrieveValidator ResRetrieveValidator
+}
+
+var _ common.Validatable[HotelResNotifRQ] = (*HotelResNotifValidator)(nil)
+var _ common.ValidatableAll[HotelResNotifRQ] = (*HotelResNotifValidator)(nil)
+
+func NewHotelResNotifValidator(opts ...ResRetrieveValidatorFunc) HotelResNotifValidator {
+	return HotelResNotifValidator{
+		resRetrieveValidator: NewResRetrieveValidator(opts...),
+	}
+}
+
+func (v HotelResNotifValidator) Validate(r HotelResNotifRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelResNotifValidator) ValidateAll(r HotelResNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelResNotifValidator) validate(p common.Path, r HotelResNotifRQ) {
+	p = p.Elem("HotelReservations")
+	for i, res := range r.HotelReservations {
+		v.resRetrieveValidator.validateHotelReservation(p.Index("HotelReservation", i), res)
+	}
+}
@@ -0,0 +1,204 @@
+package inventory
+
+import (
+	"strings"
+
+	"github.com/HGV/alpinebits/v_2022_10/common"
+)
+
+type HotelDescriptiveContentNotifValidator struct {
+	supportsRooms             bool
+	supportsOccupancyChildren bool
+}
+
+var _ common.Validatable[HotelDescriptiveContentNotifRQ] = (*HotelDescriptiveContentNotifValidator)(nil)
+var _ common.ValidatableAll[HotelDescriptiveContentNotifRQ] = (*HotelDescriptiveContentNotifValidator)(nil)
+
+type HotelDescriptiveContentNotifValidatorFunc func(*HotelDescriptiveContentNotifValidator)
+
+func NewHotelDescriptiveContentNotifValidator(opts ...HotelDescriptiveContentNotifValidatorFunc) HotelDescriptiveContentNotifValidator {
+	var v HotelDescriptiveContentNotifValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+func WithRooms() HotelDescriptiveContentNotifValidatorFunc {
+	return func(v *HotelDescriptiveContentNotifValidator) {
+		v.supportsRooms = true
+	}
+}
+
+func WithOccupancyChildren() HotelDescriptiveContentNotifValidatorFunc {
+	return func(v *HotelDescriptiveContentNotifValidator) {
+		v.supportsOccupancyChildren = true
+	}
+}
+
+func (v HotelDescriptiveContentNotifValidator) Validate(r HotelDescriptiveContentNotifRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelDescriptiveContentNotifValidator) ValidateAll(r HotelDescriptiveContentNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelDescriptiveContentNotifValidator) validate(p common.Path, r HotelDescriptiveContentNotifRQ) {
+	p = p.Elem("HotelDescriptiveContents").Elem("HotelDescriptiveContent")
+	p.Attr("HotelCode").Add(common.ValidateHotelCode(r.HotelDescriptiveContent.HotelCode))
+
+	v.validateGuestRooms(p.Elem("FacilityInfo").Elem("GuestRooms"), r.HotelDescriptiveContent.GuestRooms)
+}
+
+func (v HotelDescriptiveContentNotifValidator) validateGuestRooms(p common.Path, guestRooms []GuestRoom) {
+	var codes []string
+	indexesByCode := make(map[string][]int)
+	for i, guestRoom := range guestRooms {
+		if _, ok := indexesByCode[guestRoom.Code]; !ok {
+			codes = append(codes, guestRoom.Code)
+		}
+		indexesByCode[guestRoom.Code] = append(indexesByCode[guestRoom.Code], i)
+	}
+	for _, code := range codes {
+		v.validateGuestRoom(p, guestRooms, indexesByCode[code])
+	}
+}
+
+// validateGuestRoom validates the guest rooms at indexes, which share the same
+// code. The first one describes the room category, the others its rooms.
+func (v HotelDescriptiveContentNotifValidator) validateGuestRoom(p common.Path, guestRooms []GuestRoom, indexes []int) {
+	headGuestRoom := guestRooms[indexes[0]]
+	head := p.Index("GuestRoom", indexes[0])
+	if strings.TrimSpace(headGuestRoom.Code) == "" {
+		head.Attr("Code").Add(common.ErrMissingCode)
+	}
+
+	v.validateOccupancies(head, headGuestRoom)
+	v.validateTypeRoom(head.Elem("TypeRoom"), headGuestRoom.TypeRoom)
+	v.validateAmenities(head.Elem("Amenities"), headGuestRoom.Amenities)
+	v.validateMultimediaDescriptions(head.Elem("MultimediaDescriptions"), headGuestRoom.MultimediaDescriptions)
+	v.validateRooms(p, guestRooms, indexes[1:])
+}
+
+func (v HotelDescriptiveContentNotifValidator) validateOccupancies(p common.Path, guestRoom GuestRoom) {
+	min := guestRoom.MinOccupancy
+	std := guestRoom.TypeRoom.StandardOccupancy
+	max := guestRoom.MaxOccupancy
+	mco := guestRoom.MaxChildOccupancy
+
+	if !v.supportsOccupancyChildren && mco > 0 {
+		p.Attr("MaxChildOccupancy").AddValue(common.ErrChildOccupancyNotSupported, mco)
+	}
+
+	if mco > max {
+		p.Attr("MaxChildOccupancy").AddValue(common.ErrMaxChildOccGreaterThanMaxOcc, mco)
+	}
+
+	if std < min {
+		p.Elem("TypeRoom").Attr("StandardOccupancy").AddValue(common.ErrStdOccLowerThanMinOcc, std)
+	}
+
+	if max < std {
+		p.Attr("MaxOccupancy").AddValue(common.ErrMaxOccLowerThanStdOcc, max)
+	}
+}
+
+func (v *HotelDescriptiveContentNotifValidator) validateTypeRoom(p common.Path, typeRoom TypeRoom) {
+	if typeRoom.RoomClassificationCode < 1 || typeRoom.RoomClassificationCode > 83 {
+		p.Attr("RoomClassificationCode").AddValue(common.ErrInvalidRoomClassificationCode(typeRoom.RoomClassificationCode), typeRoom.RoomClassificationCode)
+		return
+	}
+
+	if typeRoom.RoomType > 0 {
+		allowed := map[int]int{
+			1: 42, // Room
+			2: 13, // Apartments
+			3: 13, // Mobile Homes
+			4: 13, // Bungalows
+			5: 13, // Holiday Homes
+			6: 5,  // Camping Grounds
+			7: 5,  // Pitches
+			8: 5,  // Camping Grounds/Pitches
+			9: 42, // Resting places
+		}
+		rcc, ok := allowed[typeRoom.RoomType]
+		if !ok {
+			p.Attr("RoomType").AddValue(common.ErrInvalidRoomType(typeRoom.RoomType), typeRoom.RoomType)
+			return
+		}
+		if typeRoom.RoomClassificationCode != rcc {
+			p.Attr("RoomClassificationCode").AddValue(common.ErrInvalidRoomClassificationCode(typeRoom.RoomClassificationCode), typeRoom.RoomClassificationCode)
+		}
+	}
+}
+
+func (v *HotelDescriptiveContentNotifValidator) validateAmenities(p common.Path, amenities *[]Amenity) {
+	if amenities == nil {
+		return
+	}
+
+	for i, amenity := range *amenities {
+		if code := amenity.RoomAmenityCode; code < 1 || code > 293 {
+			p.Index("Amenity", i).Attr("RoomAmenityCode").AddValue(common.ErrInvalidRoomAmenityType(code), code)
+		}
+	}
+}
+
+func (v *HotelDescriptiveContentNotifValidator) validateMultimediaDescriptions(p common.Path, mds *MultimediaDescriptions) {
+	if mds == nil {
+		p.Add(common.ErrMissingMultimediaDescriptions)
+		return
+	}
+
+	longNames := mds.LongNames()
+	if len(longNames) == 0 {
+		p.Add(common.ErrMissingLongName)
+	}
+
+	for i, md := range *mds {
+		p := p.Index("MultimediaDescription", i)
+		switch md.InfoCode {
+		case InformationTypeLongName:
+			if md.TextItems == nil {
+				continue
+			}
+			p.Elem("TextItems").Add(common.ValidateLanguageUniqueness(*md.TextItems))
+		case InformationTypeDescription:
+			if md.TextItems == nil {
+				continue
+			}
+			p.Elem("TextItems").Add(common.ValidateLanguageUniqueness(*md.TextItems))
+		case InformationTypePictures:
+			if md.ImageItems == nil {
+				continue
+			}
+			v.validateImages(p.Elem("ImageItems"), *md.ImageItems)
+		}
+	}
+}
+
+func (v *HotelDescriptiveContentNotifValidator) validateImages(p common.Path, images []ImageItem) {
+	for i, image := range images {
+		p := p.Index("ImageItem", i)
+		if category := image.Category; category < 1 || category > 23 {
+			p.Attr("Category").AddValue(common.ErrInvalidPictureCategoryCode(category), category)
+		}
+		p.Add(common.ValidateLanguageUniqueness(image.Descriptions))
+	}
+}
+
+// validateRooms validates the guest rooms at indexes, which describe the rooms
+// of a room category.
+func (v *HotelDescriptiveContentNotifValidator) validateRooms(p common.Path, guestRooms []GuestRoom, indexes []int) {
+	if !v.supportsRooms && len(indexes) > 0 {
+		p.Index("GuestRoom", indexes[0]).Add(common.ErrRoomsNotSupported)
+		return
+	}
+
+	for _, i := range indexes {
+		if strings.TrimSpace(guestRooms[i].TypeRoom.RoomID) == "" {
+			p.Index("GuestRoom", i).Elem("TypeRoom").Attr("RoomID").Add(common.ErrMissingRoomID)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package inventory
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/HGV/alpinebits/v_2022_10/common"
+)
+
+type HotelInfoValidator struct{}
+
+var _ common.Validatable[HotelDescriptiveContentNotifRQ] = (*HotelInfoValidator)(nil)
+var _ common.ValidatableAll[HotelDescriptiveContentNotifRQ] = (*HotelInfoValidator)(nil)
+
+type HotelInfoValidatorFunc func(*HotelInfoValidator)
+
+func NewHotelInfoValidator(opts ...HotelInfoValidatorFunc) HotelInfoValidator {
+	var v HotelInfoValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+var phoneNumberRegex = regexp.MustCompile(`^\+?[0-9]+$`)
+
+func (v HotelInfoValidator) Validate(r HotelDescriptiveContentNotifRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelInfoValidator) ValidateAll(r HotelDescriptiveContentNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelInfoValidator) validate(p common.Path, r HotelDescriptiveContentNotifRQ) {
+	content := r.HotelDescriptiveContent
+	p = p.Elem("HotelDescriptiveContents").Elem("HotelDescriptiveContent")
+
+	p.Attr("HotelCode").Add(common.ValidateHotelCode(content.HotelCode))
+
+	if len(content.GuestRooms) > 0 {
+		p.Elem("FacilityInfo").Add(common.ErrUnexpectedFacilityInfo)
+	}
+
+	v.validateHotelInfo(p.Elem("HotelInfo"), content.HotelInfo)
+	v.validatePolicies(p.Elem("Policies"), content.Policies)
+	v.validateAffiliationInfo(p.Elem("AffiliationInfo").Elem("Awards"), content.AffiliationInfo)
+	v.validateContactInfo(p.Elem("ContactInfos").Elem("ContactInfo"), content.ContactInfo)
+}
+
+func (v HotelInfoValidator) validateHotelInfo(p common.Path, hotelInfo *HotelInfo) {
+	if hotelInfo == nil {
+		return
+	}
+
+	if category := hotelInfo.CategoryCode; category != nil {
+		if strings.TrimSpace(category.CodeDetail) == "" {
+			p.Elem("CategoryCodes").Elem("HotelCategory").Attr("CodeDetail").Add(common.ErrMissingCodeDetail)
+		}
+	}
+
+	v.validateDescriptions(p.Elem("Descriptions").Elem("MultimediaDescriptions"), hotelInfo.Descriptions)
+	v.validatePosition(p.Elem("Position"), hotelInfo.Position)
+
+	if hotelInfo.Services != nil {
+		for i, service := range *hotelInfo.Services {
+			if service.Code < 1 {
+				p.Elem("Services").Index("Service", i).Attr("Code").Add(common.ErrMissingCode)
+			}
+		}
+	}
+}
+
+func (v HotelInfoValidator) validateDescriptions(p common.Path, mds *MultimediaDescriptions) {
+	if mds == nil {
+		return
+	}
+
+	for i, md := range *mds {
+		p := p.Index("MultimediaDescription", i)
+		switch md.InfoCode {
+		case InformationTypeDescription, InformationTypeShortDescription:
+			if md.TextItems == nil {
+				continue
+			}
+			p.Elem("TextItems").Add(common.ValidateLanguageUniqueness(*md.TextItems))
+		case InformationTypePictures:
+			if md.ImageItems == nil {
+				continue
+			}
+			v.validateImages(p.Elem("ImageItems"), *md.ImageItems)
+		case InformationTypeVideos:
+			if md.VideoItems == nil {
+				continue
+			}
+			v.validateVideos(p.Elem("VideoItems"), *md.VideoItems)
+		}
+	}
+}
+
+func (v HotelInfoValidator) validateImages(p common.Path, images []ImageItem) {
+	for i, image := range images {
+		p := p.Index("ImageItem", i)
+		if !slices.Contains([]int{1, 2, 4, 12, 15, 22}, image.Category) {
+			p.Attr("Category").AddValue(common.ErrInvalidPictureCategoryCode(image.Category), image.Category)
+		}
+		p.Add(common.ValidateLanguageUniqueness(image.Descriptions))
+	}
+}
+
+func (v HotelInfoValidator) validateVideos(p common.Path, videos []VideoItem) {
+	for i, video := range videos {
+		p := p.Index("VideoItem", i)
+		if !slices.Contains([]int{1, 2, 4, 12, 20, 22}, video.Category) {
+			p.Attr("Category").AddValue(common.ErrInvalidVideoCategoryCode(video.Category), video.Category)
+		}
+		p.Add(common.ValidateLanguageUniqueness(video.Descriptions))
+	}
+}
+
+func (v HotelInfoValidator) validatePosition(p common.Path, position *Position) {
+	if position == nil {
+		return
+	}
+
+	if lat := position.Latitude; lat != nil && (*lat < -90 || *lat > 90) {
+		p.Attr("Latitude").AddValue(common.ErrInvalidLatitude, *lat)
+	}
+
+	if lon := position.Longitude; lon != nil && (*lon < -180 || *lon > 180) {
+		p.Attr("Longitude").AddValue(common.ErrInvalidLongitude, *lon)
+	}
+}
+
+func (v HotelInfoValidator) validatePolicies(p common.Path, policies *[]Policy) {
+	if policies == nil {
+		return
+	}
+
+	for i, policy := range *policies {
+		v.validatePolicy(p.Index("Policy", i), policy)
+	}
+}
+
+func (v HotelInfoValidator) validatePolicy(p common.Path, policy Policy) {
+	if policy.CancelPolicy != nil {
+		p.Elem("CancelPolicy").Add(common.ValidateLanguageUniqueness(*policy.CancelPolicy))
+	}
+
+	if charge := policy.CheckoutCharge; charge != nil {
+		p := p.Elem("CheckoutCharges").Elem("CheckoutCharge")
+		v.validateAmount(p, charge.Amount, charge.CurrencyCode)
+		p.Elem("Description").Add(common.ValidateLanguageUniqueness(charge.Descriptions))
+	}
+
+	if pets := policy.PetsPolicy; pets != nil {
+		p := p.Elem("PetsPolicies").Elem("PetsPolicy")
+		v.validateAmount(p, pets.NonRefundableFee, pets.CurrencyCode)
+		p.Elem("Description").Add(common.ValidateLanguageUniqueness(pets.Descriptions))
+	}
+
+	if tax := policy.TaxPolicy; tax != nil {
+		p := p.Elem("TaxPolicies").Elem("TaxPolicy")
+		v.validateAmount(p, tax.Amount, tax.CurrencyCode)
+		p.Elem("TaxDescription").Add(common.ValidateLanguageUniqueness(tax.Descriptions))
+	}
+
+	v.validateStayRequirements(p.Elem("StayRequirements"), policy.StayRequirements)
+}
+
+func (v HotelInfoValidator) validateAmount(p common.Path, amount *string, currencyCode string) {
+	if amount != nil && strings.TrimSpace(currencyCode) == "" {
+		p.Attr("CurrencyCode").Add(common.ErrMissingCurrencyCode)
+	}
+}
+
+func (v HotelInfoValidator) validateStayRequirements(p common.Path, stayRequirements *[]StayRequirement) {
+	if stayRequirements == nil {
+		return
+	}
+
+	seen := make(map[StayContext]struct{})
+	for i, stayRequirement := range *stayRequirements {
+		p := p.Index("StayRequirement", i)
+		if _, exists := seen[stayRequirement.StayContext]; exists {
+			p.Attr("StayContext").AddValue(common.ErrDuplicateStayContext, stayRequirement.StayContext)
+		}
+		seen[stayRequirement.StayContext] = struct{}{}
+
+		start, end := stayRequirement.Start, stayRequirement.End
+		if start != nil && end != nil && end.Before(*start) {
+			p.Attr("Start").AddValue(common.ErrStartAfterEnd, *start)
+		}
+	}
+}
+
+func (v HotelInfoValidator) validateAffiliationInfo(p common.Path, awards *[]Award) {
+	if awards == nil {
+		return
+	}
+
+	for i, award := range *awards {
+		if strings.TrimSpace(award.Provider) == "" {
+			p.Index("Award", i).Attr("Provider").Add(common.ErrMissingProvider)
+		}
+	}
+}
+
+func (v HotelInfoValidator) validateContactInfo(p common.Path, contactInfo *ContactInfo) {
+	if contactInfo == nil {
+		return
+	}
+
+	if contactInfo.Addresses != nil {
+		for i, address := range *contactInfo.Addresses {
+			v.validateAddress(p.Elem("Addresses").Index("Address", i), address)
+		}
+	}
+
+	if contactInfo.Phones != nil {
+		for i, phone := range *contactInfo.Phones {
+			p := p.Elem("Phones").Index("Phone", i)
+			if strings.TrimSpace(phone.PhoneTechType) == "" {
+				p.Attr("PhoneTechType").Add(common.ErrMissingPhoneTechType)
+			}
+			if !phoneNumberRegex.MatchString(phone.PhoneNumber) {
+				p.Attr("PhoneNumber").AddValue(common.ErrInvalidPhoneNumber, phone.PhoneNumber)
+			}
+		}
+	}
+
+	if contactInfo.Emails != nil {
+		for i, email := range *contactInfo.Emails {
+			p := p.Elem("Emails").Index("Email", i)
+			if strings.TrimSpace(email.EmailType) == "" {
+				p.Attr("EmailType").Add(common.ErrMissingEmailType)
+			}
+			if err := common.ValidateString(email.Value); err != nil {
+				p.Add(common.ErrInvalidEmail)
+			}
+		}
+	}
+
+	if contactInfo.URLs != nil {
+		for i, url := range *contactInfo.URLs {
+			if err := common.ValidateString(url.Value); err != nil {
+				p.Elem("URLs").Index("URL", i).Add(common.ErrInvalidURL)
+			}
+		}
+	}
+}
+
+func (v HotelInfoValidator) validateAddress(p common.Path, address Address) {
+	if err := common.ValidateString(address.AddressLine); err != nil {
+		p.Elem("AddressLine").Add(common.ErrInvalidAddressLine)
+	}
+
+	if err := common.ValidateString(address.CityName); err != nil {
+		p.Elem("CityName").Add(common.ErrInvalidCityName)
+	}
+
+	if err := common.ValidateString(address.PostalCode); err != nil {
+		p.Elem("PostalCode").Add(common.ErrInvalidPostalCode)
+	}
+
+	if address.CountryName != nil {
+		if err := common.ValidateString(address.CountryName.Code); err != nil {
+			p.Elem("CountryName").Attr("Code").Add(common.ErrInvalidCountryNameCode)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package rateplans
+
+import (
+	"encoding/xml"
+
+	"github.com/HGV/alpinebits/v_2022_10/common"
+	"github.com/HGV/alpinebits/version"
+	"github.com/HGV/x/timex"
+)
+
+type HotelRatePlanRQ struct {
+	XMLName  xml.Name      `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelRatePlanRQ"`
+	Version  string        `xml:"Version,attr"`
+	RatePlan RatePlanQuery `xml:"RatePlans>RatePlan"`
+}
+
+var _ version.HotelCodeProvider = (*HotelRatePlanRQ)(nil)
+
+func (h HotelRatePlanRQ) HotelCode() string {
+	return h.RatePlan.HotelRef.HotelCode
+}
+
+type RatePlanQuery struct {
+	DateRanges         []DateRange         `xml:"DateRange"`
+	RatePlanCandidates []RatePlanCandidate `xml:"RatePlanCandidates>RatePlanCandidate"`
+	HotelRef           HotelRef            `xml:"HotelRef"`
+}
+
+type DateRange struct {
+	Start *timex.Date `xml:"Start,attr"`
+	End   *timex.Date `xml:"End,attr"`
+}
+
+type RatePlanCandidate struct {
+	RatePlanCode string `xml:"RatePlanCode,attr,omitempty"`
+	RatePlanID   string `xml:"RatePlanID,attr,omitempty"`
+}
+
+type HotelRef struct {
+	HotelCode string `xml:"HotelCode,attr,omitempty"`
+	HotelName string `xml:"HotelName,attr,omitempty"`
+}
+
+type HotelRatePlanRS struct {
+	common.Response
+
+	XMLName   xml.Name   `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelRatePlanRS"`
+	Version   string     `xml:"Version,attr"`
+	RatePlans *RatePlans `xml:"RatePlans"`
+}
@@ -2,6 +2,7 @@ package v_2022_10
 
 import (
 	_ "embed"
+	"io"
 
 	"github.com/HGV/alpinebits/internal/schema"
 	"github.com/HGV/alpinebits/version"
@@ -16,8 +17,9 @@ type Version struct {
 
 var _ version.Version[Action] = new(Version)
 
-func NewVersion() (*Version, error) {
-	s, err := schema.Parse(schemaFile)
+func NewVersion(opts ...version.ConfigFunc) (*Version, error) {
+	config := version.NewConfig(opts...)
+	s, err := schema.ParseBackend(schemaFile, schema.Backend(config.SchemaBackend))
 	if err != nil {
 		return nil, err
 	}
@@ -28,6 +30,18 @@ func (v *Version) ValidateXML(xml string) error {
 	return v.schema.Validate(xml)
 }
 
+var _ version.BytesValidator = new(Version)
+
+func (v *Version) ValidateXMLBytes(xml []byte) error {
+	return v.schema.ValidateBytes(xml)
+}
+
+var _ version.ReaderValidator = new(Version)
+
+func (v *Version) ValidateXMLReader(r io.Reader) error {
+	return v.schema.ValidateReader(r)
+}
+
 func (v *Version) String() string {
 	return "2022-10"
 }
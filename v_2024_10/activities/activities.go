@@ -0,0 +1,126 @@
+package activities
+
+import (
+	"encoding/xml"
+
+	"github.com/HGV/alpinebits/v_2024_10/common"
+)
+
+type HotelPostEventNotifRQ struct {
+	XMLName      xml.Name      `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelPostEventNotifRQ"`
+	Version      string        `xml:"Version,attr"`
+	EventReports []EventReport `xml:"EventReports>EventReport"`
+}
+
+type EventReport struct {
+	EventSite        EventSite        `xml:"EventSites>EventSite"`
+	GeneralEventInfo GeneralEventInfo `xml:"GeneralEventInfo"`
+}
+
+type EventSite struct {
+	HotelCode string  `xml:"HotelCode,attr,omitempty"`
+	HotelName string  `xml:"HotelName,attr,omitempty"`
+	EventID   EventID `xml:"Event_ID"`
+}
+
+type EventIDType int
+
+const EventIDTypeEvent EventIDType = 18
+
+type EventID struct {
+	ID        string      `xml:"ID,attr"`
+	IDContext string      `xml:"ID_Context,attr"`
+	Type      EventIDType `xml:"Type,attr"`
+}
+
+type GeneralEventInfo struct {
+	Type          string         `xml:"Type,attr,omitempty"`
+	URL           string         `xml:"URL,attr,omitempty"`
+	Acronym       string         `xml:"Acronym,attr,omitempty"`
+	EventContacts []EventContact `xml:"EventContacts>EventContact"`
+	AttendeeInfo  *AttendeeInfo  `xml:"AttendeeInfo"`
+	Dates         []Date         `xml:"Dates>Date"`
+	Comments      []Comment      `xml:"Comments>Comment"`
+}
+
+type EventContact struct {
+	Role         string        `xml:"Role,attr,omitempty"`
+	PersonName   *PersonName   `xml:"PersonName"`
+	URL          *ContactURL   `xml:"URL"`
+	EmployeeInfo *EmployeeInfo `xml:"EmployeeInfo"`
+}
+
+type PersonName struct {
+	GivenName string `xml:"GivenName,omitempty"`
+	Surname   string `xml:"Surname"`
+}
+
+type ContactURLType string
+
+const ContactURLTypeImage ContactURLType = "Image"
+
+type ContactURL struct {
+	Type  ContactURLType `xml:"Type,attr"`
+	Value string         `xml:",chardata"`
+}
+
+type EmployeeInfo struct {
+	EmployeeID string `xml:"EmployeeId,attr,omitempty"`
+}
+
+type AttendeeInfo struct {
+	TotalQuantity         int `xml:"TotalQuantity,attr"`
+	PreRegisteredQuantity int `xml:"PreRegisteredQuantity,attr"`
+}
+
+type Date struct {
+	Start              string              `xml:"Start,attr"`
+	End                string              `xml:"End,attr,omitempty"`
+	EndDateWindow      *EndDateWindow      `xml:"EndDateWindow"`
+	LocationCategories *LocationCategories `xml:"LocationCategories"`
+}
+
+type EndDateWindow struct {
+	LatestDate string `xml:"LatestDate,attr"`
+}
+
+type LocationCategories struct {
+	Location   *Location  `xml:"Location"`
+	Categories []Category `xml:"Category"`
+}
+
+type Location struct {
+	AreaID string `xml:"AreaID,attr"`
+}
+
+type Category struct {
+	Language string `xml:"Language,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type CommentName string
+
+const (
+	CommentNameTitle       CommentName = "Title"
+	CommentNameCategory    CommentName = "Category"
+	CommentNameGallery     CommentName = "Gallery"
+	CommentNameDescription CommentName = "Description"
+)
+
+type Comment struct {
+	Name   CommentName `xml:"Name,attr"`
+	Texts  []Text      `xml:"Text"`
+	Images []string    `xml:"Image"`
+}
+
+type Text struct {
+	Language string `xml:"Language,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type HotelPostEventNotifRS struct {
+	common.Response
+
+	XMLName xml.Name `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelPostEventNotifRS"`
+	Version string   `xml:"Version,attr"`
+}
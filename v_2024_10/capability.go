@@ -0,0 +1,26 @@
+package v_2024_10
+
+type Capability string
+
+const (
+	CapabilityHotelInvCountNotifAcceptRooms                          Capability = "OTA_HotelInvCountNotif_accept_rooms"
+	CapabilityHotelInvCountNotifAcceptRoomCategories                 Capability = "OTA_HotelInvCountNotif_accept_categories"
+	CapabilityHotelInvCountNotifAcceptCompleteSet                    Capability = "OTA_HotelInvCountNotif_accept_complete_set"
+	CapabilityHotelInvCountNotifAcceptDeltas                         Capability = "OTA_HotelInvCountNotif_accept_deltas"
+	CapabilityHotelInvCountNotifAcceptOutOfOrder                     Capability = "OTA_HotelInvCountNotif_accept_out_of_order"
+	CapabilityHotelInvCountNotifAcceptOutOfMarket                    Capability = "OTA_HotelInvCountNotif_accept_out_of_market"
+	CapabilityHotelInvCountNotifAcceptClosingSeasons                 Capability = "OTA_HotelInvCountNotif_accept_closing_seasons"
+	CapabilityHotelDescriptiveContentNotifInventoryUseRooms          Capability = "OTA_HotelDescriptiveContentNotif_Inventory_use_rooms"
+	CapabilityHotelDescriptiveContentNotifInventoryOccupancyChildren Capability = "OTA_HotelDescriptiveContentNotif_Inventory_occupancy_children"
+	CapabilityHotelRatePlanNotifAcceptArrivalDOW                     Capability = "OTA_HotelRatePlanNotif_accept_ArrivalDOW"
+	CapabilityHotelRatePlanNotifAcceptDepartureDOW                   Capability = "OTA_HotelRatePlanNotif_accept_DepartureDOW"
+	CapabilityHotelRatePlanNotifAcceptRatePlanBookingRule            Capability = "OTA_HotelRatePlanNotif_accept_RatePlan_BookingRule"
+	CapabilityHotelRatePlanNotifAcceptRatePlanRoomTypeBookingRule    Capability = "OTA_HotelRatePlanNotif_accept_RatePlan_RoomType_BookingRule"
+	CapabilityHotelRatePlanNotifAcceptSupplements                    Capability = "OTA_HotelRatePlanNotif_accept_Supplements"
+	CapabilityHotelRatePlanNotifAcceptFreeNightsOffers               Capability = "OTA_HotelRatePlanNotif_accept_FreeNightsOffers"
+	CapabilityHotelRatePlanNotifAcceptFamilyOffers                   Capability = "OTA_HotelRatePlanNotif_accept_FamilyOffers"
+	CapabilityHotelRatePlanNotifAcceptOverlay                        Capability = "OTA_HotelRatePlanNotif_accept_overlay"
+	CapabilityHotelRatePlanNotifAcceptRatePlanJoin                   Capability = "OTA_HotelRatePlanNotif_accept_RatePlanJoin"
+	CapabilityHotelRatePlanNotifAcceptOfferRuleBookingOffset         Capability = "OTA_HotelRatePlanNotif_accept_OfferRule_BookingOffset"
+	CapabilityHotelRatePlanNotifAcceptOfferRuleDOWLOS                Capability = "OTA_HotelRatePlanNotif_accept_OfferRule_DOWLOS"
+)
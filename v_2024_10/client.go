@@ -0,0 +1,371 @@
+package v_2024_10
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/HGV/alpinebits/archive"
+	"github.com/HGV/alpinebits/internal/compression"
+	"github.com/HGV/alpinebits/observability"
+	"github.com/HGV/alpinebits/v_2024_10/activities"
+	"github.com/HGV/alpinebits/v_2024_10/common"
+	"github.com/HGV/alpinebits/v_2024_10/freerooms"
+	"github.com/HGV/alpinebits/v_2024_10/guestrequests"
+	"github.com/HGV/alpinebits/v_2024_10/inventory"
+	"github.com/HGV/alpinebits/v_2024_10/rateplans"
+	"github.com/HGV/alpinebits/version"
+)
+
+type (
+	Client struct {
+		config            *ClientConfig
+		client            *http.Client
+		observer          observability.Observer
+		archive           *archive.Queue
+		serverAcceptsGzip atomic.Bool
+	}
+	ClientConfig struct {
+		URL                  string
+		Username             string
+		Password             string
+		ClientID             string
+		Version              version.Version[version.Action]
+		NegotiatedVersion    map[string][]string
+		HttpClient           *http.Client
+		ServerAcceptEncoding string
+		CompressionThreshold int
+		Observer             observability.Observer
+		Archive              archive.Archive
+		ArchiveQueueSize     int
+	}
+	ClientResponse[RS any] struct {
+		*http.Response
+
+		Data          *RS
+		SendInventory bool
+		SendFreeRooms bool
+		SendRatePlans bool
+	}
+)
+
+func NewClient(config ClientConfig) (*Client, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		config:   &config,
+		client:   cmp.Or(config.HttpClient, &http.Client{}),
+		observer: cmp.Or(config.Observer, observability.Nop),
+	}
+	if config.Archive != nil {
+		c.archive = archive.NewQueue(config.Archive, config.ArchiveQueueSize)
+	}
+	c.serverAcceptsGzip.Store(compression.AcceptsGzipValue(config.ServerAcceptEncoding))
+	return c, nil
+}
+
+func (c *ClientConfig) validate() error {
+	if _, err := url.Parse(c.URL); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(c.Username) == "" {
+		return errors.New("c.Username is empty")
+	}
+
+	if strings.TrimSpace(c.Password) == "" {
+		return errors.New("c.Password is empty")
+	}
+
+	if strings.TrimSpace(c.ClientID) == "" {
+		return errors.New("c.ClientID is empty")
+	}
+
+	if c.Version == nil {
+		return errors.New("c.Version is empty")
+	}
+
+	if err := version.ValidateVersionString(c.Version.String()); err != nil {
+		return err
+	}
+
+	if len(c.NegotiatedVersion) == 0 {
+		return errors.New("c.NegotiatedVersion is empty")
+	}
+
+	return nil
+}
+
+func (c *Client) PushHotelInvCountNotif(ctx context.Context, r freerooms.HotelInvCountNotifRQ) (*ClientResponse[freerooms.HotelInvCountNotifRS], error) {
+	return sendRequest[freerooms.HotelInvCountNotifRS](ctx, c, ActionHotelInvCountNotif, r)
+}
+
+func (c *Client) PullGuestRequests(ctx context.Context, r guestrequests.ReadRQ) (*ClientResponse[guestrequests.ResRetrieveRS], error) {
+	return sendRequest[guestrequests.ResRetrieveRS](ctx, c, ActionReadGuestRequests, r)
+}
+
+func (c *Client) PushAcknowledgement(ctx context.Context, r guestrequests.NotifReportRQ) (*ClientResponse[guestrequests.NotifReportRS], error) {
+	return sendRequest[guestrequests.NotifReportRS](ctx, c, ActionNotifReportGuestRequests, r)
+}
+
+func (c *Client) PushGuestRequests(ctx context.Context, r guestrequests.HotelResNotifRQ) (*ClientResponse[guestrequests.HotelResNotifRS], error) {
+	return sendRequest[guestrequests.HotelResNotifRS](ctx, c, ActionHotelResNotifGuestRequests, r)
+}
+
+func (c *Client) PushHotelDescriptiveContentNotif(ctx context.Context, r inventory.HotelDescriptiveContentNotifRQ) (*ClientResponse[inventory.HotelDescriptiveContentNotifRS], error) {
+	return sendRequest[inventory.HotelDescriptiveContentNotifRS](ctx, c, ActionHotelDescriptiveContentNotifInventory, r)
+}
+
+func (c *Client) PushHotelInfo(ctx context.Context, r inventory.HotelDescriptiveContentNotifRQ) (*ClientResponse[inventory.HotelDescriptiveContentNotifRS], error) {
+	return sendRequest[inventory.HotelDescriptiveContentNotifRS](ctx, c, ActionHotelDescriptiveContentNotifInfo, r)
+}
+
+func (c *Client) PullHotelDescriptiveInfo(ctx context.Context, r inventory.HotelDescriptiveInfoRQ) (*ClientResponse[inventory.HotelDescriptiveInfoRS], error) {
+	return sendRequest[inventory.HotelDescriptiveInfoRS](ctx, c, ActionHotelDescriptiveInfoInventory, r)
+}
+
+func (c *Client) PullHotelInfo(ctx context.Context, r inventory.HotelDescriptiveInfoRQ) (*ClientResponse[inventory.HotelDescriptiveInfoRS], error) {
+	return sendRequest[inventory.HotelDescriptiveInfoRS](ctx, c, ActionHotelDescriptiveInfoInfo, r)
+}
+
+func (c *Client) PushRatePlans(ctx context.Context, r rateplans.HotelRatePlanNotifRQ) (*ClientResponse[rateplans.HotelRatePlanNotifRS], error) {
+	return sendRequest[rateplans.HotelRatePlanNotifRS](ctx, c, ActionHotelRatePlanNotifRatePlans, r)
+}
+
+func (c *Client) PullBaseRates(ctx context.Context, r rateplans.HotelRatePlanRQ) (*ClientResponse[rateplans.HotelRatePlanRS], error) {
+	return sendRequest[rateplans.HotelRatePlanRS](ctx, c, ActionHotelRatePlanBaseRates, r)
+}
+
+func (c *Client) PushEventReports(ctx context.Context, r activities.HotelPostEventNotifRQ) (*ClientResponse[activities.HotelPostEventNotifRS], error) {
+	return sendRequest[activities.HotelPostEventNotifRS](ctx, c, ActionHotelPostEventNotifEventReports, r)
+}
+
+func sendRequest[RS any, RQ any](ctx context.Context, c *Client, action Action, rq RQ) (*ClientResponse[RS], error) {
+	ex := exchange{
+		Observation: observability.Observation{
+			Side:     observability.SideClient,
+			Version:  c.config.Version.String(),
+			Action:   action.String(),
+			ClientID: c.config.ClientID,
+		},
+	}
+	if p, ok := any(rq).(version.HotelCodeProvider); ok {
+		ex.hotelCode = p.HotelCode()
+	}
+	ctx = c.observer.Start(ctx, ex.Observation)
+	start := time.Now()
+
+	var rs RS
+	resp, err := c.send(ctx, action, rq, &rs, &ex)
+	end := time.Now()
+
+	ex.Duration = end.Sub(start)
+	ex.Err = err
+	if ex.Outcome == "" {
+		ex.Outcome = observability.OutcomeSuccess
+		if err != nil {
+			ex.Outcome = observability.OutcomeFailed
+		}
+	}
+	c.observer.End(ctx, ex.Observation)
+
+	if c.archive != nil && ex.request != "" {
+		c.archiveExchange(ctx, ex, start, end)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return newClientResponse(resp, &rs)
+}
+
+// exchange collects what is known about a request while it is sent.
+type exchange struct {
+	observability.Observation
+
+	request   string
+	response  string
+	hotelCode string
+}
+
+func (c *Client) send(ctx context.Context, action Action, rq any, rs any, ex *exchange) (*http.Response, error) {
+	req, err := c.newRequest(ctx, action, rq, ex)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req, rs, ex)
+}
+
+func (c *Client) archiveExchange(ctx context.Context, ex exchange, start, end time.Time) {
+	err := c.archive.Store(ctx, archive.Entry{
+		Side:        archive.SideClient,
+		ClientID:    ex.ClientID,
+		Version:     ex.Version,
+		Action:      ex.Action,
+		HotelCode:   ex.hotelCode,
+		RequestedAt: start,
+		RespondedAt: end,
+		StatusCode:  ex.StatusCode,
+		Request:     ex.request,
+		Response:    ex.response,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "archiving request failed", "error", err)
+	}
+}
+
+// CloseArchive stops archiving exchanges and waits until the queued ones are
+// stored or ctx is done.
+func (c *Client) CloseArchive(ctx context.Context) error {
+	if c.archive == nil {
+		return nil
+	}
+	return c.archive.Close(ctx)
+}
+
+var ErrUnsupportedAction = errors.New("unsupported action")
+
+func (c *Client) newRequest(ctx context.Context, action Action, request any, ex *exchange) (*http.Request, error) {
+	if _, ok := c.config.NegotiatedVersion[action.HandshakeName()]; !ok {
+		ex.Outcome = observability.OutcomeRejected
+		return nil, ErrUnsupportedAction
+	}
+
+	xml, err := xml.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	ex.request = string(xml)
+	ex.RequestSize = len(xml)
+
+	if err = version.ValidateXMLBytes(c.config.Version, xml); err != nil {
+		ex.Outcome = observability.OutcomeInvalidXML
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.WriteField("action", action.String()); err != nil {
+		return nil, err
+	}
+	if err := w.WriteField("request", string(xml)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	reqBody, compressed, err := compression.GzipIf(
+		&body,
+		c.serverAcceptsGzip.Load(),
+		cmp.Or(c.config.CompressionThreshold, compression.DefaultThreshold))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth(c.config.Username, c.config.Password)
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if compressed {
+		req.Header.Set(compression.HeaderContentEncoding, compression.EncodingGzip)
+	}
+	req.Header.Set("X-AlpineBits-ClientID", c.config.ClientID)
+	req.Header.Set("X-AlpineBits-ClientProtocolVersion", c.config.Version.String())
+
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request, v any, ex *exchange) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Proxies in between may answer without the header, so only a valid
+	// AlpineBits response can tell that the server stopped accepting gzip.
+	if compression.AcceptsGzip(resp.Header) {
+		c.serverAcceptsGzip.Store(true)
+	}
+
+	ex.StatusCode = resp.StatusCode
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	ex.response = string(body)
+	ex.ResponseSize = len(body)
+
+	if sc := resp.StatusCode; sc < 200 || sc > 299 {
+		ex.Outcome = observability.OutcomeFromStatus(sc)
+		return nil, fmt.Errorf("request failed with status code: %d", sc)
+	}
+
+	if err = version.ValidateXMLBytes(c.config.Version, body); err != nil {
+		ex.Outcome = observability.OutcomeInvalidXML
+		return nil, fmt.Errorf("xml validation failed: %w", err)
+	}
+	if !compression.AcceptsGzip(resp.Header) {
+		c.serverAcceptsGzip.Store(false)
+	}
+
+	if err = xml.Unmarshal(body, v); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func newClientResponse[T any](r *http.Response, v *T) (*ClientResponse[T], error) {
+	response := &ClientResponse[T]{Response: r, Data: v}
+	response.populateCompleteSetRequests(v)
+	return response, nil
+}
+
+func (r *ClientResponse[T]) populateCompleteSetRequests(v any) {
+	if rs, ok := v.(common.Response); ok {
+		var statuses []common.Status
+
+		if rs.Errors != nil {
+			for _, e := range *rs.Errors {
+				statuses = append(statuses, e.Status)
+			}
+		}
+
+		if rs.Warnings != nil {
+			for _, w := range *rs.Warnings {
+				statuses = append(statuses, w.Status)
+			}
+		}
+
+		for _, status := range statuses {
+			switch status {
+			case common.StatusSendInventory:
+				r.SendInventory = true
+			case common.StatusSendFreeRooms:
+				r.SendFreeRooms = true
+			case common.StatusSendRatePlans:
+				r.SendRatePlans = true
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Localize returns a copy of err with the message translated into lang, a
+// language code such as "de" or "it-CH". Messages without translation, and
+// those in languages other than German and Italian, are kept in English.
+func (err Error) Localize(lang string) Error {
+	if format, ok := messages[err.messageID][baseLanguage(lang)]; ok {
+		err.Value = fmt.Sprintf(format, err.args...)
+	}
+	return err
+}
+
+// Localize translates the errors of err into lang, see Error.Localize. It
+// returns the ValidationErrors or *Error found in err's chain, or err itself
+// if there are none.
+func Localize(err error, lang string) error {
+	var errs ValidationErrors
+	var e *Error
+	switch {
+	case errors.As(err, &errs):
+		localized := make(ValidationErrors, len(errs))
+		for i, verr := range errs {
+			verr.Err = Localize(verr.Err, lang)
+			localized[i] = verr
+		}
+		return localized
+	case errors.As(err, &e):
+		localized := e.Localize(lang)
+		return &localized
+	}
+	return err
+}
+
+func baseLanguage(lang string) string {
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return strings.ToLower(lang)
+}
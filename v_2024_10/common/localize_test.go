@@ -0,0 +1,128 @@
+package common
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessages(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "errors.go", nil, 0)
+	assert.NoError(t, err)
+
+	consts := make(map[string]string)
+	formats := make(map[ErrorID]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			if lit, ok := firstStringLit(n.Values); ok {
+				consts[n.Names[0].Name] = lit
+			}
+		case *ast.CallExpr:
+			fn, ok := n.Fun.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			// the ID and the format of the message are at different positions
+			var id, format ast.Expr
+			switch fn.Name {
+			case "newError", "newErrorf":
+				id, format = n.Args[0], n.Args[2]
+			case "newMessageErrorf":
+				id, format = n.Args[1], n.Args[3]
+			default:
+				return true
+			}
+			lit, ok := format.(*ast.BasicLit)
+			if !ok {
+				return true
+			}
+			f, _ := strconv.Unquote(lit.Value)
+			switch id := id.(type) {
+			case *ast.BasicLit:
+				s, _ := strconv.Unquote(id.Value)
+				formats[ErrorID(s)] = f
+			case *ast.Ident:
+				if s, ok := consts[id.Name]; ok {
+					formats[ErrorID(s)] = f
+				}
+			}
+		}
+		return true
+	})
+	assert.NotEmpty(t, formats)
+
+	verbs := regexp.MustCompile(`%[sd]`)
+	for id, format := range formats {
+		translations, ok := messages[id]
+		if !assert.True(t, ok, "missing translations of %s", id) {
+			continue
+		}
+		for _, lang := range []string{"de", "it"} {
+			assert.Equal(t,
+				verbs.FindAllString(format, -1),
+				verbs.FindAllString(translations[lang], -1),
+				"verbs of the %s translation of %s", lang, id)
+		}
+	}
+	for id := range messages {
+		assert.Contains(t, formats, id, "unused translations")
+	}
+}
+
+func firstStringLit(exprs []ast.Expr) (string, bool) {
+	if len(exprs) == 0 {
+		return "", false
+	}
+	lit, ok := exprs[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, _ := strconv.Unquote(lit.Value)
+	return s, true
+}
+
+func TestErrorLocalize(t *testing.T) {
+	localized := ErrMissingHotelCode.Localize("de-AT")
+	assert.Equal(t, "Pflichtattribut HotelCode fehlt", localized.Value)
+	assert.Equal(t, CodeRequiredFieldMissing, localized.Code)
+	assert.ErrorIs(t, localized, ErrMissingHotelCode)
+	assert.Equal(t, "missing required attribute HotelCode", ErrMissingHotelCode.Value)
+
+	assert.Equal(t, "InvCode 1 - 2 nicht gefunden", ErrInvCodeNotFound("1 - 2").Localize("de").Value)
+	assert.Equal(t,
+		"il soggiorno minimo deve essere ≤ del soggiorno massimo, ricevuti 7 e -1",
+		ErrMinStayGratherThanMaxStay(7, -1).Localize("IT").Value)
+	assert.Equal(t,
+		"InvCode missing required attribute HotelCode nicht gefunden",
+		ErrInvCodeNotFound("missing required attribute HotelCode").Localize("de").Value)
+	assert.Equal(t, ErrDeltasNotSupported.Value, ErrDeltasNotSupported.Localize("fr").Value)
+	assert.Equal(t, "unknown hotel", Error{Value: "unknown hotel"}.Localize("de").Value)
+}
+
+func TestLocalize(t *testing.T) {
+	other := errors.New("other")
+	assert.Equal(t, other, Localize(other, "de"))
+	assert.Equal(t, ErrDeltasNotSupported.Value, Localize(ErrDeltasNotSupported, "").Error())
+
+	err := Localize(ErrDeltasNotSupported, "it")
+	assert.Equal(t, "i delta non sono supportati", err.Error())
+	assert.ErrorIs(t, err, ErrDeltasNotSupported)
+
+	missingHotelCode := ErrMissingHotelCode.Localize("de")
+	err = Localize(ValidationErrors{
+		{Path: "Inventories/@HotelCode", Err: ErrMissingHotelCode},
+		{Path: "Inventories/Inventory[1]", Err: other},
+	}, "de")
+	assert.Equal(t, ValidationErrors{
+		{Path: "Inventories/@HotelCode", Err: &missingHotelCode},
+		{Path: "Inventories/Inventory[1]", Err: other},
+	}, err)
+}
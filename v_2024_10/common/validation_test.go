@@ -0,0 +1,171 @@
+package common
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HGV/x/timex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateHotelCode(t *testing.T) {
+	assert.ErrorIs(t, ValidateHotelCode(" "), ErrMissingHotelCode)
+	assert.Nil(t, ValidateHotelCode("9000"), ErrMissingHotelCode)
+}
+
+type mockRange struct {
+	dateRange timex.DateRange
+}
+
+func (m mockRange) DateRange() timex.DateRange {
+	return m.dateRange
+}
+
+func newDate(year, month, day int) timex.Date {
+	return timex.Date{
+		Year:  year,
+		Month: time.Month(month),
+		Day:   day,
+	}
+}
+
+func TestValidateOverlaps(t *testing.T) {
+	tests := []struct {
+		name        string
+		ranges      []mockRange
+		expectError bool
+	}{
+		{
+			name:        "No ranges",
+			ranges:      []mockRange{},
+			expectError: false,
+		},
+		{
+			name: "Single range",
+			ranges: []mockRange{
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 1), End: newDate(2023, 1, 10)}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Non-overlapping ranges",
+			ranges: []mockRange{
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 1), End: newDate(2023, 1, 10)}},
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 11), End: newDate(2023, 1, 20)}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Overlapping ranges",
+			ranges: []mockRange{
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 1), End: newDate(2023, 1, 10)}},
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 9), End: newDate(2023, 1, 20)}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Adjacent ranges",
+			ranges: []mockRange{
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 1), End: newDate(2023, 1, 10)}},
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 10), End: newDate(2023, 1, 20)}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Multiple overlaps",
+			ranges: []mockRange{
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 1), End: newDate(2023, 1, 10)}},
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 9), End: newDate(2023, 1, 15)}},
+				{dateRange: timex.DateRange{Start: newDate(2023, 1, 14), End: newDate(2023, 1, 20)}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOverlaps(tt.ranges)
+			if tt.expectError {
+				assert.Error(t, err, "expected an error")
+			} else {
+				assert.NoError(t, err, "did not expect an error")
+			}
+		})
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	fn := func(p Path) {
+		p = p.Elem("Inventories")
+		p.Attr("HotelCode").Add(ErrMissingHotelCode)
+		for i, code := range []string{"DZ", "", "<EZ>"} {
+			p := p.Index("Inventory", i).Elem("StatusApplicationControl")
+			switch code {
+			case "":
+				p.Attr("InvTypeCode").Add(ErrMissingInvTypeCode)
+			case "<EZ>":
+				p.Attr("InvTypeCode").AddValue(ErrInvTypeCodeNotFound(code), code)
+			}
+		}
+	}
+
+	assert.Equal(t, ErrMissingHotelCode, Validate(fn))
+
+	err := ValidateAll(fn)
+	assert.ErrorIs(t, err, ErrMissingHotelCode)
+	assert.ErrorIs(t, err, ErrMissingInvTypeCode)
+	assert.Equal(t, ValidationErrors{
+		{Path: "Inventories/@HotelCode", Err: ErrMissingHotelCode},
+		{Path: "Inventories/Inventory[2]/StatusApplicationControl/@InvTypeCode", Err: ErrMissingInvTypeCode},
+		{Path: "Inventories/Inventory[3]/StatusApplicationControl/@InvTypeCode", Value: "<EZ>", Err: ErrInvTypeCodeNotFound("<EZ>")},
+	}, err)
+	assert.Equal(t, `Inventories/Inventory[3]/StatusApplicationControl/@InvTypeCode: `+
+		ErrInvTypeCodeNotFound("<EZ>").Error()+` (value "<EZ>")`, err.(ValidationErrors)[2].Error())
+
+	assert.NoError(t, Validate(func(p Path) { p.Add(nil) }))
+	assert.NoError(t, ValidateAll(func(p Path) { p.Add(nil) }))
+}
+
+func TestValidateStopsAtFirstError(t *testing.T) {
+	var walked bool
+	err := Validate(func(p Path) {
+		p.Add(ErrMissingHotelCode)
+		walked = true
+	})
+	assert.Equal(t, ErrMissingHotelCode, err)
+	assert.False(t, walked)
+
+	assert.PanicsWithValue(t, "other", func() {
+		_ = Validate(func(p Path) { panic("other") })
+	})
+}
+
+func TestResponse_AppendValidationErrors(t *testing.T) {
+	var resp Response
+	resp.AppendValidationErrors(ValidationErrors{
+		{Path: "Inventories/@HotelCode", Err: ErrMissingHotelCode},
+		{Path: "Inventories/Inventory[1]", Value: "<x>", Err: errors.New("invalid")},
+	})
+
+	assert.Equal(t, &[]Error{
+		{Type: ErrorWarningTypeApplicationError, Code: CodeRequiredFieldMissing, ID: "missing_hotel_code", Value: "Inventories/@HotelCode: " + ErrMissingHotelCode.Error()},
+		{Type: ErrorWarningTypeApplicationError, Code: CodeInvalidValue, Value: `Inventories/Inventory[1]: invalid (value "&lt;x&gt;")`},
+	}, resp.Errors)
+}
+
+func TestResponse_AppendError(t *testing.T) {
+	var resp Response
+	resp.AppendError(*ErrInvTypeCodeNotFound("A&B<x"))
+	resp.AppendWarning(*NewWarning("room <DZ> & more"))
+
+	b, err := xml.Marshal(resp)
+	assert.NoError(t, err)
+
+	var rs Response
+	assert.NoError(t, xml.Unmarshal(b, &rs))
+	assert.Equal(t, "inv type code not found A&amp;B&lt;x", (*rs.Errors)[0].Value)
+	assert.Equal(t, "room &lt;DZ&gt; &amp; more", (*rs.Warnings)[0].Value)
+	assert.Equal(t, "inv type code not found A&B<x", ErrInvTypeCodeNotFound("A&B<x").Error())
+}
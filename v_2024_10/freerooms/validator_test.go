@@ -0,0 +1,119 @@
+package freerooms
+
+import (
+	"encoding/xml"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/HGV/alpinebits/internal/schema"
+	"github.com/HGV/alpinebits/v_2024_10/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelInvCountNotifValidator_Validate(t *testing.T) {
+	tests := []struct {
+		file      string
+		validator HotelInvCountNotifValidator
+	}{
+		{
+			file: "test/data/FreeRooms-OTA_HotelInvCountNotifRQ-closing_seasons.xml",
+			validator: NewHotelInvCountNotifValidator(
+				WithCompleteSet(),
+				WithClosingSeasons(),
+			),
+		},
+		{
+			file: "test/data/FreeRooms-OTA_HotelInvCountNotifRQ-delta.xml",
+			validator: NewHotelInvCountNotifValidator(
+				WithDeltas(),
+			),
+		},
+		{
+			file: "test/data/FreeRooms-OTA_HotelInvCountNotifRQ-empty.xml",
+			validator: NewHotelInvCountNotifValidator(
+				WithCompleteSet(),
+			),
+		},
+		{
+			file: "test/data/FreeRooms-OTA_HotelInvCountNotifRQ.xml",
+			validator: NewHotelInvCountNotifValidator(
+				WithCompleteSet(),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(tt.file)
+			if err != nil {
+				assert.NoError(t, err, "Failed to read file %s", tt.file)
+			}
+
+			var rq HotelInvCountNotifRQ
+			if err := xml.Unmarshal(data, &rq); err != nil {
+				assert.NoError(t, err, "Failed to unmarshal data from file %s", tt.file)
+			}
+
+			assert.IsType(t, HotelInvCountNotifRQ{}, rq)
+			assert.NoError(t, tt.validator.Validate(rq))
+		})
+	}
+}
+
+func TestHotelInvCountNotifValidator_ValidateCompleteSetNotSupported(t *testing.T) {
+	data, err := os.ReadFile("test/data/FreeRooms-OTA_HotelInvCountNotifRQ.xml")
+	assert.NoError(t, err)
+
+	var rq HotelInvCountNotifRQ
+	assert.NoError(t, xml.Unmarshal(data, &rq))
+
+	validator := NewHotelInvCountNotifValidator(WithDeltas())
+	assert.ErrorIs(t, validator.Validate(rq), common.ErrCompleteSetNotSupported)
+}
+
+func TestHotelInvCountNotifValidator_ValidateAll(t *testing.T) {
+	data, err := os.ReadFile("test/data/FreeRooms-OTA_HotelInvCountNotifRQ.xml")
+	assert.NoError(t, err)
+
+	var rq HotelInvCountNotifRQ
+	assert.NoError(t, xml.Unmarshal(data, &rq))
+
+	validator := NewHotelInvCountNotifValidator(WithRooms())
+	assert.ErrorIs(t, validator.Validate(rq), common.ErrCompleteSetNotSupported)
+
+	var errs common.ValidationErrors
+	assert.ErrorAs(t, validator.ValidateAll(rq), &errs)
+	assert.Equal(t, common.ValidationErrors{
+		{Path: "UniqueID/@Instance", Value: "CompleteSet", Err: common.ErrCompleteSetNotSupported},
+		{Path: "Inventories/Inventory[1]/StatusApplicationControl/@InvCode", Err: common.ErrMissingInvCode},
+		{Path: "Inventories/Inventory[1]/InvCounts/InvCount[1]/@Count", Value: "3", Err: common.ErrInvalidCount(3)},
+		{Path: "Inventories/Inventory[2]/StatusApplicationControl/@InvCode", Err: common.ErrMissingInvCode},
+		{Path: "Inventories/Inventory[3]/StatusApplicationControl/@InvCode", Err: common.ErrMissingInvCode},
+	}, errs)
+}
+
+func TestHotelInvCountNotifValidator_MissingStatusApplicationControl(t *testing.T) {
+	data, err := os.ReadFile("test/data/FreeRooms-OTA_HotelInvCountNotifRQ-delta.xml")
+	assert.NoError(t, err)
+	doc := strings.Replace(string(data), `<StatusApplicationControl Start="2020-08-21" End="2020-08-30" InvTypeCode="DOUBLE" />`, "", 1)
+
+	xsd, err := os.ReadFile("../alpinebits.xsd")
+	assert.NoError(t, err)
+	s, err := schema.Parse(xsd)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate(doc))
+
+	var rq HotelInvCountNotifRQ
+	assert.NoError(t, xml.Unmarshal([]byte(doc), &rq))
+
+	validator := NewHotelInvCountNotifValidator()
+	assert.Equal(t, common.ErrDeltasNotSupported, validator.Validate(rq))
+
+	var errs common.ValidationErrors
+	assert.ErrorAs(t, validator.ValidateAll(rq), &errs)
+	assert.Equal(t, common.ValidationErrors{
+		{Path: "UniqueID", Err: common.ErrDeltasNotSupported},
+		{Path: "Inventories/Inventory[2]/StatusApplicationControl", Err: common.ErrMissingStatusApplicationControl},
+	}, errs)
+}
@@ -0,0 +1,163 @@
+package guestrequests
+
+import (
+	"encoding/xml"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/HGV/alpinebits/internal/schema"
+	"github.com/HGV/alpinebits/v_2024_10/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResRetrieveValidator_Validate(t *testing.T) {
+	tests := []struct {
+		file      string
+		validator ResRetrieveValidator
+	}{
+		{
+			file:      "test/data/GuestRequests-OTA_ResRetrieveRS-cancellation.xml",
+			validator: NewResRetrieveValidator(),
+		},
+		{
+			file:      "test/data/GuestRequests-OTA_ResRetrieveRS-error.xml",
+			validator: NewResRetrieveValidator(),
+		},
+		{
+			file:      "test/data/GuestRequests-OTA_ResRetrieveRS-request-with-roomtype.xml",
+			validator: NewResRetrieveValidator(),
+		},
+		{
+			file:      "test/data/GuestRequests-OTA_ResRetrieveRS-reservation-empty.xml",
+			validator: NewResRetrieveValidator(),
+		},
+		{
+			file:      "test/data/GuestRequests-OTA_ResRetrieveRS-reservation.xml",
+			validator: NewResRetrieveValidator(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(tt.file)
+			if err != nil {
+				assert.NoError(t, err, "Failed to read file %s", tt.file)
+			}
+
+			var rq ResRetrieveRS
+			if err := xml.Unmarshal(data, &rq); err != nil {
+				assert.NoError(t, err, "Failed to unmarshal data from file %s", tt.file)
+			}
+
+			assert.IsType(t, ResRetrieveRS{}, rq)
+			assert.NoError(t, tt.validator.Validate(rq))
+		})
+	}
+}
+
+func TestResRetrieveRS_Unmarshal(t *testing.T) {
+	data, err := os.ReadFile("test/data/GuestRequests-OTA_ResRetrieveRS-reservation.xml")
+	assert.NoError(t, err)
+
+	var rs ResRetrieveRS
+	assert.NoError(t, xml.Unmarshal(data, &rs))
+
+	res := (*rs.HotelReservations)[0]
+	assert.NotNil(t, res.LastModifyDateTime)
+	assert.True(t, *res.RoomStayReservation)
+	assert.Equal(t, []SpecialRequest{{
+		RequestCode: "PETS",
+		CodeContext: SpecialRequestCodeContextAlpineBits,
+		Text:        &Text{Value: "We will bring our dog."},
+	}}, *res.ResGlobalInfo.SpecialRequests)
+}
+
+func TestResRetrieveValidator_ValidateServices(t *testing.T) {
+	data, err := os.ReadFile("test/data/GuestRequests-OTA_ResRetrieveRS-reservation.xml")
+	assert.NoError(t, err)
+
+	var rs ResRetrieveRS
+	assert.NoError(t, xml.Unmarshal(data, &rs))
+
+	res := (*rs.HotelReservations)[0]
+	assert.Len(t, *res.Services, 1)
+	assert.Equal(t, "bigsuite", (*res.RoomStays)[0].RoomRate.RoomTypeCode)
+	assert.Equal(t, []ServiceRPH{{RPH: "1"}}, (*res.RoomStays)[0].ServiceRPHs)
+
+	(*res.RoomStays)[0].ServiceRPHs[0].RPH = "2"
+	assert.Equal(t, common.ErrServiceRPHNotFound("2"), NewResRetrieveValidator().Validate(rs))
+}
+
+func TestHotelResNotifValidator_Validate(t *testing.T) {
+	file := "test/data/GuestRequests-OTA_HotelResNotifRQ.xml"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		assert.NoError(t, err, "Failed to read file %s", file)
+	}
+
+	var rq HotelResNotifRQ
+	if err := xml.Unmarshal(data, &rq); err != nil {
+		assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+	}
+
+	assert.Len(t, rq.HotelReservations, 1)
+	assert.Equal(t, "6b34fe24ac2ff810", rq.HotelReservations[0].UniqueID.ID)
+
+	assert.NoError(t, NewHotelResNotifValidator().Validate(rq))
+	assert.NoError(t, NewHotelResNotifValidator(WithRoomTypeCodes(map[string]struct{}{"bigsuite": {}})).Validate(rq))
+
+	v := NewHotelResNotifValidator(WithRoomTypeCodes(map[string]struct{}{"DZ": {}}))
+	assert.EqualError(t, v.Validate(rq), common.ErrInvCodeNotFound("bigsuite").Error())
+}
+
+func TestHotelResNotifRS_Unmarshal(t *testing.T) {
+	file := "test/data/GuestRequests-OTA_HotelResNotifRS.xml"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		assert.NoError(t, err, "Failed to read file %s", file)
+	}
+
+	var rs HotelResNotifRS
+	if err := xml.Unmarshal(data, &rs); err != nil {
+		assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+	}
+
+	assert.NotNil(t, rs.Success)
+	assert.Equal(t, "6b34fe24ac2ff810", (*rs.Warnings)[0].RecordID)
+	assert.Equal(t, UniqueIDTypeReservation, (*rs.HotelReservations)[0].UniqueID.Type)
+
+	xsd, err := os.ReadFile("../alpinebits.xsd")
+	if err != nil {
+		assert.NoError(t, err, "Failed to read schema")
+	}
+	s, err := schema.Parse(xsd)
+	assert.NoError(t, err)
+
+	b, err := xml.Marshal(rs)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate(string(b)))
+}
+
+func TestResRetrieveValidator_MissingCustomerAndResGlobalInfo(t *testing.T) {
+	data, err := os.ReadFile("test/data/GuestRequests-OTA_ResRetrieveRS-request-with-roomtype.xml")
+	assert.NoError(t, err)
+	doc := regexp.MustCompile(`(?s)<ResGuests>.*</ResGuests>|<ResGlobalInfo>.*</ResGlobalInfo>`).ReplaceAllString(string(data), "")
+
+	xsd, err := os.ReadFile("../alpinebits.xsd")
+	assert.NoError(t, err)
+	s, err := schema.Parse(xsd)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate(doc))
+
+	var rs ResRetrieveRS
+	assert.NoError(t, xml.Unmarshal([]byte(doc), &rs))
+
+	validator := NewResRetrieveValidator(WithRoomTypeCodes(map[string]struct{}{"B": {}}))
+	assert.Equal(t, common.ErrInvCodeNotFound("A"), validator.Validate(rs))
+
+	err = validator.ValidateAll(rs)
+	assert.ErrorIs(t, err, common.IDInvCodeNotFound)
+	assert.ErrorIs(t, err, common.ErrMissingCustomer)
+	assert.ErrorIs(t, err, common.ErrMissingResGlobalInfo)
+}
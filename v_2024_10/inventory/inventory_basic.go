@@ -0,0 +1,145 @@
+package inventory
+
+import (
+	"encoding/xml"
+
+	"github.com/HGV/alpinebits/internal"
+	"github.com/HGV/alpinebits/v_2024_10/common"
+	"github.com/HGV/alpinebits/version"
+)
+
+type HotelDescriptiveContentNotifRQ struct {
+	XMLName                 xml.Name                `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelDescriptiveContentNotifRQ"`
+	Version                 string                  `xml:"Version,attr"`
+	HotelDescriptiveContent HotelDescriptiveContent `xml:"HotelDescriptiveContents>HotelDescriptiveContent"`
+}
+
+var _ version.HotelCodeProvider = (*HotelDescriptiveContentNotifRQ)(nil)
+
+func (h HotelDescriptiveContentNotifRQ) HotelCode() string {
+	return h.HotelDescriptiveContent.HotelCode
+}
+
+type HotelDescriptiveContent struct {
+	HotelCode       string       `xml:"HotelCode,attr"`
+	HotelName       string       `xml:"HotelName,attr"`
+	AreaID          int          `xml:"AreaID,attr,omitempty"`
+	HotelInfo       *HotelInfo   `xml:"HotelInfo"`
+	GuestRooms      []GuestRoom  `xml:"FacilityInfo>GuestRooms>GuestRoom"`
+	Policies        *[]Policy    `xml:"Policies>Policy"`
+	AffiliationInfo *[]Award     `xml:"AffiliationInfo>Awards>Award"`
+	ContactInfo     *ContactInfo `xml:"ContactInfos>ContactInfo"`
+}
+
+type GuestRoom struct {
+	Code                   string                  `xml:"Code,attr"`
+	MinOccupancy           int                     `xml:"MinOccupancy,attr,omitempty"`
+	MaxOccupancy           int                     `xml:"MaxOccupancy,attr,omitempty"`
+	MaxChildOccupancy      int                     `xml:"MaxChildOccupancy,attr,omitempty"`
+	OldCode                string                  `xml:"ID,attr,omitempty"`
+	TypeRoom               TypeRoom                `xml:"TypeRoom"`
+	Amenities              *[]Amenity              `xml:"Amenities>Amenity"`
+	MultimediaDescriptions *MultimediaDescriptions `xml:"MultimediaDescriptions>MultimediaDescription"`
+}
+
+func (g GuestRoom) MinFull() int {
+	return internal.CalculateMinFull(g.MaxChildOccupancy, g.TypeRoom.StandardOccupancy, g.MaxOccupancy)
+}
+
+type TypeRoom struct {
+	StandardOccupancy      int    `xml:"StandardOccupancy,attr,omitempty"`
+	RoomClassificationCode int    `xml:"RoomClassificationCode,attr,omitempty"`
+	RoomType               int    `xml:"RoomType,attr,omitempty"`
+	Size                   int    `xml:"Size,attr,omitempty"`
+	RoomID                 string `xml:"RoomID,attr,omitempty"`
+}
+
+type Amenity struct {
+	RoomAmenityCode int `xml:"RoomAmenityCode,attr"`
+}
+
+type MultimediaDescriptions []MultimediaDescription
+
+func (mds MultimediaDescriptions) LongNames() []common.Description {
+	for _, md := range mds {
+		if md.InfoCode == InformationTypeLongName && md.TextItems != nil {
+			return *md.TextItems
+		}
+	}
+	return nil
+}
+
+func (mds MultimediaDescriptions) Descriptions() []common.Description {
+	for _, md := range mds {
+		if md.InfoCode == InformationTypeDescription && md.TextItems != nil {
+			return *md.TextItems
+		}
+	}
+	return nil
+}
+
+func (mds MultimediaDescriptions) ShortDescriptions() []common.Description {
+	for _, md := range mds {
+		if md.InfoCode == InformationTypeShortDescription && md.TextItems != nil {
+			return *md.TextItems
+		}
+	}
+	return nil
+}
+
+func (mds MultimediaDescriptions) Videos() []VideoItem {
+	for _, md := range mds {
+		if md.InfoCode == InformationTypeVideos && md.VideoItems != nil {
+			return *md.VideoItems
+		}
+	}
+	return nil
+}
+
+func (mds MultimediaDescriptions) Pictures() []ImageItem {
+	for _, md := range mds {
+		if md.InfoCode == InformationTypePictures && md.ImageItems != nil {
+			return *md.ImageItems
+		}
+	}
+	return nil
+}
+
+type MultimediaDescription struct {
+	InfoCode   InformationType       `xml:"InfoCode,attr"`
+	TextItems  *[]common.Description `xml:"TextItems>TextItem>Description"`
+	ImageItems *[]ImageItem          `xml:"ImageItems>ImageItem"`
+	VideoItems *[]VideoItem          `xml:"VideoItems>VideoItem"`
+}
+
+type InformationType int
+
+const (
+	InformationTypeDescription      InformationType = 1
+	InformationTypeShortDescription InformationType = 17
+	InformationTypePictures         InformationType = 23
+	InformationTypeVideos           InformationType = 24
+	InformationTypeLongName         InformationType = 25
+)
+
+type ImageItem struct {
+	Category     int                  `xml:"Category,attr"`
+	ImageFormat  ImageFormat          `xml:"ImageFormat"`
+	Descriptions []common.Description `xml:"Description,omitempty"`
+}
+
+type ImageFormat struct {
+	CopyrightNotice string     `xml:"CopyrightNotice,attr,omitempty"`
+	SourceID        string     `xml:"SourceID,attr,omitempty"`
+	Title           string     `xml:"Title,attr,omitempty"`
+	ApplicableStart string     `xml:"ApplicableStart,attr,omitempty"`
+	ApplicableEnd   string     `xml:"ApplicableEnd,attr,omitempty"`
+	URL             common.URL `xml:"URL"`
+}
+
+type HotelDescriptiveContentNotifRS struct {
+	common.Response
+
+	XMLName xml.Name `xml:"http://www.opentravel.org/OTA/2003/05 OTA_HotelDescriptiveContentNotifRS"`
+	Version string   `xml:"Version,attr"`
+}
@@ -0,0 +1,209 @@
+package inventory
+
+import (
+	"github.com/HGV/alpinebits/v_2024_10/common"
+	"github.com/HGV/x/timex"
+)
+
+type HotelInfo struct {
+	HotelStatusCode int                     `xml:"HotelStatusCode,attr,omitempty"`
+	CategoryCode    *HotelCategory          `xml:"CategoryCodes>HotelCategory"`
+	Descriptions    *MultimediaDescriptions `xml:"Descriptions>MultimediaDescriptions>MultimediaDescription"`
+	Position        *Position               `xml:"Position"`
+	Services        *[]HotelInfoService     `xml:"Services>Service"`
+}
+
+type HotelCategory struct {
+	Code       string `xml:"Code,attr,omitempty"`
+	CodeDetail string `xml:"CodeDetail,attr"`
+}
+
+type Position struct {
+	Altitude                  *float64                  `xml:"Altitude,attr"`
+	AltitudeUnitOfMeasureCode AltitudeUnitOfMeasureCode `xml:"AltitudeUnitOfMeasureCode,attr,omitempty"`
+	Latitude                  *float64                  `xml:"Latitude,attr"`
+	Longitude                 *float64                  `xml:"Longitude,attr"`
+}
+
+type AltitudeUnitOfMeasureCode int
+
+const AltitudeUnitOfMeasureCodeMeter AltitudeUnitOfMeasureCode = 3
+
+type HotelInfoService struct {
+	Code          int        `xml:"Code,attr"`
+	ProximityCode int        `xml:"ProximityCode,attr,omitempty"`
+	Included      *bool      `xml:"Included,attr"`
+	Features      *[]Feature `xml:"Features>Feature"`
+}
+
+type Feature struct {
+	AccessibleCode int `xml:"AccessibleCode,attr"`
+}
+
+type VideoItem struct {
+	Category     int                  `xml:"Category,attr"`
+	VideoFormat  VideoFormat          `xml:"VideoFormat"`
+	Descriptions []common.Description `xml:"Description,omitempty"`
+}
+
+type VideoFormat struct {
+	CopyrightNotice string     `xml:"CopyrightNotice,attr,omitempty"`
+	SourceID        string     `xml:"SourceID,attr,omitempty"`
+	Title           string     `xml:"Title,attr,omitempty"`
+	ApplicableStart string     `xml:"ApplicableStart,attr,omitempty"`
+	URL             common.URL `xml:"URL"`
+}
+
+type Policy struct {
+	CancelPolicy           *[]common.Description   `xml:"CancelPolicy>CancelPenalty>PenaltyDescription>Text"`
+	CheckoutCharge         *CheckoutCharge         `xml:"CheckoutCharges>CheckoutCharge"`
+	PetsPolicy             *PetsPolicy             `xml:"PetsPolicies>PetsPolicy"`
+	TaxPolicy              *TaxPolicy              `xml:"TaxPolicies>TaxPolicy"`
+	GuaranteePaymentPolicy *GuaranteePaymentPolicy `xml:"GuaranteePaymentPolicy>GuaranteePayment"`
+	PolicyInfo             *PolicyInfo             `xml:"PolicyInfo"`
+	StayRequirements       *[]StayRequirement      `xml:"StayRequirements>StayRequirement"`
+}
+
+type CheckoutCharge struct {
+	Amount        *string              `xml:"Amount,attr"`
+	CurrencyCode  string               `xml:"CurrencyCode,attr,omitempty"`
+	DecimalPlaces int                  `xml:"DecimalPlaces,attr,omitempty"`
+	Descriptions  []common.Description `xml:"Description>Text"`
+}
+
+type PetsPolicy struct {
+	MaxPetQuantity   *int                 `xml:"MaxPetQuantity,attr"`
+	NonRefundableFee *string              `xml:"NonRefundableFee,attr"`
+	ChargeCode       int                  `xml:"ChargeCode,attr,omitempty"`
+	CurrencyCode     string               `xml:"CurrencyCode,attr,omitempty"`
+	DecimalPlaces    int                  `xml:"DecimalPlaces,attr,omitempty"`
+	Descriptions     []common.Description `xml:"Description>Text"`
+}
+
+type TaxPolicy struct {
+	Amount          *string              `xml:"Amount,attr"`
+	CurrencyCode    string               `xml:"CurrencyCode,attr,omitempty"`
+	DecimalPlaces   int                  `xml:"DecimalPlaces,attr,omitempty"`
+	Code            int                  `xml:"Code,attr,omitempty"`
+	ChargeFrequency int                  `xml:"ChargeFrequency,attr,omitempty"`
+	ChargeUnit      int                  `xml:"ChargeUnit,attr,omitempty"`
+	Descriptions    []common.Description `xml:"TaxDescription>Text"`
+}
+
+type GuaranteePaymentPolicy struct {
+	AcceptedPayments []AcceptedPayment `xml:"AcceptedPayments>AcceptedPayment"`
+	AmountPercent    *AmountPercent    `xml:"AmountPercent"`
+	Deadline         *Deadline         `xml:"Deadline"`
+}
+
+type AcceptedPayment struct {
+	BankAcct    *BankAcct    `xml:"BankAcct"`
+	Cash        *Cash        `xml:"Cash"`
+	PaymentCard *PaymentCard `xml:"PaymentCard"`
+}
+
+type BankAcct struct {
+	BankAcctName   string `xml:"BankAcctName"`
+	BankAcctNumber string `xml:"BankAcctNumber>PlainText"`
+	BankID         string `xml:"BankID>PlainText"`
+}
+
+type Cash struct {
+	CashIndicator bool `xml:"CashIndicator,attr"`
+}
+
+type PaymentCard struct {
+	CardCode string `xml:"CardCode,attr,omitempty"`
+	CardType string `xml:"CardType,omitempty"`
+}
+
+type AmountPercent struct {
+	Percent string `xml:"Percent,attr"`
+}
+
+type Deadline struct {
+	OffsetDropTime       string `xml:"OffsetDropTime,attr"`
+	OffsetTimeUnit       string `xml:"OffsetTimeUnit,attr"`
+	OffsetUnitMultiplier int    `xml:"OffsetUnitMultiplier,attr"`
+}
+
+type PolicyInfo struct {
+	MinGuestAge *int `xml:"MinGuestAge,attr"`
+}
+
+type StayRequirement struct {
+	StayContext StayContext `xml:"StayContext,attr,omitempty"`
+	Start       *timex.Time `xml:"Start,attr"`
+	End         *timex.Time `xml:"End,attr"`
+}
+
+type StayContext string
+
+const (
+	StayContextCheckin  StayContext = "Checkin"
+	StayContextCheckout StayContext = "Checkout"
+)
+
+type Award struct {
+	Rating                 string `xml:"Rating,attr"`
+	Provider               string `xml:"Provider,attr"`
+	RatingSymbol           string `xml:"RatingSymbol,attr,omitempty"`
+	OfficialAppointmentInd *bool  `xml:"OfficialAppointmentInd,attr"`
+}
+
+type ContactInfo struct {
+	Location    ContactLocation `xml:"Location,attr,omitempty"`
+	Addresses   *[]Address      `xml:"Addresses>Address"`
+	Phones      *[]Phone        `xml:"Phones>Phone"`
+	Emails      *[]Email        `xml:"Emails>Email"`
+	URLs        *[]ContactURL   `xml:"URLs>URL"`
+	CompanyName *string         `xml:"CompanyName"`
+}
+
+type ContactLocation int
+
+const ContactLocationHotel ContactLocation = 6
+
+type Address struct {
+	Language    string       `xml:"Language,attr,omitempty"`
+	AddressLine string       `xml:"AddressLine"`
+	CityName    string       `xml:"CityName"`
+	PostalCode  string       `xml:"PostalCode"`
+	StateProv   *StateProv   `xml:"StateProv"`
+	CountryName *CountryName `xml:"CountryName"`
+}
+
+type StateProv struct {
+	StateCode string `xml:"StateCode,attr"`
+}
+
+type CountryName struct {
+	Code string `xml:"Code,attr"`
+}
+
+type Phone struct {
+	PhoneTechType string `xml:"PhoneTechType,attr"`
+	PhoneNumber   string `xml:"PhoneNumber,attr"`
+}
+
+type Email struct {
+	EmailType string `xml:"EmailType,attr"`
+	Value     string `xml:",chardata"`
+}
+
+type ContactURL struct {
+	ID    ContactURLType `xml:"ID,attr,omitempty"`
+	Value string         `xml:",chardata"`
+}
+
+type ContactURLType string
+
+const (
+	ContactURLTypeWebsite     ContactURLType = "WEBSITE"
+	ContactURLTypeTrustYou    ContactURLType = "TRUSTYOU"
+	ContactURLTypeTripAdvisor ContactURLType = "TRIPADVISOR"
+	ContactURLTypeTwitter     ContactURLType = "TWITTER"
+	ContactURLTypeFacebook    ContactURLType = "FACEBOOK"
+	ContactURLTypeInstagram   ContactURLType = "INSTAGRAM"
+	ContactURLTypeYouTube     ContactURLType = "YOUTUBE"
+)
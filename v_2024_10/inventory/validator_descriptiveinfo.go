@@ -0,0 +1,32 @@
+package inventory
+
+import (
+	"github.com/HGV/alpinebits/v_2024_10/common"
+)
+
+type HotelDescriptiveInfoValidator struct{}
+
+var _ common.Validatable[HotelDescriptiveInfoRQ] = (*HotelDescriptiveInfoValidator)(nil)
+var _ common.ValidatableAll[HotelDescriptiveInfoRQ] = (*HotelDescriptiveInfoValidator)(nil)
+
+type HotelDescriptiveInfoValidatorFunc func(*HotelDescriptiveInfoValidator)
+
+func NewHotelDescriptiveInfoValidator(opts ...HotelDescriptiveInfoValidatorFunc) HotelDescriptiveInfoValidator {
+	var v HotelDescriptiveInfoValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+func (v HotelDescriptiveInfoValidator) Validate(r HotelDescriptiveInfoRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelDescriptiveInfoValidator) ValidateAll(r HotelDescriptiveInfoRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelDescriptiveInfoValidator) validate(p common.Path, r HotelDescriptiveInfoRQ) {
+	p.Elem("HotelDescriptiveInfos").Elem("HotelDescriptiveInfo").Attr("HotelCode").Add(common.ValidateHotelCode(r.HotelCode()))
+}
@@ -0,0 +1,80 @@
+package inventory
+
+import (
+	"encoding/xml"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/HGV/alpinebits/internal/schema"
+	"github.com/HGV/alpinebits/v_2024_10/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelDescriptiveContentNotifValidator_Validate(t *testing.T) {
+	tests := []struct {
+		file      string
+		validator HotelDescriptiveContentNotifValidator
+	}{
+		{
+			file:      "test/data/Inventory-OTA_HotelDescriptiveContentNotifRQ-delete-all.xml",
+			validator: NewHotelDescriptiveContentNotifValidator(),
+		},
+		{
+			file:      "test/data/Inventory-OTA_HotelDescriptiveContentNotifRQ-with-roomtype.xml",
+			validator: NewHotelDescriptiveContentNotifValidator(),
+		},
+		{
+			file: "test/data/Inventory-OTA_HotelDescriptiveContentNotifRQ.xml",
+			validator: NewHotelDescriptiveContentNotifValidator(
+				WithOccupancyChildren(),
+				WithRooms(),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(tt.file)
+			if err != nil {
+				assert.NoError(t, err, "Failed to read file %s", tt.file)
+			}
+
+			var rq HotelDescriptiveContentNotifRQ
+			if err := xml.Unmarshal(data, &rq); err != nil {
+				assert.NoError(t, err, "Failed to unmarshal data from file %s", tt.file)
+			}
+
+			assert.IsType(t, HotelDescriptiveContentNotifRQ{}, rq)
+			assert.NoError(t, tt.validator.Validate(rq))
+		})
+	}
+}
+
+func TestHotelDescriptiveContentNotifValidator_MissingItems(t *testing.T) {
+	data, err := os.ReadFile("test/data/Inventory-OTA_HotelDescriptiveContentNotifRQ.xml")
+	assert.NoError(t, err)
+
+	xsd, err := os.ReadFile("../alpinebits.xsd")
+	assert.NoError(t, err)
+	s, err := schema.Parse(xsd)
+	assert.NoError(t, err)
+
+	for _, expr := range []string{
+		`(?s)<MultimediaDescriptions>.*</MultimediaDescriptions>`,
+		`(?s)<TextItems>.*?</TextItems>`,
+		`(?s)<ImageItems>.*</ImageItems>`,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			doc := regexp.MustCompile(expr).ReplaceAllString(string(data), "")
+			assert.NoError(t, s.Validate(doc))
+
+			var rq HotelDescriptiveContentNotifRQ
+			assert.NoError(t, xml.Unmarshal([]byte(doc), &rq))
+
+			validator := NewHotelDescriptiveContentNotifValidator()
+			assert.Equal(t, common.ErrChildOccupancyNotSupported, validator.Validate(rq))
+			assert.ErrorIs(t, validator.ValidateAll(rq), common.ErrChildOccupancyNotSupported)
+		})
+	}
+}
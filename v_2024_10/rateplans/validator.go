@@ -0,0 +1,1066 @@
+package rateplans
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"slices"
+
+	"github.com/HGV/alpinebits/internal"
+	"github.com/HGV/alpinebits/v_2024_10/common"
+	"github.com/HGV/x/slicesx"
+)
+
+type RatePlanOccupancySettings struct {
+	Min    *int
+	Max    *int
+	MinAge *int
+}
+
+type RoomTypeOccupancySettings struct {
+	Min int
+	Std int
+	Max int
+}
+
+type MasterRatePlan struct {
+	ChargeType   RatePlanChargeType
+	DerivedPlans map[string]MealPlan
+}
+
+type HotelRatePlanNotifValidator struct {
+	supportsArrivalDOW             bool
+	supportsDepartureDOW           bool
+	ratePlanMapping                map[string]MasterRatePlan
+	supportsRatePlanJoin           bool
+	adultOccupancy                 RatePlanOccupancySettings
+	childOccupancy                 *RatePlanOccupancySettings
+	supportsOverlay                bool
+	supportsGenericBookingRules    bool
+	supportsRoomTypeBokingRules    bool
+	roomTypeMapping                map[string]RoomTypeOccupancySettings
+	supplementMapping              map[string]struct{}
+	supportsSupplements            bool
+	supportsFreeNightOffer         bool
+	supportsFamilyOffer            bool
+	supportsOfferRuleBookingOffset bool
+	supportsOfferRuleDOWLOS        bool
+	ratePlanNotifType              RatePlanNotifType
+}
+
+var _ common.Validatable[HotelRatePlanNotifRQ] = (*HotelRatePlanNotifValidator)(nil)
+var _ common.ValidatableAll[HotelRatePlanNotifRQ] = (*HotelRatePlanNotifValidator)(nil)
+
+type HotelRatePlanNotifValidatorFunc func(*HotelRatePlanNotifValidator)
+
+func NewHotelRatePlanNotifValidator(opts ...HotelRatePlanNotifValidatorFunc) HotelRatePlanNotifValidator {
+	v := HotelRatePlanNotifValidator{
+		supplementMapping: map[string]struct{}{},
+		ratePlanMapping:   map[string]MasterRatePlan{},
+	}
+
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+func WithArrivalDOW() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsArrivalDOW = true
+	}
+}
+
+func WithDepartureDOW() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsDepartureDOW = true
+	}
+}
+
+func WithRatePlanMapping(mapping map[string]MasterRatePlan) HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.ratePlanMapping = mapping
+	}
+}
+
+func WithAdultOccupancy(occupancy RatePlanOccupancySettings) HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.adultOccupancy = occupancy
+	}
+}
+
+func WithChildOccupancy(occupancy RatePlanOccupancySettings) HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.childOccupancy = &occupancy
+	}
+}
+
+func WithRatePlanJoin() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsRatePlanJoin = true
+	}
+}
+
+func WithOverlay() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsOverlay = true
+	}
+}
+
+func WithGenericBookingRules() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsGenericBookingRules = true
+	}
+}
+
+func WithRoomTypeBookingRules() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsRoomTypeBokingRules = true
+	}
+}
+
+func WithRoomTypeCodes(mapping map[string]RoomTypeOccupancySettings) HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.roomTypeMapping = mapping
+	}
+}
+
+func WithSupplements() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsSupplements = true
+	}
+}
+
+func WithFreeNightOffer() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsFreeNightOffer = true
+	}
+}
+
+func WithFamilyOffer() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsFamilyOffer = true
+	}
+}
+
+func WithOfferRuleBookingOffset() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsOfferRuleBookingOffset = true
+	}
+}
+
+func WithOfferRuleDOWLOS() HotelRatePlanNotifValidatorFunc {
+	return func(v *HotelRatePlanNotifValidator) {
+		v.supportsOfferRuleDOWLOS = true
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) Validate(r HotelRatePlanNotifRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v *HotelRatePlanNotifValidator) ValidateAll(r HotelRatePlanNotifRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v *HotelRatePlanNotifValidator) validate(p common.Path, r HotelRatePlanNotifRQ) {
+	p = p.Elem("RatePlans")
+	p.Attr("HotelCode").Add(common.ValidateHotelCode(r.RatePlans.HotelCode))
+
+	if r.IsReset() {
+		v.validateRatePlansReset(p, r.RatePlans.RatePlans)
+	} else {
+		v.validateRatePlans(p, r.RatePlans.RatePlans)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlansReset(p common.Path, ratePlans []RatePlan) {
+	for i, ratePlan := range ratePlans {
+		v.validateRatePlanCode(p.Index("RatePlan", i), ratePlan.RatePlanCode)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlans(p common.Path, ratePlans []RatePlan) {
+	for i, ratePlan := range ratePlans {
+		v.validateRatePlan(p.Index("RatePlan", i), ratePlan)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlan(p common.Path, ratePlan RatePlan) {
+	v.validateRatePlanCode(p, ratePlan.RatePlanCode)
+	v.validateCurrencyCode(p, ratePlan.CurrencyCode)
+
+	usesJoinFeature := ratePlan.RatePlanQualifier != nil || ratePlan.RatePlanID != ""
+	if !v.supportsRatePlanJoin && usesJoinFeature {
+		p.Add(common.ErrRatePlanJoinNotSupported)
+		return
+	}
+
+	switch v.ratePlanNotifType = ratePlan.RatePlanNotifType; v.ratePlanNotifType {
+	case RatePlanNotifTypeFull, RatePlanNotifTypeNew:
+		v.validateRatePlanNew(p, ratePlan)
+	case RatePlanNotifTypeOverlay:
+		v.validateRatePlanOverlay(p, ratePlan)
+	case RatePlanNotifTypeRemove:
+		v.validateRatePlanRemove(p, ratePlan)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlanMasterCode(p common.Path, rp RatePlan) bool {
+	if !v.supportsRatePlanJoin {
+		return true
+	}
+
+	ok := true
+	if err := common.ValidateString(rp.RatePlanID); err != nil {
+		p.Attr("RatePlanID").Add(common.ErrMissingRatePlanID)
+		ok = false
+	}
+
+	if rp.RatePlanQualifier == nil {
+		p.Attr("RatePlanQualifier").Add(common.ErrMissingRatePlanQualifier)
+		ok = false
+	}
+
+	return ok
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlanCode(p common.Path, code string) {
+	if err := common.ValidateString(code); err != nil {
+		p.Attr("RatePlanCode").Add(common.ErrMissingRatePlanCode)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateCurrencyCode(p common.Path, code string) {
+	if err := common.ValidateString(code); err != nil {
+		p.Attr("CurrencyCode").Add(common.ErrMissingCurrencyCode)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlanNew(p common.Path, ratePlan RatePlan) {
+	if !v.validateRatePlanMasterCode(p, ratePlan) {
+		return
+	}
+
+	if ratePlan.IsMaster() {
+		v.validateRatePlanNewMaster(p, ratePlan)
+	} else {
+		v.validateRatePlanNewDerived(p, ratePlan)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlanNewMaster(p common.Path, ratePlan RatePlan) {
+	v.validateOffers(p.Elem("Offers"), ratePlan.Offers)
+	v.validateDescriptions(p.Elem("Description"), ratePlan.Descriptions)
+	v.validateBookingRules(p.Elem("BookingRules"), ratePlan.BookingRules)
+	v.validateRates(p.Elem("Rates"), ratePlan.Rates)
+	v.validateSupplements(p.Elem("Supplements"), ratePlan.Supplements)
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlanNewDerived(p common.Path, ratePlan RatePlan) {
+	if _, ok := v.ratePlanMapping[ratePlan.RatePlanID]; !ok {
+		p.Attr("RatePlanID").AddValue(common.ErrRatePlanNotFound(ratePlan.RatePlanID), ratePlan.RatePlanID)
+		return
+	}
+
+	v.validateDerivedChargeType(p, ratePlan)
+	v.validateMealTypeUniqueness(p, ratePlan)
+	v.validateBookingRules(p.Elem("BookingRules"), ratePlan.BookingRules)
+	v.validateRates(p.Elem("Rates"), ratePlan.Rates)
+	v.validateDateDependingSupplements(p.Elem("Supplements"), ratePlan.Supplements)
+
+	if len(ratePlan.Offers) > 0 {
+		p.Elem("Offers").Add(common.ErrUnexpectedOffers)
+	}
+
+	if !ratePlan.Descriptions.isZero() {
+		p.Elem("Description").Add(common.ErrUnexpectedDescription)
+	}
+}
+
+// validateDerivedChargeType validates that a derived rate plan's charge type matches the master rate plan's charge type.
+func (v *HotelRatePlanNotifValidator) validateDerivedChargeType(p common.Path, ratePlan RatePlan) {
+	if len(ratePlan.Rates) == 0 || len(ratePlan.Rates[0].BaseByGuestAmts) == 0 {
+		return
+	}
+
+	derivedChargeType := ratePlan.Rates[0].BaseByGuestAmts[0].Type
+	if derivedChargeType == nil {
+		return
+	}
+
+	master, ok := v.ratePlanMapping[ratePlan.RatePlanID]
+	if !ok {
+		return
+	}
+
+	if *derivedChargeType != master.ChargeType {
+		p.Elem("Rates").Index("Rate", 0).Elem("BaseByGuestAmts").Index("BaseByGuestAmt", 0).Attr("Type").
+			AddValue(common.ErrChargeTypeMismatch, *derivedChargeType)
+	}
+}
+
+// validateMealTypeUniqueness validates that no other rate plan code under the same master has the same meal type.
+func (v *HotelRatePlanNotifValidator) validateMealTypeUniqueness(p common.Path, ratePlan RatePlan) {
+	if len(ratePlan.Rates) == 0 || ratePlan.Rates[0].MealsIncluded == nil {
+		return
+	}
+
+	mealType := ratePlan.Rates[0].MealsIncluded.MealPlanCodes
+
+	master, ok := v.ratePlanMapping[ratePlan.RatePlanID]
+	if !ok {
+		return
+	}
+
+	for existingCode, existingMealType := range master.DerivedPlans {
+		if existingCode == ratePlan.RatePlanCode {
+			continue
+		}
+
+		if existingMealType == mealType {
+			p.Elem("Rates").Index("Rate", 0).Elem("MealsIncluded").Attr("MealPlanCodes").
+				AddValue(common.ErrDuplicateMealType(existingCode, int(mealType)), mealType)
+			return
+		}
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateOffers(p common.Path, offers []Offer) {
+	if len(offers) == 0 {
+		p.Add(common.ErrMissingOfferRule)
+		return
+	}
+
+	v.validateOfferRule(p.Index("Offer", 0).Elem("OfferRules").Elem("OfferRule"), offers[0].OfferRule)
+	v.validateAdditionalOffers(p, offers)
+}
+
+func (v *HotelRatePlanNotifValidator) validateOfferRule(p common.Path, offerRule *OfferRule) {
+	if offerRule == nil {
+		p.Add(common.ErrMissingOfferRule)
+		return
+	}
+
+	if !v.supportsOfferRuleBookingOffset &&
+		(offerRule.MinAdvancedBookingOffset != nil || offerRule.MaxAdvancedBookingOffset != nil) {
+		p.Add(common.ErrOfferRuleBookingOffsetNotSupported)
+	}
+
+	if !v.supportsOfferRuleDOWLOS &&
+		(len(offerRule.LengthsOfStay) > 0 ||
+			offerRule.ArrivalDaysOfWeek != nil ||
+			offerRule.DepartureDaysOfWeek != nil) {
+		p.Add(common.ErrOfferRuleDOWLOSNotSupported)
+	}
+
+	v.validateOfferRuleLengthsOfStay(p.Elem("LengthsOfStay"), offerRule)
+	v.validateOccupancies(p, offerRule.Occupancies)
+}
+
+func (v *HotelRatePlanNotifValidator) validateOfferRuleLengthsOfStay(p common.Path, offerRule *OfferRule) {
+	var minArrival int
+	var maxArrival int
+	for i, los := range offerRule.LengthsOfStay {
+		switch los.MinMaxMessageType {
+		case StayTypeMinArrival:
+			minArrival = los.Time
+		case StayTypeMaxArrival:
+			maxArrival = los.Time
+		case StayTypeMinThrough, StayTypeMaxThrough:
+			p.Index("LengthOfStay", i).Attr("MinMaxMessageType").
+				AddValue(common.ErrStayThroughNotAllowedInOfferRule, los.MinMaxMessageType)
+			return
+		}
+	}
+
+	if maxArrival > 0 && minArrival > maxArrival {
+		p.Add(common.ErrMinStayArrivalGratherThanMaxStayArrival(minArrival, maxArrival))
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateOccupancies(p common.Path, occupancies []Occupancy) {
+	adults := common.IndexesFunc(occupancies, Occupancy.isAdult)
+	switch len(adults) {
+	case 0:
+		p.Add(common.ErrMissingAdultOccupancy)
+		return
+	case 1:
+		adultOccupancy := occupancies[adults[0]]
+		v.validateOccupancy(p.Index("Occupancy", adults[0]), adultOccupancy)
+		v.populateAdultOccupancy(adultOccupancy)
+	}
+
+	children := common.IndexesFunc(occupancies, Occupancy.isChild)
+	switch len(children) {
+	case 0:
+		break
+	case 1:
+		childOccupancy := occupancies[children[0]]
+		v.validateOccupancy(p.Index("Occupancy", children[0]), childOccupancy)
+		v.populateChildOccupancy(childOccupancy)
+	default:
+		p.Index("Occupancy", children[1]).Add(common.ErrDuplicateChildOccupancy)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateOccupancy(p common.Path, o Occupancy) {
+	if min := o.MinOccupancy; min != nil && *min > 99 {
+		p.Attr("MinOccupancy").AddValue(common.ErrInvalidMinOccupancy, *min)
+	}
+	if max := o.MaxOccupancy; max != nil && *max > 99 {
+		p.Attr("MaxOccupancy").AddValue(common.ErrInvalidMaxOccupancy, *max)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) populateAdultOccupancy(occupancy Occupancy) {
+	v.adultOccupancy.Min = occupancy.MinOccupancy
+	v.adultOccupancy.Max = occupancy.MaxOccupancy
+	v.adultOccupancy.MinAge = occupancy.MinAge
+}
+
+func (v *HotelRatePlanNotifValidator) populateChildOccupancy(occupancy Occupancy) {
+	v.childOccupancy = &RatePlanOccupancySettings{
+		Min:    occupancy.MinOccupancy,
+		Max:    occupancy.MaxOccupancy,
+		MinAge: occupancy.MinAge,
+	}
+}
+
+// validateAdditionalOffers validates the offers following the first one, which
+// holds the offer rule.
+func (v *HotelRatePlanNotifValidator) validateAdditionalOffers(p common.Path, offers []Offer) {
+	additional := offers[1:]
+	offer := func(i int) common.Path {
+		return p.Index("Offer", i+1)
+	}
+
+	freeNightOffers := common.IndexesFunc(additional, Offer.IsFreeNightOffer)
+	switch len(freeNightOffers) {
+	case 0:
+		break
+	case 1:
+		v.validateFreeNightOffer(offer(freeNightOffers[0]), additional[freeNightOffers[0]])
+	default:
+		offer(freeNightOffers[1]).Add(common.ErrDuplicateFreeNightOffer)
+	}
+
+	familyOffers := common.IndexesFunc(additional, Offer.IsFamilyOffer)
+	switch len(familyOffers) {
+	case 0:
+		break
+	case 1:
+		v.validateFamilyOffer(offer(familyOffers[0]), additional[familyOffers[0]])
+	default:
+		offer(familyOffers[1]).Add(common.ErrDuplicateFamilyOffer)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateFreeNightOffer(p common.Path, offer Offer) {
+	if !v.supportsFreeNightOffer {
+		p.Add(common.ErrFreeNightOfferNotSupported)
+		return
+	}
+
+	discount := p.Elem("Discount")
+	if offer.Discount.NightsRequired == 0 {
+		discount.Attr("NightsRequired").Add(common.ErrMissingNightsRequired)
+	}
+
+	if offer.Discount.NightsDiscounted == 0 {
+		discount.Attr("NightsDiscounted").Add(common.ErrMissingNightsDiscounted)
+	}
+
+	if pattern := offer.Discount.DiscountPattern; pattern != "" {
+		expectedPattern := internal.CalculateDiscountPattern(
+			offer.Discount.NightsRequired,
+			offer.Discount.NightsDiscounted,
+		)
+		if pattern != expectedPattern {
+			discount.Attr("DiscountPattern").AddValue(common.ErrInvalidDiscountPattern, pattern)
+		}
+	}
+
+	if offer.Guest != nil {
+		p.Elem("Guests").Elem("Guest").Add(common.ErrUnexpectedGuest)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateFamilyOffer(p common.Path, offer Offer) {
+	if !v.supportsFamilyOffer {
+		p.Add(common.ErrFamilyOfferNotSupported)
+		return
+	}
+
+	guest := p.Elem("Guests").Elem("Guest")
+	if offer.Guest.AgeQualifyingCode != AgeQualifyingCodeChild {
+		guest.Attr("AgeQualifyingCode").AddValue(common.ErrInvalidGuestAgeQualifyngCode, offer.Guest.AgeQualifyingCode)
+	}
+
+	if v.childOccupancy != nil && v.childOccupancy.MinAge != nil && offer.Guest.MaxAge <= *v.childOccupancy.MinAge {
+		guest.Attr("MaxAge").AddValue(common.ErrFamilyOfferMaxAgeTooLow(offer.Guest.MaxAge, *v.childOccupancy.MinAge), offer.Guest.MaxAge)
+	}
+
+	discount := p.Elem("Discount")
+	if offer.Discount.NightsRequired > 0 {
+		discount.Attr("NightsRequired").Add(common.ErrUnexpectedNightsRequired)
+	}
+
+	if offer.Discount.NightsDiscounted > 0 {
+		discount.Attr("NightsDiscounted").Add(common.ErrUnexpectedNightsDiscounted)
+	}
+
+	if offer.Discount.DiscountPattern != "" {
+		discount.Attr("DiscountPattern").Add(common.ErrUnexpectedDiscountPattern)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateDescriptions(p common.Path, d RatePlanDescription) {
+	p.Add(common.ValidateLanguageUniqueness(d.Titles))
+	p.Add(common.ValidateLanguageUniqueness(d.Intros))
+	p.Add(common.ValidateLanguageUniqueness(d.Descriptions))
+
+	for _, item := range d.Gallery {
+		p.Add(common.ValidateLanguageUniqueness(item.Descriptions))
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateBookingRules(p common.Path, bookingRules []BookingRule) {
+	for i, bookgingRule := range bookingRules {
+		v.validateBookingRule(p.Index("BookingRule", i), bookgingRule)
+	}
+
+	v.validateBookingRuleOverlaps(p, bookingRules)
+}
+
+func (v *HotelRatePlanNotifValidator) validateBookingRule(p common.Path, bookingRule BookingRule) {
+	if v.supportsRoomTypeBokingRules {
+		if err := common.ValidateString(bookingRule.Code); err != nil {
+			p.Attr("Code").Add(common.ErrMissingCode)
+		} else if _, ok := v.roomTypeMapping[bookingRule.Code]; !ok {
+			p.Attr("Code").AddValue(common.ErrInvTypeCodeNotFound(bookingRule.Code), bookingRule.Code)
+		}
+	} else if v.supportsGenericBookingRules {
+		if bookingRule.Code != "" || bookingRule.CodeContext != "" {
+			p.Add(common.ErrRoomTypeBookingRulesNotSupported)
+		}
+	}
+
+	if bookingRule.Start.After(bookingRule.End) {
+		p.Attr("Start").AddValue(common.ErrStartAfterEnd, bookingRule.Start)
+	}
+
+	v.validateLengthsOfStay(p.Elem("LengthsOfStay"), bookingRule.LengthsOfStay)
+
+	if !v.supportsArrivalDOW && bookingRule.ArrivalDaysOfWeek != nil {
+		p.Elem("DOW_Restrictions").Elem("ArrivalDaysOfWeek").Add(common.ErrArrivalDOWNotSupported)
+	}
+
+	if !v.supportsDepartureDOW && bookingRule.DepartureDaysOfWeek != nil {
+		p.Elem("DOW_Restrictions").Elem("DepartureDaysOfWeek").Add(common.ErrDepartureDOWNotSupported)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateLengthsOfStay(p common.Path, lengthsOfStay []LengthOfStay) {
+	minArrival, minThrough := 1, 1
+	maxArrival, maxThrough := math.MaxInt32, math.MaxInt32
+
+	for _, lengthOfStay := range lengthsOfStay {
+		switch lengthOfStay.MinMaxMessageType {
+		case StayTypeMinArrival:
+			minArrival = lengthOfStay.Time
+		case StayTypeMaxArrival:
+			maxArrival = lengthOfStay.Time
+		case StayTypeMinThrough:
+			minThrough = lengthOfStay.Time
+		case StayTypeMaxThrough:
+			maxThrough = lengthOfStay.Time
+		}
+	}
+
+	min := int(math.Max(float64(minArrival), float64(minThrough)))
+	max := int(math.Min(float64(maxArrival), float64(maxThrough)))
+	if min > max {
+		p.Add(common.ErrMinStayGratherThanMaxStay(min, max))
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateBookingRuleOverlaps(p common.Path, bookingRules []BookingRule) {
+	if v.supportsRoomTypeBokingRules {
+		bookingRulesByRoomType := slicesx.GroupByFunc(bookingRules, func(b BookingRule) string {
+			return b.Code
+		})
+		for _, brs := range bookingRulesByRoomType {
+			p.Add(common.ValidateOverlaps(brs))
+		}
+	} else if v.supportsGenericBookingRules {
+		p.Add(common.ValidateOverlaps(bookingRules))
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRates(p common.Path, rates []Rate) {
+	if len(rates) == 0 {
+		p.Add(common.ErrMissingStaticRate)
+		return
+	}
+
+	v.validateStaticRate(p.Index("Rate", 0), rates[0])
+	v.validateDateDependingRates(p, rates, 1)
+}
+
+func (v *HotelRatePlanNotifValidator) validateStaticRate(p common.Path, rate Rate) {
+	if rate.RateTimeUnit != nil && *rate.RateTimeUnit != TimeUnitDay {
+		p.Attr("RateTimeUnit").AddValue(common.ErrInvalidRateTimeUnit, *rate.RateTimeUnit)
+	}
+
+	b := p.Elem("BaseByGuestAmts")
+	switch len(rate.BaseByGuestAmts) {
+	case 0:
+		b.Add(common.ErrMissingBaseByGuestAmt)
+	case 1:
+		b := b.Index("BaseByGuestAmt", 0)
+		amt := rate.BaseByGuestAmts[0]
+		if amt.NumberOfGuests != nil {
+			b.Attr("NumberOfGuests").Add(common.ErrUnexpectedNumberOfGuests)
+		}
+		if amt.AgeQualifyingCode != nil {
+			b.Attr("AgeQualifyingCode").Add(common.ErrUnexpectedAgeQualifyingCode)
+		}
+		if amt.AmountAfterTax != nil {
+			b.Attr("AmountAfterTax").Add(common.ErrUnexpectedAmountAfterTax)
+		}
+	default:
+		b.Index("BaseByGuestAmt", 1).Add(common.ErrUnexpectedBaseByGuestAmt)
+	}
+
+	if rate.MealsIncluded == nil {
+		p.Elem("MealsIncluded").Add(common.ErrMissingMealsIncluded)
+	}
+
+	if rate.InvTypeCode != "" {
+		p.Attr("InvTypeCode").Add(common.ErrUnexpectedInvTypeCode)
+	}
+
+	if rate.Start != nil {
+		p.Attr("Start").Add(common.ErrUnexpectedStart)
+	}
+
+	if rate.End != nil {
+		p.Attr("End").Add(common.ErrUnexpectedEnd)
+	}
+
+	if len(rate.AdditionalGuestAmounts) > 0 {
+		p.Elem("AdditionalGuestAmounts").Add(common.ErrUnexpectedAdditionalGuestAmounts)
+	}
+}
+
+// validateDateDependingRates validates rates[first:].
+func (v *HotelRatePlanNotifValidator) validateDateDependingRates(p common.Path, rates []Rate, first int) {
+	for i := first; i < len(rates); i++ {
+		v.validateDateDependingRate(p.Index("Rate", i), rates[i])
+	}
+
+	v.validateDateDependingRateOverlaps(p, rates[first:])
+}
+
+func (v *HotelRatePlanNotifValidator) validateDateDependingRate(p common.Path, rate Rate) {
+	if err := common.ValidateString(rate.InvTypeCode); err != nil {
+		p.Attr("InvTypeCode").Add(common.ErrMissingInvTypeCode)
+		return
+	}
+
+	roomTypeOccupancySettings, ok := v.roomTypeMapping[rate.InvTypeCode]
+	if !ok {
+		p.Attr("InvTypeCode").AddValue(common.ErrInvTypeCodeNotFound(rate.InvTypeCode), rate.InvTypeCode)
+		return
+	}
+
+	if rate.Start == nil {
+		p.Attr("Start").Add(common.ErrMissingStart)
+	}
+
+	if rate.End == nil {
+		p.Attr("End").Add(common.ErrMissingEnd)
+	}
+
+	if rate.Start != nil && rate.End != nil && rate.Start.After(*rate.End) {
+		p.Attr("Start").AddValue(common.ErrStartAfterEnd, *rate.Start)
+	}
+
+	v.validateBaseByGuestAmts(p.Elem("BaseByGuestAmts"), rate.BaseByGuestAmts, roomTypeOccupancySettings)
+	v.validateAdditionalGuestAmounts(p.Elem("AdditionalGuestAmounts"), rate.AdditionalGuestAmounts)
+
+	if rate.RateTimeUnit != nil {
+		p.Attr("RateTimeUnit").Add(common.ErrUnexpectedRateTimeUnit)
+	}
+
+	if rate.UnitMultiplier > 0 {
+		p.Attr("UnitMultiplier").Add(common.ErrUnexpectedUnitMultiplier)
+	}
+
+	if rate.MealsIncluded != nil {
+		p.Elem("MealsIncluded").Add(common.ErrUnexpectedMealsIncluded)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateBaseByGuestAmts(p common.Path, baseByGuestAmts []BaseByGuestAmt, roomTypeOccupancySettings RoomTypeOccupancySettings) {
+	numberOfGuestSeen := make(map[int]struct{})
+	stdOccupancySeen := false
+	for i, baseByGuestAmt := range baseByGuestAmts {
+		p := p.Index("BaseByGuestAmt", i)
+		if !v.validateBaseByGuestAmt(p, baseByGuestAmt) {
+			continue
+		}
+
+		numberOfGuests := *baseByGuestAmt.NumberOfGuests
+		if _, exists := numberOfGuestSeen[numberOfGuests]; exists {
+			p.Attr("NumberOfGuests").AddValue(common.ErrDuplicateBaseByGuestAmt(numberOfGuests), numberOfGuests)
+		}
+		numberOfGuestSeen[numberOfGuests] = struct{}{}
+
+		if numberOfGuests == roomTypeOccupancySettings.Std {
+			stdOccupancySeen = true
+		}
+	}
+
+	isStdOccupancyRequired := v.ratePlanNotifType.IsFull()
+	if isStdOccupancyRequired && !stdOccupancySeen {
+		p.Add(common.ErrMissingBaseByGuestAmtWithStdOccupancy(roomTypeOccupancySettings.Std))
+	}
+}
+
+// validateBaseByGuestAmt reports whether NumberOfGuests is present, which the
+// checks across all amounts depend on.
+func (v *HotelRatePlanNotifValidator) validateBaseByGuestAmt(p common.Path, baseByGuestAmt BaseByGuestAmt) bool {
+	if baseByGuestAmt.NumberOfGuests == nil {
+		p.Attr("NumberOfGuests").Add(common.ErrMissingNumberOfGuests)
+	}
+
+	if baseByGuestAmt.AgeQualifyingCode == nil {
+		p.Attr("AgeQualifyingCode").Add(common.ErrMissingAgeQualifyingCode)
+	}
+
+	if baseByGuestAmt.AmountAfterTax == nil {
+		p.Attr("AmountAfterTax").Add(common.ErrMissingAmountAfterTax)
+	}
+
+	if baseByGuestAmt.Type != nil {
+		p.Attr("Type").Add(common.ErrUnexpectedType)
+	}
+
+	return baseByGuestAmt.NumberOfGuests != nil
+}
+
+func (v *HotelRatePlanNotifValidator) validateAdditionalGuestAmounts(p common.Path, additionalGuestAmounts []AdditionalGuestAmount) {
+	adults := common.IndexesFunc(additionalGuestAmounts, AdditionalGuestAmount.IsAdult)
+	switch len(adults) {
+	case 0:
+		break
+	case 1:
+		if additionalGuestAmounts[adults[0]].Amount == nil {
+			p.Index("AdditionalGuestAmount", adults[0]).Attr("Amount").Add(common.ErrMissingAmount)
+		}
+	default:
+		p.Index("AdditionalGuestAmount", adults[1]).Add(common.ErrDuplicateAdditionalGuestAmountAdult)
+	}
+
+	children := common.IndexesFunc(additionalGuestAmounts, AdditionalGuestAmount.IsChild)
+	if v.childOccupancy == nil && len(children) > 0 {
+		p.Index("AdditionalGuestAmount", children[0]).Add(common.ErrChildrenNotAllowed)
+		return
+	}
+	for _, i := range children {
+		p := p.Index("AdditionalGuestAmount", i)
+		child := additionalGuestAmounts[i]
+		if child.MinAge == nil && child.MaxAge == nil {
+			p.Attr("MinAge").Add(common.ErrMissingMinAge)
+		}
+
+		if child.MinAge != nil && child.MaxAge != nil && *child.MinAge >= *child.MaxAge {
+			p.Attr("MinAge").AddValue(common.ErrMinAgeGreaterThanOrEqualsThanMaxAge, *child.MinAge)
+		}
+
+		if v.childOccupancy.MinAge != nil && child.MinAge != nil && *child.MinAge < *v.childOccupancy.MinAge {
+			p.Attr("MinAge").AddValue(common.ErrMinAgeOutOfRange(*child.MinAge, *v.childOccupancy.Min), *child.MinAge)
+		}
+
+		if v.adultOccupancy.MinAge != nil && child.MaxAge != nil && *child.MaxAge > *v.adultOccupancy.MinAge {
+			p.Attr("MaxAge").AddValue(common.ErrMaxAgeOutOfRange(*child.MaxAge, *v.adultOccupancy.MinAge), *child.MaxAge)
+		}
+
+		if child.Amount == nil {
+			p.Attr("Amount").Add(common.ErrMissingAmount)
+		}
+	}
+
+	childAmounts := make([]AdditionalGuestAmount, len(children))
+	for i, j := range children {
+		childAmounts[i] = additionalGuestAmounts[j]
+	}
+	v.validateAgeRangeOverlaps(p, childAmounts)
+}
+
+func (v *HotelRatePlanNotifValidator) validateAgeRangeOverlaps(p common.Path, ageRanges []AdditionalGuestAmount) {
+	minMaxAge := func(a AdditionalGuestAmount) (min int, max int) {
+		min = 0
+		if a.MinAge != nil {
+			min = *a.MinAge
+		}
+
+		max = 18
+		if a.MaxAge != nil {
+			// MaxAge is exclusive
+			max = *a.MaxAge - 1
+		}
+
+		return
+	}
+
+	sortAdditionalGuestAmountsByAge(ageRanges)
+
+	for i := 0; i < len(ageRanges)-1; i++ {
+		min1, max1 := minMaxAge(ageRanges[i])
+		min2, max2 := minMaxAge(ageRanges[i+1])
+		if max1 >= min2 {
+			p.Add(common.ErrAgeRangeOverlaps(min1, max1, min2, max2))
+			return
+		}
+	}
+}
+
+func sortAdditionalGuestAmountsByAge(amounts []AdditionalGuestAmount) {
+	slices.SortFunc(amounts, func(a, b AdditionalGuestAmount) int {
+		// Compare MinAge first (nil treated as 0)
+		minA := 0
+		if a.MinAge != nil {
+			minA = *a.MinAge
+		}
+		minB := 0
+		if b.MinAge != nil {
+			minB = *b.MinAge
+		}
+
+		if minA != minB {
+			return cmp.Compare(minA, minB)
+		}
+
+		// If MinAge is equal, compare MaxAge (nil treated as infinity)
+		maxA := math.MaxInt32
+		if a.MaxAge != nil {
+			maxA = *a.MaxAge
+		}
+		maxB := math.MaxInt32
+		if b.MaxAge != nil {
+			maxB = *b.MaxAge
+		}
+
+		return cmp.Compare(maxA, maxB)
+	})
+}
+
+func (v *HotelRatePlanNotifValidator) validateDateDependingRateOverlaps(p common.Path, rates []Rate) {
+	ratesByInvTypeCode := slicesx.GroupByFunc(rates, func(r Rate) string {
+		return r.InvTypeCode
+	})
+	for _, rates := range ratesByInvTypeCode {
+		p.Add(common.ValidateOverlaps(rates))
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateSupplements(p common.Path, supplements []Supplement) {
+	if !v.supportsSupplements && len(supplements) > 0 {
+		p.Add(common.ErrSupplementsNotSupported)
+		return
+	}
+
+	for _, i := range common.IndexesFunc(supplements, Supplement.isStaticSupplement) {
+		v.validateStaticSupplement(p.Index("Supplement", i), supplements[i])
+		v.supplementMapping[supplements[i].InvCode] = struct{}{}
+	}
+
+	dateDepending := common.IndexesFunc(supplements, Supplement.isDateDependingSupplement)
+	for _, i := range dateDepending {
+		v.validateDateDependingSupplement(p.Index("Supplement", i), supplements[i])
+	}
+
+	dateDependingSupplements := make([]Supplement, len(dateDepending))
+	for i, j := range dateDepending {
+		dateDependingSupplements[i] = supplements[j]
+	}
+	v.validateDateDependingSupplementsOverlaps(p, dateDependingSupplements)
+}
+
+func (v *HotelRatePlanNotifValidator) validateStaticSupplement(p common.Path, supplement Supplement) {
+	if supplement.AddToBasicRateIndicator == nil {
+		p.Attr("AddToBasicRateIndicator").Add(common.ErrMissingAddToBasicRateIndicator)
+	}
+
+	if supplement.MandatoryIndicator == nil {
+		p.Attr("MandatoryIndicator").Add(common.ErrMissingMandatoryIndicator)
+	}
+
+	if supplement.ChargeTypeCode == nil {
+		p.Attr("ChargeTypeCode").Add(common.ErrMissingChargeTypeCode)
+	}
+
+	if pi := supplement.PrerequisiteInventory; pi != nil {
+		switch pi.InvType {
+		case PrerequisiteInventoryInvTypeAlpineBitsDOW:
+			match, _ := regexp.MatchString("[0-1]{7}", pi.InvCode)
+			if !match {
+				p.Elem("PrerequisiteInventory").Attr("InvCode").AddValue(common.ErrInvalidDOWString, pi.InvCode)
+			}
+		default:
+			p.Elem("PrerequisiteInventory").Attr("InvType").AddValue(common.ErrInvalidInvType(string(pi.InvType)), pi.InvType)
+		}
+	}
+
+	if d := supplement.Descriptions; d != nil {
+		v.validateDescriptions(p.Elem("Description"), *d)
+	}
+
+	if supplement.Amount != nil {
+		p.Attr("Amount").Add(common.ErrUnexpectedAmount)
+	}
+
+	if supplement.Start != nil {
+		p.Attr("Start").Add(common.ErrUnexpectedStart)
+	}
+
+	if supplement.End != nil {
+		p.Attr("End").Add(common.ErrUnexpectedEnd)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateDateDependingSupplements(p common.Path, supplements []Supplement) {
+	for i, supplement := range supplements {
+		v.validateDateDependingSupplement(p.Index("Supplement", i), supplement)
+	}
+
+	v.validateDateDependingSupplementsOverlaps(p, supplements)
+}
+
+func (v *HotelRatePlanNotifValidator) validateDateDependingSupplement(p common.Path, supplement Supplement) {
+	if err := common.ValidateString(supplement.InvCode); err != nil {
+		p.Attr("InvCode").Add(common.ErrMissingInvCode)
+	} else if _, ok := v.supplementMapping[supplement.InvCode]; !ok {
+		p.Attr("InvCode").AddValue(common.ErrInvCodeNotFound(supplement.InvCode), supplement.InvCode)
+	}
+
+	if supplement.Start == nil {
+		p.Attr("Start").Add(common.ErrMissingStart)
+	}
+
+	if supplement.End == nil {
+		p.Attr("End").Add(common.ErrMissingEnd)
+	}
+
+	if supplement.Start != nil && supplement.End != nil && supplement.Start.After(*supplement.End) {
+		p.Attr("Start").AddValue(common.ErrStartAfterEnd, *supplement.Start)
+	}
+
+	if pi := supplement.PrerequisiteInventory; pi != nil {
+		switch pi.InvType {
+		case PrerequisiteInventoryInvTypeRoomType:
+			if _, ok := v.roomTypeMapping[pi.InvCode]; !ok {
+				p.Elem("PrerequisiteInventory").Attr("InvCode").AddValue(common.ErrInvCodeNotFound(pi.InvCode), pi.InvCode)
+			}
+		default:
+			p.Elem("PrerequisiteInventory").Attr("InvType").AddValue(common.ErrInvalidInvType(string(pi.InvType)), pi.InvType)
+		}
+	}
+
+	if supplement.AddToBasicRateIndicator != nil {
+		p.Attr("AddToBasicRateIndicator").Add(common.ErrUnexpectedAddToBasicRateIndicator)
+	}
+
+	if supplement.MandatoryIndicator != nil {
+		p.Attr("MandatoryIndicator").Add(common.ErrUnexpectedAddToBasicRateIndicator)
+	}
+
+	if supplement.ChargeTypeCode != nil {
+		p.Attr("ChargeTypeCode").Add(common.ErrUnexpectedAddToBasicRateIndicator)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateDateDependingSupplementsOverlaps(p common.Path, supplements []Supplement) {
+	type key struct {
+		SupplementCode string
+		InvTypeCode    string
+	}
+	supplementsByInvCode := slicesx.GroupByFunc(supplements, func(s Supplement) key {
+		key := key{
+			SupplementCode: s.InvCode,
+		}
+		if s.PrerequisiteInventory != nil {
+			switch s.PrerequisiteInventory.InvType {
+			case PrerequisiteInventoryInvTypeRoomType:
+				key.InvTypeCode = s.PrerequisiteInventory.InvCode
+			}
+		}
+		return key
+	})
+	for _, supplements := range supplementsByInvCode {
+		p.Add(common.ValidateOverlaps(supplements))
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlanOverlay(p common.Path, ratePlan RatePlan) {
+	if !v.supportsOverlay {
+		p.Attr("RatePlanNotifType").AddValue(common.ErrDeltasNotSupported, ratePlan.RatePlanNotifType)
+		return
+	}
+
+	if ratePlan.RatePlanID != "" {
+		if _, ok := v.ratePlanMapping[ratePlan.RatePlanID]; !ok {
+			p.Attr("RatePlanID").AddValue(common.ErrRatePlanNotFound(ratePlan.RatePlanID), ratePlan.RatePlanID)
+			return
+		}
+	}
+
+	mealPlanSeen := false
+	for _, master := range v.ratePlanMapping {
+		if _, ok := master.DerivedPlans[ratePlan.RatePlanCode]; ok {
+			mealPlanSeen = true
+			break
+		}
+	}
+	if !mealPlanSeen {
+		p.Attr("RatePlanCode").AddValue(common.ErrRatePlanNotFound(ratePlan.RatePlanCode), ratePlan.RatePlanCode)
+		return
+	}
+
+	v.validateBookingRules(p.Elem("BookingRules"), ratePlan.BookingRules)
+	v.validateDateDependingRates(p.Elem("Rates"), ratePlan.Rates, 0)
+	v.validateDateDependingSupplements(p.Elem("Supplements"), ratePlan.Supplements)
+
+	if len(ratePlan.Offers) > 0 {
+		p.Elem("Offers").Add(common.ErrUnexpectedOffers)
+	}
+
+	if !ratePlan.Descriptions.isZero() {
+		p.Elem("Description").Add(common.ErrUnexpectedDescription)
+	}
+}
+
+func (v *HotelRatePlanNotifValidator) validateRatePlanRemove(p common.Path, ratePlan RatePlan) {
+	if len(ratePlan.Offers) > 0 {
+		p.Elem("Offers").Add(common.ErrUnexpectedOffers)
+	}
+
+	if !ratePlan.Descriptions.isZero() {
+		p.Elem("Description").Add(common.ErrUnexpectedDescription)
+	}
+
+	if len(ratePlan.BookingRules) > 0 {
+		p.Elem("BookingRules").Add(common.ErrUnexpectedBookingRules)
+	}
+
+	if len(ratePlan.Rates) > 0 {
+		p.Elem("Rates").Add(common.ErrUnexpectedRates)
+	}
+
+	if len(ratePlan.Supplements) > 0 {
+		p.Elem("Supplements").Add(common.ErrUnexpectedSupplements)
+	}
+}
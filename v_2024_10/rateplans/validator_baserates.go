@@ -0,0 +1,55 @@
+package rateplans
+
+import (
+	"strings"
+
+	"github.com/HGV/alpinebits/v_2024_10/common"
+)
+
+type HotelRatePlanValidator struct{}
+
+var _ common.Validatable[HotelRatePlanRQ] = (*HotelRatePlanValidator)(nil)
+var _ common.ValidatableAll[HotelRatePlanRQ] = (*HotelRatePlanValidator)(nil)
+
+type HotelRatePlanValidatorFunc func(*HotelRatePlanValidator)
+
+func NewHotelRatePlanValidator(opts ...HotelRatePlanValidatorFunc) HotelRatePlanValidator {
+	var v HotelRatePlanValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+func (v HotelRatePlanValidator) Validate(r HotelRatePlanRQ) error {
+	return common.Validate(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelRatePlanValidator) ValidateAll(r HotelRatePlanRQ) error {
+	return common.ValidateAll(func(p common.Path) { v.validate(p, r) })
+}
+
+func (v HotelRatePlanValidator) validate(p common.Path, r HotelRatePlanRQ) {
+	p = p.Elem("RatePlans").Elem("RatePlan")
+	p.Elem("HotelRef").Attr("HotelCode").Add(common.ValidateHotelCode(r.HotelCode()))
+
+	v.validateDateRanges(p, r.RatePlan.DateRanges)
+	v.validateRatePlanCandidates(p.Elem("RatePlanCandidates"), r.RatePlan.RatePlanCandidates)
+}
+
+func (v HotelRatePlanValidator) validateDateRanges(p common.Path, dateRanges []DateRange) {
+	for i, dateRange := range dateRanges {
+		start, end := dateRange.Start, dateRange.End
+		if start != nil && end != nil && start.After(*end) {
+			p.Index("DateRange", i).Attr("Start").AddValue(common.ErrStartAfterEnd, *start)
+		}
+	}
+}
+
+func (v HotelRatePlanValidator) validateRatePlanCandidates(p common.Path, candidates []RatePlanCandidate) {
+	for i, candidate := range candidates {
+		if strings.TrimSpace(candidate.RatePlanCode) == "" {
+			p.Index("RatePlanCandidate", i).Attr("RatePlanCode").Add(common.ErrMissingRatePlanCode)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package rateplans
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+
+	"github.com/HGV/alpinebits/internal/schema"
+	"github.com/HGV/alpinebits/v_2024_10/common"
+	"github.com/HGV/x/timex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelRatePlanValidator_Validate(t *testing.T) {
+	file := "test/data/RatePlans-OTA_HotelRatePlanRQ.xml"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		assert.NoError(t, err, "Failed to read file %s", file)
+	}
+
+	var rq HotelRatePlanRQ
+	if err := xml.Unmarshal(data, &rq); err != nil {
+		assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+	}
+
+	assert.Equal(t, "123", rq.HotelCode())
+	assert.Len(t, rq.RatePlan.RatePlanCandidates, 2)
+
+	v := NewHotelRatePlanValidator()
+	assert.NoError(t, v.Validate(rq))
+
+	start, _ := timex.ParseDate("2021-04-01")
+	invalid := rq
+	invalid.RatePlan.DateRanges = []DateRange{{Start: &start, End: rq.RatePlan.DateRanges[0].End}}
+	assert.ErrorIs(t, v.Validate(invalid), common.ErrStartAfterEnd)
+
+	invalid = rq
+	invalid.RatePlan.RatePlanCandidates = []RatePlanCandidate{{RatePlanID: "1"}}
+	assert.ErrorIs(t, v.Validate(invalid), common.ErrMissingRatePlanCode)
+
+	invalid = rq
+	invalid.RatePlan.HotelRef = HotelRef{}
+	assert.ErrorIs(t, v.Validate(invalid), common.ErrMissingHotelCode)
+}
+
+func TestHotelRatePlanRS_Unmarshal(t *testing.T) {
+	file := "test/data/RatePlans-OTA_HotelRatePlanRS.xml"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		assert.NoError(t, err, "Failed to read file %s", file)
+	}
+
+	var rs HotelRatePlanRS
+	if err := xml.Unmarshal(data, &rs); err != nil {
+		assert.NoError(t, err, "Failed to unmarshal data from file %s", file)
+	}
+
+	assert.NotNil(t, rs.Success)
+	assert.Equal(t, "123", rs.RatePlans.HotelCode)
+	assert.Len(t, rs.RatePlans.RatePlans, 1)
+	ratePlan := rs.RatePlans.RatePlans[0]
+	assert.Equal(t, "123456-xyz", ratePlan.RatePlanCode)
+	assert.Len(t, ratePlan.BookingRules, 1)
+	assert.Len(t, ratePlan.Rates, 1)
+	assert.Len(t, ratePlan.Rates[0].BaseByGuestAmts, 1)
+	assert.Len(t, ratePlan.Descriptions.Titles, 2)
+	assert.Len(t, ratePlan.Descriptions.Descriptions, 1)
+
+	xsd, err := os.ReadFile("../alpinebits.xsd")
+	if err != nil {
+		assert.NoError(t, err, "Failed to read schema")
+	}
+	s, err := schema.Parse(xsd)
+	assert.NoError(t, err)
+
+	b, err := xml.Marshal(rs)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate(string(b)))
+}
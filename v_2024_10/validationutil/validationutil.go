@@ -0,0 +1,186 @@
+package validationutil
+
+import (
+	"slices"
+
+	"github.com/HGV/alpinebits/v_2024_10"
+	"github.com/HGV/alpinebits/v_2024_10/activities"
+	"github.com/HGV/alpinebits/v_2024_10/freerooms"
+	"github.com/HGV/alpinebits/v_2024_10/guestrequests"
+	"github.com/HGV/alpinebits/v_2024_10/inventory"
+	"github.com/HGV/alpinebits/v_2024_10/rateplans"
+	"github.com/HGV/alpinebits/version"
+)
+
+func NewFreeRoomOptions(capabilities []string) []freerooms.HotelInvCountNotifValidatorFunc {
+	var options []freerooms.HotelInvCountNotifValidatorFunc
+
+	capabilityMap := map[v_2024_10.Capability]func() freerooms.HotelInvCountNotifValidatorFunc{
+		v_2024_10.CapabilityHotelInvCountNotifAcceptRooms:          freerooms.WithRooms,
+		v_2024_10.CapabilityHotelInvCountNotifAcceptRoomCategories: freerooms.WithCategories,
+		v_2024_10.CapabilityHotelInvCountNotifAcceptCompleteSet:    freerooms.WithCompleteSet,
+		v_2024_10.CapabilityHotelInvCountNotifAcceptDeltas:         freerooms.WithDeltas,
+		v_2024_10.CapabilityHotelInvCountNotifAcceptOutOfOrder:     freerooms.WithOutOfOrder,
+		v_2024_10.CapabilityHotelInvCountNotifAcceptOutOfMarket:    freerooms.WithOutOfMarket,
+		v_2024_10.CapabilityHotelInvCountNotifAcceptClosingSeasons: freerooms.WithClosingSeasons,
+	}
+
+	for cap, fn := range capabilityMap {
+		if slices.Contains(capabilities, string(cap)) {
+			options = append(options, fn())
+		}
+	}
+
+	return options
+}
+
+func NewInventoryOptions(capabilities []string) []inventory.HotelDescriptiveContentNotifValidatorFunc {
+	var options []inventory.HotelDescriptiveContentNotifValidatorFunc
+
+	capabilityMap := map[v_2024_10.Capability]func() inventory.HotelDescriptiveContentNotifValidatorFunc{
+		v_2024_10.CapabilityHotelDescriptiveContentNotifInventoryUseRooms:          inventory.WithRooms,
+		v_2024_10.CapabilityHotelDescriptiveContentNotifInventoryOccupancyChildren: inventory.WithOccupancyChildren,
+	}
+
+	for cap, fn := range capabilityMap {
+		if slices.Contains(capabilities, string(cap)) {
+			options = append(options, fn())
+		}
+	}
+
+	return options
+}
+
+func NewRatePlanOptions(capabilities []string) []rateplans.HotelRatePlanNotifValidatorFunc {
+	var options []rateplans.HotelRatePlanNotifValidatorFunc
+
+	capabilityMap := map[v_2024_10.Capability]func() rateplans.HotelRatePlanNotifValidatorFunc{
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptArrivalDOW:                  rateplans.WithArrivalDOW,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptDepartureDOW:                rateplans.WithDepartureDOW,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptRatePlanBookingRule:         rateplans.WithGenericBookingRules,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptRatePlanRoomTypeBookingRule: rateplans.WithRoomTypeBookingRules,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptSupplements:                 rateplans.WithSupplements,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptFreeNightsOffers:            rateplans.WithFreeNightOffer,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptFamilyOffers:                rateplans.WithFamilyOffer,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptOverlay:                     rateplans.WithOverlay,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptRatePlanJoin:                rateplans.WithRatePlanJoin,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptOfferRuleBookingOffset:      rateplans.WithOfferRuleBookingOffset,
+		v_2024_10.CapabilityHotelRatePlanNotifAcceptOfferRuleDOWLOS:             rateplans.WithOfferRuleDOWLOS,
+	}
+
+	for cap, fn := range capabilityMap {
+		if slices.Contains(capabilities, string(cap)) {
+			options = append(options, fn())
+		}
+	}
+
+	return options
+}
+
+// RequestValidator holds validator options that cannot be derived from
+// capabilities, such as the room and rate plan codes known for a hotel.
+type RequestValidator struct {
+	all           bool
+	freeRooms     []freerooms.HotelInvCountNotifValidatorFunc
+	guestRequests []guestrequests.ResRetrieveValidatorFunc
+	inventory     []inventory.HotelDescriptiveContentNotifValidatorFunc
+	ratePlans     []rateplans.HotelRatePlanNotifValidatorFunc
+}
+
+type RequestValidatorFunc func(*RequestValidator)
+
+// WithAllErrors reports all errors of a request as common.ValidationErrors
+// instead of the first one.
+func WithAllErrors() RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.all = true
+	}
+}
+
+func WithFreeRoomOptions(opts ...freerooms.HotelInvCountNotifValidatorFunc) RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.freeRooms = append(v.freeRooms, opts...)
+	}
+}
+
+func WithGuestRequestOptions(opts ...guestrequests.ResRetrieveValidatorFunc) RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.guestRequests = append(v.guestRequests, opts...)
+	}
+}
+
+func WithInventoryOptions(opts ...inventory.HotelDescriptiveContentNotifValidatorFunc) RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.inventory = append(v.inventory, opts...)
+	}
+}
+
+func WithRatePlanOptions(opts ...rateplans.HotelRatePlanNotifValidatorFunc) RequestValidatorFunc {
+	return func(v *RequestValidator) {
+		v.ratePlans = append(v.ratePlans, opts...)
+	}
+}
+
+// NewValidateFunc returns a function running the validator matching the
+// decoded request data, configured from the given capabilities and opts.
+// Requests without a validator are accepted as is.
+func NewValidateFunc(opts ...RequestValidatorFunc) func(action version.Action, data any, capabilities []string) error {
+	var v RequestValidator
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v.validate
+}
+
+// ValidateRequest validates the request data configured from capabilities
+// only.
+func ValidateRequest(action version.Action, data any, capabilities []string) error {
+	return RequestValidator{}.validate(action, data, capabilities)
+}
+
+// ValidateRequestAll is like ValidateRequest but reports all errors of the
+// request as common.ValidationErrors instead of the first one.
+func ValidateRequestAll(action version.Action, data any, capabilities []string) error {
+	return RequestValidator{all: true}.validate(action, data, capabilities)
+}
+
+func (v RequestValidator) validate(action version.Action, data any, capabilities []string) error {
+	switch rq := data.(type) {
+	case *freerooms.HotelInvCountNotifRQ:
+		opts := append(NewFreeRoomOptions(capabilities), v.freeRooms...)
+		return validate(v.all, freerooms.NewHotelInvCountNotifValidator(opts...), *rq)
+	case *guestrequests.ReadRQ:
+		return validate(v.all, guestrequests.ReadValidator{}, *rq)
+	case *guestrequests.HotelResNotifRQ:
+		return validate(v.all, guestrequests.NewHotelResNotifValidator(v.guestRequests...), *rq)
+	case *inventory.HotelDescriptiveContentNotifRQ:
+		if action == v_2024_10.ActionHotelDescriptiveContentNotifInfo {
+			return validate(v.all, inventory.NewHotelInfoValidator(), *rq)
+		}
+		opts := append(NewInventoryOptions(capabilities), v.inventory...)
+		return validate(v.all, inventory.NewHotelDescriptiveContentNotifValidator(opts...), *rq)
+	case *inventory.HotelDescriptiveInfoRQ:
+		return validate(v.all, inventory.NewHotelDescriptiveInfoValidator(), *rq)
+	case *rateplans.HotelRatePlanNotifRQ:
+		opts := append(NewRatePlanOptions(capabilities), v.ratePlans...)
+		validator := rateplans.NewHotelRatePlanNotifValidator(opts...)
+		return validate(v.all, &validator, *rq)
+	case *rateplans.HotelRatePlanRQ:
+		return validate(v.all, rateplans.NewHotelRatePlanValidator(), *rq)
+	case *activities.HotelPostEventNotifRQ:
+		return validate(v.all, activities.NewHotelPostEventNotifValidator(), *rq)
+	}
+	return nil
+}
+
+type validator[T any] interface {
+	Validate(T) error
+	ValidateAll(T) error
+}
+
+func validate[T any](all bool, v validator[T], rq T) error {
+	if all {
+		return v.ValidateAll(rq)
+	}
+	return v.Validate(rq)
+}
@@ -0,0 +1,36 @@
+package version
+
+// SchemaBackend selects the implementation validating documents against the
+// XSD of a version.
+type SchemaBackend string
+
+const (
+	// SchemaBackendLibxml2 validates with libxml2 and requires cgo.
+	SchemaBackendLibxml2 SchemaBackend = "libxml2"
+	// SchemaBackendPureGo validates with a Go implementation of the parts of
+	// XSD used by the AlpineBits schemas.
+	SchemaBackendPureGo SchemaBackend = "purego"
+)
+
+// Config holds the options of a version. The zero value uses libxml2 when
+// built with cgo and the pure-Go backend otherwise.
+type Config struct {
+	SchemaBackend SchemaBackend
+}
+
+type ConfigFunc func(*Config)
+
+func WithSchemaBackend(backend SchemaBackend) ConfigFunc {
+	return func(c *Config) {
+		c.SchemaBackend = backend
+	}
+}
+
+// NewConfig applies opts to an empty Config.
+func NewConfig(opts ...ConfigFunc) Config {
+	var c Config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
@@ -1,7 +1,10 @@
 package version
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
 	"regexp"
 
 	"github.com/HGV/x/timex"
@@ -13,6 +16,12 @@ type (
 
 		ValidateXML(xml string) error
 	}
+	BytesValidator interface {
+		ValidateXMLBytes(xml []byte) error
+	}
+	ReaderValidator interface {
+		ValidateXMLReader(r io.Reader) error
+	}
 	Action interface {
 		fmt.Stringer
 
@@ -25,8 +34,58 @@ type (
 	DateRangeProvider interface {
 		DateRange() timex.DateRange
 	}
+	UniqueIDProvider interface {
+		UniqueIDValue() string
+	}
+	EchoDataProvider interface {
+		EchoDataValue() string
+	}
+	ErrorResponder interface {
+		ErrorResponse(data any, err error) (any, bool)
+	}
+	ErrorLocalizer interface {
+		LocalizeError(err error, lang string) error
+	}
+	Decoder interface {
+		Decode(r io.Reader) (any, error)
+	}
+	MessageTypesProvider interface {
+		MessageTypes() (request, response reflect.Type)
+	}
 )
 
+// ErrTooManyRequests is passed to ErrorResponder and ErrorLocalizer for
+// requests above a rate limit, which render it as the version's OTA error.
+var ErrTooManyRequests = errors.New("too many requests")
+
+// ValidateXMLBytes validates xml with v, without copying it if v implements
+// BytesValidator.
+func ValidateXMLBytes(v interface{ ValidateXML(xml string) error }, xml []byte) error {
+	if bv, ok := v.(BytesValidator); ok {
+		return bv.ValidateXMLBytes(xml)
+	}
+	return v.ValidateXML(string(xml))
+}
+
+// ValidateXMLReader validates the document read from r with v, which reads it
+// as a whole unless v implements ReaderValidator.
+func ValidateXMLReader(v interface{ ValidateXML(xml string) error }, r io.Reader) error {
+	if rv, ok := v.(ReaderValidator); ok {
+		return rv.ValidateXMLReader(r)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return ValidateXMLBytes(v, b)
+}
+
+// MessageTypesOf returns the types of RQ and RS for implementations of
+// MessageTypesProvider.
+func MessageTypesOf[RQ, RS any]() (request, response reflect.Type) {
+	return reflect.TypeFor[RQ](), reflect.TypeFor[RS]()
+}
+
 func ValidateVersionString(s string) error {
 	matched, _ := regexp.MatchString(`^\d{4}-\d{2}\w?$`, s)
 	if !matched {
@@ -1,6 +1,8 @@
 package version
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,3 +34,30 @@ func TestValidateVersionString(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConfig(t *testing.T) {
+	assert.Equal(t, Config{}, NewConfig())
+	assert.Equal(t, Config{SchemaBackend: SchemaBackendPureGo}, NewConfig(WithSchemaBackend(SchemaBackendPureGo)))
+}
+
+// stringVersion implements only ValidateXML, like versions written before
+// BytesValidator and ReaderValidator.
+type stringVersion struct{ validated []string }
+
+func (v *stringVersion) String() string { return "2020-10" }
+
+func (v *stringVersion) ValidateXML(xml string) error {
+	v.validated = append(v.validated, xml)
+	if xml == "" {
+		return errors.New("empty")
+	}
+	return nil
+}
+
+func TestValidateXMLFallback(t *testing.T) {
+	v := new(stringVersion)
+	assert.NoError(t, ValidateXMLBytes(v, []byte("<a/>")))
+	assert.NoError(t, ValidateXMLReader(v, strings.NewReader("<b/>")))
+	assert.EqualError(t, ValidateXMLBytes(v, nil), "empty")
+	assert.Equal(t, []string{"<a/>", "<b/>", ""}, v.validated)
+}